@@ -0,0 +1,19 @@
+variable "k8s_cluster" {}
+
+provider "po" {
+  config_context_cluster = var.k8s_cluster
+}
+
+data "po_alert_catalog" "all" {
+  namespaces = ["monitoring"]
+  label_selector = "role=alert-rules"
+}
+
+resource "local_file" "handbook" {
+  filename = "${path.module}/alerts.md"
+  content = data.po_alert_catalog.all.markdown
+}
+
+output "duplicate_alerts" {
+  value = data.po_alert_catalog.all.duplicate_alerts
+}
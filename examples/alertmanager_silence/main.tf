@@ -0,0 +1,22 @@
+variable "k8s_cluster" {}
+variable "namespace" { default = "monitoring" }
+
+provider "po" {
+  config_context_cluster = var.k8s_cluster
+}
+
+resource "po_alertmanager_silence" "maintenance" {
+  alertmanager = "${var.namespace}/main"
+  matchers {
+    name = "namespace"
+    value = "database"
+  }
+  matchers {
+    name = "severity"
+    value = "critical"
+    is_equal = false
+  }
+  duration = "4h"
+  created_by = "ops"
+  comment = "Database upgrade"
+}
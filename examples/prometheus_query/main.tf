@@ -0,0 +1,15 @@
+variable "k8s_cluster" {}
+variable "namespace" { default = "monitoring" }
+
+provider "po" {
+  config_context_cluster = var.k8s_cluster
+}
+
+data "po_prometheus_query" "api_targets" {
+  prometheus = "${var.namespace}/k8s"
+  query = "count(up{job=\"api\"})"
+}
+
+output "api_targets" {
+  value = length(data.po_prometheus_query.api_targets.vector) > 0 ? data.po_prometheus_query.api_targets.vector[0].value : 0
+}
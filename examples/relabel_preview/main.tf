@@ -0,0 +1,34 @@
+data "po_relabel_preview" "pods" {
+  relabel_configs {
+    source_labels = ["__meta_kubernetes_namespace"]
+    regex = "kube-.*"
+    action = "drop"
+  }
+  relabel_configs {
+    source_labels = ["__meta_kubernetes_namespace", "__meta_kubernetes_pod_name"]
+    separator = "/"
+    target_label = "instance"
+  }
+  relabel_configs {
+    regex = "__meta_kubernetes_.*"
+    action = "labeldrop"
+  }
+
+  samples {
+    labels = {
+      __address__ = "10.0.0.1:8080"
+      __meta_kubernetes_namespace = "default"
+      __meta_kubernetes_pod_name = "api-0"
+    }
+  }
+  samples {
+    labels = {
+      __address__ = "10.0.0.2:8080"
+      __meta_kubernetes_namespace = "kube-system"
+    }
+  }
+}
+
+output "instances" {
+  value = [for r in data.po_relabel_preview.pods.results : lookup(r.labels, "instance", "dropped")]
+}
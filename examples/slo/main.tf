@@ -0,0 +1,38 @@
+variable "k8s_cluster" {}
+variable "namespace" { default = "monitoring" }
+
+provider "po" {
+  config_context_cluster = var.k8s_cluster
+}
+
+resource "po_slo" "api_availability" {
+  metadata {
+    name = "api-availability"
+    namespace = var.namespace
+    labels = {
+      prometheus = "k8s"
+      role = "alert-rules"
+    }
+  }
+  spec {
+    objective = 99.9
+    time_window = "30d"
+    sli {
+      error_query = "sum(rate(http_requests_total{job=\"api\",code=~\"5..\"}[{{.window}}]))"
+      total_query = "sum(rate(http_requests_total{job=\"api\"}[{{.window}}]))"
+    }
+    alerting {
+      name = "APIErrorBudgetBurn"
+      labels = {
+        team = "api"
+      }
+      annotations = {
+        summary = "API is burning its availability error budget too fast"
+      }
+    }
+  }
+}
+
+output "rules" {
+  value = po_slo.api_availability.rules_yaml
+}
@@ -0,0 +1,53 @@
+variable "k8s_cluster" {}
+variable "namespace" { default = "monitoring" }
+
+provider "po" {
+  config_context_cluster = var.k8s_cluster
+  standard_rules_version = "1"
+}
+
+data "po_standard_rules" "baseline" {
+  bundles = ["node", "kubelet", "apiserver", "prometheus", "alertmanager"]
+  selectors = {
+    node = "job=\"node-exporter\""
+  }
+  thresholds = {
+    node_filesystem_free_percent = 15
+  }
+  severities = {
+    critical = "page"
+    warning = "ticket"
+  }
+  runbook_url = "https://runbooks.example.com/alerts"
+  labels = {
+    team = "platform"
+  }
+}
+
+resource "po_prometheus_rule" "baseline" {
+  metadata {
+    name = "baseline"
+    namespace = var.namespace
+    labels = {
+      role = "alert-rules"
+    }
+  }
+  spec {
+    dynamic "groups" {
+      for_each = data.po_standard_rules.baseline.groups
+      content {
+        name = groups.value.name
+        dynamic "rules" {
+          for_each = groups.value.rules
+          content {
+            alert = rules.value.alert
+            expr = rules.value.expr
+            for = rules.value.for
+            labels = rules.value.labels
+            annotations = rules.value.annotations
+          }
+        }
+      }
+    }
+  }
+}
@@ -0,0 +1,61 @@
+variable "k8s_cluster" {}
+variable "namespace" { default = "monitoring" }
+variable "thanos_version" { default = "v0.12.2" }
+
+provider "po" {
+  config_context_cluster = var.k8s_cluster
+}
+
+resource "po_thanos_ruler" "thanos_ruler" {
+  metadata {
+    name = "thanos-ruler"
+    namespace = var.namespace
+  }
+  spec {
+    image = "quay.io/thanos/thanos:${var.thanos_version}"
+    replicas = 2
+    query_endpoints = ["dnssrv+_http._tcp.thanos-querier.${var.namespace}.svc.cluster.local"]
+    alertmanagers_url = ["dnssrv+http://alertmanager-operated.${var.namespace}.svc.cluster.local:9093"]
+    object_storage_config {
+      name = "thanos-objstore-config"
+      key = "thanos.yaml"
+    }
+    rule_selector {
+      match_labels = {
+        role = "thanos-rules"
+      }
+    }
+    rule_namespace_selector {}
+    retention = "24h"
+    storage {
+      volume_claim_template {
+        access_modes = ["ReadWriteOnce"]
+        resources {
+          requests = {
+            storage = "10Gi"
+          }
+        }
+      }
+    }
+  }
+}
+
+resource "po_prometheus_rule" "thanos_rules" {
+  metadata {
+    name = "thanos-global-rules"
+    namespace = var.namespace
+    labels = {
+      role = "thanos-rules"
+    }
+  }
+  spec {
+    groups {
+      name = "global.rules"
+      partial_response_strategy = "warn"
+      rules {
+        record = "cluster:up:sum"
+        expr = "sum(up) by (cluster)"
+      }
+    }
+  }
+}
@@ -8,10 +8,13 @@ require (
 	github.com/hashicorp/go-version v1.2.0
 	github.com/hashicorp/terraform-plugin-sdk v1.3.0
 	github.com/mitchellh/go-homedir v1.1.0
+	github.com/prometheus/common v0.6.0
+	github.com/prometheus/prometheus v2.3.2+incompatible
 	github.com/robfig/cron v1.2.0
 	github.com/terraform-providers/terraform-provider-aws v2.32.0+incompatible
 	github.com/terraform-providers/terraform-provider-google v2.17.0+incompatible
 	github.com/terraform-providers/terraform-provider-kubernetes v1.10.0
+	gopkg.in/yaml.v2 v2.2.4
 	k8s.io/api v0.0.0-20191025225708-5524a3672fbb
 	k8s.io/apimachinery v0.0.0-20191025225532-af6325b3a843
 	k8s.io/client-go v12.0.0+incompatible
@@ -19,3 +22,6 @@ require (
 )
 
 replace github.com/terraform-providers/terraform-provider-kubernetes v1.10.0 => ./kubernetes
+
+// Pinned to the revision used by prometheus-operator v0.34.0.
+replace github.com/prometheus/prometheus => github.com/prometheus/prometheus v0.0.0-20190818123050-43acd0e2e93f
@@ -0,0 +1,512 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strconv"
+	"strings"
+)
+
+const header = "// Code generated by internal/codegen. DO NOT EDIT.\n\n"
+
+type generator struct {
+	pkg     string
+	structs []*structType
+	roots   []string
+
+	buf           bytes.Buffer
+	useIntstr     bool
+	useReflect    bool
+	useValidation bool
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// source returns the schema, expand and flatten functions.
+func (g *generator) source() ([]byte, error) {
+	g.buf.Reset()
+	body := g.body()
+
+	g.buf.Reset()
+	g.printf(header)
+	g.printf("package %s\n\n", g.pkg)
+	g.printf("import (\n")
+	if g.useReflect {
+		g.printf("\t\"reflect\"\n\n")
+	}
+	g.printf("\tpo_types \"github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1\"\n")
+	g.printf("\t\"github.com/hashicorp/terraform-plugin-sdk/helper/schema\"\n")
+	if g.useValidation {
+		g.printf("\t\"github.com/hashicorp/terraform-plugin-sdk/helper/validation\"\n")
+	}
+	if g.useIntstr {
+		g.printf("\t\"k8s.io/apimachinery/pkg/util/intstr\"\n")
+	}
+	g.printf(")\n\n")
+	g.buf.Write(body)
+	return format.Source(g.buf.Bytes())
+}
+
+func (g *generator) body() []byte {
+	for _, st := range g.structs {
+		g.schemaFunc(st)
+		g.expandFunc(st)
+		g.flattenFunc(st)
+	}
+	g.printf(`func genFlattenStringSlice(in []string) []interface{} {
+	out := make([]interface{}, len(in))
+	for i, v := range in {
+		out[i] = v
+	}
+	return out
+}
+
+func genFlattenStringMap(in map[string]string) map[string]interface{} {
+	out := make(map[string]interface{}, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
+`)
+	return append([]byte(nil), g.buf.Bytes()...)
+}
+
+func (g *generator) schemaFunc(st *structType) {
+	g.printf("// gen%sSchema returns the schema of %s.", st.Name, st.Name)
+	if st.Doc != "" {
+		g.printf(" %s", st.Doc)
+	}
+	g.printf("\n")
+	for _, s := range st.Skipped {
+		g.printf("// Not generated: %s.\n", s)
+	}
+	if len(st.Excluded) > 0 {
+		g.printf("// Not included: %s.\n", strings.Join(st.Excluded, ", "))
+	}
+	g.printf("func gen%sSchema() map[string]*schema.Schema {\n", st.Name)
+	if st.Extra != "" {
+		g.printf("\ts := map[string]*schema.Schema{\n")
+	} else {
+		g.printf("\treturn map[string]*schema.Schema{\n")
+	}
+	for _, f := range st.Fields {
+		g.printf("\t\t%q: {\n", f.Attr)
+		t := f.Type
+		listType := "schema.TypeList"
+		if f.Set {
+			listType = "schema.TypeSet"
+		}
+		switch {
+		case t.Kind == kindString || t.Kind == kindIntOrString:
+			g.printf("\t\t\tType: schema.TypeString,\n")
+		case t.Kind == kindBool:
+			g.printf("\t\t\tType: schema.TypeBool,\n")
+		case t.Kind == kindInt:
+			g.printf("\t\t\tType: schema.TypeInt,\n")
+		case t.Kind == kindFloat:
+			g.printf("\t\t\tType: schema.TypeFloat,\n")
+		case t.Kind == kindStringSlice:
+			g.printf("\t\t\tType: %s,\n", listType)
+			g.printf("\t\t\tElem: &schema.Schema{Type: schema.TypeString},\n")
+		case t.Kind == kindStringMap:
+			g.printf("\t\t\tType: schema.TypeMap,\n")
+			g.printf("\t\t\tElem: &schema.Schema{Type: schema.TypeString},\n")
+		case t.Kind == kindStruct:
+			g.printf("\t\t\tType: %s,\n", listType)
+			if !t.List {
+				g.printf("\t\t\tMaxItems: 1,\n")
+			}
+			g.printf("\t\t\tElem: &schema.Resource{\n\t\t\t\tSchema: gen%sSchema(),\n\t\t\t},\n", t.Struct)
+		case t.Kind == kindExternal:
+			g.printf("\t\t\tType: %s,\n", listType)
+			if t.External.MaxItems > 0 {
+				g.printf("\t\t\tMaxItems: %d,\n", t.External.MaxItems)
+			}
+			if t.External.Elem != "" {
+				g.printf("\t\t\tElem: %s,\n", t.External.Elem)
+			} else {
+				g.printf("\t\t\tElem: &schema.Resource{\n\t\t\t\tSchema: %s,\n\t\t\t},\n", t.External.Schema)
+			}
+		}
+		if f.Required {
+			g.printf("\t\t\tRequired: true,\n")
+		} else {
+			g.printf("\t\t\tOptional: true,\n")
+		}
+		if f.ForceNew {
+			g.printf("\t\t\tForceNew: true,\n")
+		}
+		if f.Sensitive {
+			g.printf("\t\t\tSensitive: true,\n")
+		}
+		if f.Default != "" {
+			g.printf("\t\t\tDefault: %s,\n", f.Default)
+		}
+		if f.ValidateFunc != "" {
+			g.useValidation = g.useValidation || strings.Contains(f.ValidateFunc, "validation.")
+			g.printf("\t\t\tValidateFunc: %s,\n", f.ValidateFunc)
+		}
+		if f.Doc != "" {
+			g.printf("\t\t\tDescription: %s,\n", strconv.Quote(f.Doc))
+		}
+		g.printf("\t\t},\n")
+	}
+	if st.Extra != "" {
+		g.printf("\t}\n")
+		g.printf("\tfor k, v := range %s {\n\t\ts[k] = v\n\t}\n", st.Extra)
+		g.printf("\treturn s\n}\n\n")
+		return
+	}
+	g.printf("\t}\n}\n\n")
+}
+
+func (g *generator) expandFunc(st *structType) {
+	g.printf("func genExpand%s(l []interface{}) (*po_types.%s, error) {\n", st.Name, st.Name)
+	g.printf("\tobj := &po_types.%s{}\n", st.Name)
+	if len(st.Fields) == 0 {
+		g.printf("\treturn obj, nil\n}\n\n")
+		return
+	}
+	g.printf("\tif len(l) == 0 || l[0] == nil {\n\t\treturn obj, nil\n\t}\n")
+	g.printf("\tin := l[0].(map[string]interface{})\n\n")
+	for _, f := range st.Fields {
+		g.expandField(f)
+	}
+	g.printf("\treturn obj, nil\n}\n\n")
+}
+
+func (g *generator) expandField(f *field) {
+	t := f.Type
+	assign := func(v string) {
+		if t.Pointer {
+			g.printf("\t\tp := %s\n\t\tobj.%s = &p\n", v, f.GoName)
+		} else {
+			g.printf("\t\tobj.%s = %s\n", f.GoName, v)
+		}
+	}
+	// list reads a list or set attribute into v.
+	list := func() {
+		if f.Set {
+			g.printf("\tif s, ok := in[%q].(*schema.Set); ok && s.Len() > 0 {\n", f.Attr)
+			g.printf("\t\tv := s.List()\n")
+		} else {
+			g.printf("\tif v, ok := in[%q].([]interface{}); ok && len(v) > 0 {\n", f.Attr)
+		}
+	}
+	// Pointers to booleans and numbers are set even to zero values, as
+	// they usually stand for operator defaults which differ from them.
+	cond := "ok"
+	if !t.Pointer {
+		switch t.Kind {
+		case kindBool:
+			cond = "ok && v"
+		case kindInt, kindFloat:
+			cond = "ok && v != 0"
+		}
+	}
+
+	switch t.Kind {
+	case kindString:
+		g.printf("\tif v, ok := in[%q].(string); ok && v != \"\" {\n", f.Attr)
+		assign(conversion(t.GoType, "v"))
+	case kindBool:
+		g.printf("\tif v, ok := in[%q].(bool); %s {\n", f.Attr, cond)
+		assign(conversion(t.GoType, "v"))
+	case kindInt:
+		g.printf("\tif v, ok := in[%q].(int); %s {\n", f.Attr, cond)
+		assign(conversion(t.GoType, "v"))
+	case kindFloat:
+		g.printf("\tif v, ok := in[%q].(float64); %s {\n", f.Attr, cond)
+		assign(conversion(t.GoType, "v"))
+	case kindIntOrString:
+		g.useIntstr = true
+		g.printf("\tif v, ok := in[%q].(string); ok && v != \"\" {\n", f.Attr)
+		assign("intstr.Parse(v)")
+	case kindStringSlice:
+		list()
+		g.printf("\t\tobj.%s = expandStringSlice(v)\n", f.GoName)
+	case kindStringMap:
+		g.printf("\tif v, ok := in[%q].(map[string]interface{}); ok && len(v) > 0 {\n", f.Attr)
+		g.printf("\t\tobj.%s = expandStringMap(v)\n", f.GoName)
+	case kindStruct:
+		list()
+		if t.List {
+			elem := "po_types." + t.Struct
+			deref := "*"
+			if t.ElemPointer {
+				elem = "*" + elem
+				deref = ""
+			}
+			g.printf("\t\tobj.%s = make([]%s, len(v))\n", f.GoName, elem)
+			g.printf("\t\tfor i, e := range v {\n")
+			g.printf("\t\t\ts, err := genExpand%s([]interface{}{e})\n", t.Struct)
+			g.printf("\t\t\tif err != nil {\n\t\t\t\treturn obj, err\n\t\t\t}\n")
+			g.printf("\t\t\tobj.%s[i] = %ss\n", f.GoName, deref)
+			g.printf("\t\t}\n")
+		} else {
+			g.printf("\t\ts, err := genExpand%s(v)\n", t.Struct)
+			g.printf("\t\tif err != nil {\n\t\t\treturn obj, err\n\t\t}\n")
+			if t.Pointer {
+				g.printf("\t\tobj.%s = s\n", f.GoName)
+			} else {
+				g.printf("\t\tobj.%s = *s\n", f.GoName)
+			}
+		}
+	case kindExternal:
+		e := t.External
+		list()
+		if e.ExpandError {
+			g.printf("\t\tx, err := %s(v)\n", e.Expand)
+			g.printf("\t\tif err != nil {\n\t\t\treturn obj, err\n\t\t}\n")
+		} else {
+			g.printf("\t\tx := %s(v)\n", e.Expand)
+		}
+		if e.ExpandDeref {
+			g.printf("\t\tobj.%s = *x\n", f.GoName)
+		} else {
+			g.printf("\t\tobj.%s = x\n", f.GoName)
+		}
+	}
+	g.printf("\t}\n")
+}
+
+func (g *generator) flattenFunc(st *structType) {
+	g.printf("func genFlatten%s(in po_types.%s) ([]interface{}, error) {\n", st.Name, st.Name)
+	g.printf("\tatt := make(map[string]interface{})\n\n")
+	for _, f := range st.Fields {
+		g.flattenField(f)
+	}
+	g.printf("\treturn []interface{}{att}, nil\n}\n\n")
+}
+
+func (g *generator) flattenField(f *field) {
+	t := f.Type
+	value := "in." + f.GoName
+	if t.Pointer {
+		g.printf("\tif %s != nil {\n", value)
+		if t.Kind != kindIntOrString {
+			value = "*" + value
+		}
+	}
+
+	switch t.Kind {
+	case kindString:
+		g.printf("\tatt[%q] = %s\n", f.Attr, toBasic("string", t.GoType, value))
+	case kindBool:
+		g.printf("\tatt[%q] = %s\n", f.Attr, toBasic("bool", t.GoType, value))
+	case kindInt:
+		g.printf("\tatt[%q] = %s\n", f.Attr, toBasic("int", t.GoType, value))
+	case kindFloat:
+		g.printf("\tatt[%q] = %s\n", f.Attr, toBasic("float64", t.GoType, value))
+	case kindIntOrString:
+		g.printf("\tatt[%q] = %s.String()\n", f.Attr, value)
+	case kindStringSlice:
+		g.printf("\tatt[%q] = genFlattenStringSlice(%s)\n", f.Attr, value)
+	case kindStringMap:
+		g.printf("\tatt[%q] = genFlattenStringMap(%s)\n", f.Attr, value)
+	case kindStruct:
+		if t.List {
+			g.printf("\tif len(%s) > 0 {\n", value)
+			g.printf("\t\tl := make([]interface{}, 0, len(%s))\n", value)
+			g.printf("\t\tfor _, e := range %s {\n", value)
+			elem := "e"
+			if t.ElemPointer {
+				g.printf("\t\t\tif e == nil {\n\t\t\t\tcontinue\n\t\t\t}\n")
+				elem = "*e"
+			}
+			g.printf("\t\t\ts, err := genFlatten%s(%s)\n", t.Struct, elem)
+			g.printf("\t\t\tif err != nil {\n\t\t\t\treturn nil, err\n\t\t\t}\n")
+			g.printf("\t\t\tl = append(l, s[0])\n")
+			g.printf("\t\t}\n")
+			g.printf("\t\tatt[%q] = l\n", f.Attr)
+			g.printf("\t}\n")
+		} else {
+			// Empty structs aren't flattened, so blocks which aren't
+			// configured don't show up as changes. Pointers are already
+			// checked for nil above.
+			if !t.Pointer {
+				g.useReflect = true
+				g.printf("\tif !reflect.ValueOf(%s).IsZero() {\n", value)
+			}
+			g.printf("\t\ts, err := genFlatten%s(%s)\n", t.Struct, value)
+			g.printf("\t\tif err != nil {\n\t\t\treturn nil, err\n\t\t}\n")
+			g.printf("\t\tatt[%q] = s\n", f.Attr)
+			if !t.Pointer {
+				g.printf("\t}\n")
+			}
+		}
+	case kindExternal:
+		e := t.External
+		switch {
+		case e.FlattenNil:
+			g.printf("\tif %s != nil {\n", value)
+		case e.FlattenAddr:
+			g.useReflect = true
+			g.printf("\tif !reflect.ValueOf(%s).IsZero() {\n", value)
+		}
+		arg := value
+		if e.FlattenAddr {
+			arg = "&" + value
+		}
+		if e.FlattenError {
+			g.printf("\t{\n")
+			g.printf("\t\tx, err := %s(%s)\n", e.Flatten, arg)
+			g.printf("\t\tif err != nil {\n\t\t\treturn nil, err\n\t\t}\n")
+			g.printf("\t\tatt[%q] = x\n", f.Attr)
+			g.printf("\t}\n")
+		} else {
+			g.printf("\tatt[%q] = %s(%s)\n", f.Attr, e.Flatten, arg)
+		}
+		if e.FlattenNil || e.FlattenAddr {
+			g.printf("\t}\n")
+		}
+	}
+	if t.Pointer {
+		g.printf("\t}\n")
+	}
+}
+
+// tests returns round-trip tests of the root structs.
+func (g *generator) tests() ([]byte, error) {
+	g.buf.Reset()
+	g.printf(header)
+	g.printf("package %s\n\n", g.pkg)
+	g.printf("import (\n\t\"reflect\"\n\t\"testing\"\n\n\t\"github.com/hashicorp/terraform-plugin-sdk/helper/schema\"\n)\n\n")
+
+	for _, r := range g.roots {
+		g.printf(`func TestGen%[1]s_roundTrip(t *testing.T) {
+	s := genRoundTripSchema(gen%[1]sSchema())
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{
+		"spec": []interface{}{genSampleConfig(gen%[1]sSchema())},
+	})
+	expanded, err := genExpand%[1]s(d.Get("spec").([]interface{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	flattened, err := genFlatten%[1]s(*expanded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d = schema.TestResourceDataRaw(t, s, map[string]interface{}{})
+	if err := d.Set("spec", flattened); err != nil {
+		t.Fatal(err)
+	}
+	roundTripped, err := genExpand%[1]s(d.Get("spec").([]interface{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Flattened values are compared, as helpers of external types don't
+	// preserve the difference between nil and empty values.
+	again, err := genFlatten%[1]s(*roundTripped)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(genNormalize(flattened), genNormalize(again)) {
+		t.Errorf("%[1]s differs after round trip:\n%%#v\n%%#v", flattened, again)
+	}
+}
+
+`, r)
+	}
+
+	g.printf(`func genRoundTripSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"spec": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem:     &schema.Resource{Schema: s},
+		},
+	}
+}
+
+// genNormalize replaces sets, which can't be compared with reflect.DeepEqual,
+// by lists.
+func genNormalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case *schema.Set:
+		return genNormalize(v.List())
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, e := range v {
+			out[i] = genNormalize(e)
+		}
+		return out
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			out[k] = genNormalize(e)
+		}
+		return out
+	}
+	return v
+}
+
+// genSampleConfig returns a configuration setting every attribute, except
+// validated attributes, which need meaningful values.
+func genSampleConfig(s map[string]*schema.Schema) map[string]interface{} {
+	out := map[string]interface{}{}
+	for k, v := range s {
+		if (v.Computed && !v.Optional) || v.ValidateFunc != nil {
+			continue
+		}
+		switch v.Type {
+		case schema.TypeString:
+			out[k] = "sample"
+		case schema.TypeBool:
+			out[k] = true
+		case schema.TypeInt:
+			out[k] = 1
+		case schema.TypeFloat:
+			out[k] = 1.5
+		case schema.TypeMap:
+			out[k] = map[string]interface{}{"key": "value"}
+		case schema.TypeList, schema.TypeSet:
+			switch e := v.Elem.(type) {
+			case *schema.Resource:
+				out[k] = []interface{}{genSampleConfig(e.Schema)}
+			case *schema.Schema:
+				if e.Type == schema.TypeString {
+					out[k] = []interface{}{"sample"}
+				}
+			}
+		}
+	}
+	return out
+}
+`)
+	return format.Source(g.buf.Bytes())
+}
+
+// conversion converts v of the type read from Terraform to goType.
+func conversion(goType, v string) string {
+	switch goType {
+	case "", "string", "bool", "int", "float64":
+		return v
+	}
+	return goType + "(" + v + ")"
+}
+
+// toBasic converts v of goType to the type stored by Terraform.
+func toBasic(basic, goType, v string) string {
+	if goType == basic {
+		return v
+	}
+	return basic + "(" + v + ")"
+}
+
+// report lists fields which weren't generated.
+func (g *generator) report() string {
+	var b strings.Builder
+	for _, st := range g.structs {
+		for _, s := range st.Skipped {
+			fmt.Fprintf(&b, "%s.%s\n", st.Name, s)
+		}
+	}
+	return b.String()
+}
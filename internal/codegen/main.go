@@ -0,0 +1,73 @@
+// Command codegen generates Terraform schema, expand and flatten functions
+// from the prometheus-operator API types, together with round-trip tests.
+//
+// Struct and field docs become attribute descriptions, JSON names become
+// attribute names. Fields whose types can't be represented are listed in
+// the doc comment of the generated schema function and on stderr.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+func main() {
+	var (
+		pkgPath   = flag.String("pkg", "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1", "import path of the operator API package")
+		dir       = flag.String("dir", "", "directory of the operator API package, looked up with go list if empty")
+		overrides = flag.String("overrides", "", "JSON file with overrides")
+		pkgName   = flag.String("package", "prometheus_operator", "package name of the generated files")
+		out       = flag.String("out", "zz_generated_schema.go", "output file")
+		testOut   = flag.String("test-out", "zz_generated_schema_test.go", "output file of the round-trip tests, skipped if empty")
+	)
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("codegen: ")
+
+	if *dir == "" {
+		d, err := exec.Command("go", "list", "-f", "{{.Dir}}", *pkgPath).Output()
+		if err != nil {
+			log.Fatalf("Failed to find package %s: %s", *pkgPath, err)
+		}
+		*dir = strings.TrimSpace(string(d))
+	}
+
+	o, err := loadOverrides(*overrides)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := parsePackage(*dir)
+	if err != nil {
+		log.Fatalf("Failed to parse %s: %s", *dir, err)
+	}
+	structs, err := src.collect(o.Roots, o)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	g := &generator{pkg: *pkgName, structs: structs, roots: o.Roots}
+	code, err := g.source()
+	if err != nil {
+		log.Fatalf("Failed to format generated code: %s", err)
+	}
+	if err := ioutil.WriteFile(*out, code, 0644); err != nil {
+		log.Fatal(err)
+	}
+	if *testOut != "" {
+		tests, err := g.tests()
+		if err != nil {
+			log.Fatalf("Failed to format generated tests: %s", err)
+		}
+		if err := ioutil.WriteFile(*testOut, tests, 0644); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if r := g.report(); r != "" {
+		fmt.Fprintf(os.Stderr, "Fields not generated:\n%s", r)
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+// overrides adjusts the generated code where the operator types alone
+// don't carry enough information.
+type overrides struct {
+	// Roots are the structs code is generated for, together with all
+	// structs reachable from them.
+	Roots []string `json:"roots"`
+	// Structs are keyed by the name of the struct.
+	Structs map[string]structOverride `json:"structs"`
+	// Fields are keyed by <Struct>.<GoField>.
+	Fields map[string]fieldOverride `json:"fields"`
+	// ExternalTypes map type expressions with package paths, such as
+	// *k8s.io/api/core/v1.SecretKeySelector, to existing helpers.
+	ExternalTypes map[string]externalType `json:"externalTypes"`
+}
+
+// structOverride restricts the generated attributes of a struct, e.g. for
+// specs whose pod-level fields are core types handled by the resources.
+type structOverride struct {
+	// Include lists the Go fields generated, all fields if empty.
+	Include []string `json:"include"`
+	// Extra is an expression returning map[string]*schema.Schema, with
+	// attributes which have no field in the type. They are added to the
+	// schema but neither expanded nor flattened.
+	Extra string `json:"extra"`
+}
+
+type fieldOverride struct {
+	// Name replaces the attribute name derived from the JSON name.
+	Name      string `json:"name"`
+	Required  bool   `json:"required"`
+	Sensitive bool   `json:"sensitive"`
+	Skip      bool   `json:"skip"`
+	ForceNew  bool   `json:"forceNew"`
+	// Set makes a list of strings, structs or external types a TypeSet.
+	Set bool `json:"set"`
+	// Default and ValidateFunc are Go expressions.
+	Default      string `json:"default"`
+	ValidateFunc string `json:"validateFunc"`
+	// Description replaces the doc comment of the field.
+	Description string `json:"description"`
+}
+
+// externalType describes how a type from outside of the operator package is
+// represented, using helpers of the provider.
+type externalType struct {
+	// Schema is an expression returning map[string]*schema.Schema, or Elem an
+	// expression returning *schema.Resource.
+	Schema   string `json:"schema"`
+	Elem     string `json:"elem"`
+	MaxItems int    `json:"maxItems"`
+
+	// Expand is called with []interface{}. ExpandError is set if it returns
+	// an error as well, ExpandDeref if it returns a pointer to be
+	// dereferenced.
+	Expand      string `json:"expand"`
+	ExpandError bool   `json:"expandError"`
+	ExpandDeref bool   `json:"expandDeref"`
+
+	// Flatten returns []interface{}. FlattenAddr is set if it takes a pointer
+	// to a field which isn't one, FlattenError if it returns an error.
+	Flatten      string `json:"flatten"`
+	FlattenAddr  bool   `json:"flattenAddr"`
+	FlattenError bool   `json:"flattenError"`
+	// FlattenNil is set if the field is a pointer which has to be checked
+	// before flattening.
+	FlattenNil bool `json:"flattenNil"`
+}
+
+func loadOverrides(path string) (*overrides, error) {
+	o := &overrides{
+		Structs:       map[string]structOverride{},
+		Fields:        map[string]fieldOverride{},
+		ExternalTypes: map[string]externalType{},
+	}
+	if path == "" {
+		return o, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, o); err != nil {
+		return nil, fmt.Errorf("Failed to parse %s: %s", path, err)
+	}
+	for k, e := range o.ExternalTypes {
+		if (e.Schema == "") == (e.Elem == "") || e.Expand == "" || e.Flatten == "" {
+			return nil, fmt.Errorf("External type %s needs exactly one of schema and elem, expand and flatten", k)
+		}
+	}
+	return o, nil
+}
@@ -0,0 +1,417 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type fieldKind int
+
+const (
+	kindUnsupported fieldKind = iota
+	kindString
+	kindBool
+	kindInt
+	kindFloat
+	kindIntOrString
+	kindStringSlice
+	kindStringMap
+	kindStruct
+	kindExternal
+)
+
+// typeRef describes the Go type of a struct field.
+type typeRef struct {
+	Kind fieldKind
+	// GoType is the type name used for conversions, e.g. int32 or
+	// po_types.LogLevel.
+	GoType string
+	// Struct is the name of the referenced struct of the operator package.
+	Struct  string
+	Pointer bool
+	List    bool
+	// ElemPointer is set for lists of pointers, e.g. []*RelabelConfig.
+	ElemPointer bool
+	External    *externalType
+	// Expr is the type expression with package paths, used to look up
+	// external types and to report unsupported fields.
+	Expr string
+}
+
+type field struct {
+	GoName       string
+	JSONName     string
+	Attr         string
+	Doc          string
+	Type         typeRef
+	Required     bool
+	Sensitive    bool
+	ForceNew     bool
+	Set          bool
+	Default      string
+	ValidateFunc string
+}
+
+type structType struct {
+	Name   string
+	Doc    string
+	Fields []*field
+	// Skipped lists fields which can't be generated.
+	Skipped []string
+	// Excluded lists fields left out by the include list of the overrides.
+	Excluded []string
+	Extra    string
+}
+
+// pkgSource holds the parsed type declarations of the operator API package.
+type pkgSource struct {
+	structs map[string]*ast.StructType
+	docs    map[string]string
+	basics  map[string]string
+	imports map[string]map[string]string
+	files   map[string]string
+}
+
+func parsePackage(dir string) (*pkgSource, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && !strings.HasPrefix(fi.Name(), "zz_generated")
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	src := &pkgSource{
+		structs: map[string]*ast.StructType{},
+		docs:    map[string]string{},
+		basics:  map[string]string{},
+		imports: map[string]map[string]string{},
+		files:   map[string]string{},
+	}
+	for _, pkg := range pkgs {
+		for name, f := range pkg.Files {
+			imports := map[string]string{}
+			for _, imp := range f.Imports {
+				path, _ := strconv.Unquote(imp.Path.Value)
+				alias := path[strings.LastIndex(path, "/")+1:]
+				if imp.Name != nil {
+					alias = imp.Name.Name
+				}
+				imports[alias] = path
+			}
+			src.imports[name] = imports
+
+			for _, decl := range f.Decls {
+				gd, ok := decl.(*ast.GenDecl)
+				if !ok || gd.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gd.Specs {
+					ts := spec.(*ast.TypeSpec)
+					doc := ts.Doc
+					if doc == nil {
+						doc = gd.Doc
+					}
+					src.docs[ts.Name.Name] = docText(doc)
+					src.files[ts.Name.Name] = name
+					switch t := ts.Type.(type) {
+					case *ast.StructType:
+						src.structs[ts.Name.Name] = t
+					case *ast.Ident:
+						src.basics[ts.Name.Name] = t.Name
+					}
+				}
+			}
+		}
+	}
+	return src, nil
+}
+
+// collect returns the structs reachable from roots, sorted by name.
+func (src *pkgSource) collect(roots []string, o *overrides) ([]*structType, error) {
+	seen := map[string]*structType{}
+	var visit func(name string) error
+	visit = func(name string) error {
+		if _, ok := seen[name]; ok {
+			return nil
+		}
+		st, ok := src.structs[name]
+		if !ok {
+			return fmt.Errorf("Struct %s not found", name)
+		}
+		so := o.Structs[name]
+		out := &structType{Name: name, Doc: src.docs[name], Extra: so.Extra}
+		seen[name] = out
+		if err := src.fields(out, st, src.imports[src.files[name]], o); err != nil {
+			return err
+		}
+		if err := checkIncluded(out, so.Include); err != nil {
+			return err
+		}
+		for _, f := range out.Fields {
+			if f.Type.Kind == kindStruct {
+				if err := visit(f.Type.Struct); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	for _, r := range roots {
+		if err := visit(r); err != nil {
+			return nil, err
+		}
+	}
+
+	out := make([]*structType, 0, len(seen))
+	for _, st := range seen {
+		out = append(out, st)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+func (src *pkgSource) fields(out *structType, st *ast.StructType, imports map[string]string, o *overrides) error {
+	for _, f := range st.Fields.List {
+		name, opts := jsonTag(f)
+		if name == "-" {
+			continue
+		}
+		if len(f.Names) == 0 {
+			// Embedded structs of the operator package are inlined,
+			// others (TypeMeta, ObjectMeta) are handled by resources.
+			if id, ok := f.Type.(*ast.Ident); ok && src.structs[id.Name] != nil && strings.Contains(opts, "inline") {
+				if err := src.fields(out, src.structs[id.Name], imports, o); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		goName := f.Names[0].Name
+		if !ast.IsExported(goName) || name == "" {
+			continue
+		}
+
+		key := out.Name + "." + goName
+		fo := o.Fields[key]
+		if include := o.Structs[out.Name].Include; len(include) > 0 && !contains(include, goName) {
+			out.Excluded = append(out.Excluded, goName)
+			continue
+		}
+		if fo.Skip {
+			out.Skipped = append(out.Skipped, fmt.Sprintf("%s: skipped by overrides", goName))
+			continue
+		}
+
+		t := src.typeRef(f.Type, imports, o)
+		if t.Kind == kindUnsupported {
+			out.Skipped = append(out.Skipped, fmt.Sprintf("%s: unsupported type %s", goName, t.Expr))
+			continue
+		}
+		if fo.Set && !(t.Kind == kindStringSlice || t.List || t.Kind == kindExternal) {
+			return fmt.Errorf("%s: only lists can be sets", key)
+		}
+		attr := snakeCase(name)
+		if fo.Name != "" {
+			attr = fo.Name
+		}
+		doc := docText(f.Doc)
+		if fo.Description != "" {
+			doc = fo.Description
+		}
+		out.Fields = append(out.Fields, &field{
+			GoName:       goName,
+			JSONName:     name,
+			Attr:         attr,
+			Doc:          doc,
+			Type:         t,
+			Required:     fo.Required,
+			Sensitive:    fo.Sensitive,
+			ForceNew:     fo.ForceNew,
+			Set:          fo.Set,
+			Default:      fo.Default,
+			ValidateFunc: fo.ValidateFunc,
+		})
+	}
+	return nil
+}
+
+// checkIncluded returns an error if a field of the include list isn't a
+// field of the struct, e.g. after it was renamed by the operator.
+func checkIncluded(st *structType, include []string) error {
+	for _, name := range include {
+		found := false
+		for _, f := range st.Fields {
+			found = found || f.GoName == name
+		}
+		for _, s := range st.Skipped {
+			found = found || strings.HasPrefix(s, name+":")
+		}
+		if !found {
+			return fmt.Errorf("Included field %s.%s not found", st.Name, name)
+		}
+	}
+	return nil
+}
+
+func contains(l []string, s string) bool {
+	for _, e := range l {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+func (src *pkgSource) typeRef(expr ast.Expr, imports map[string]string, o *overrides) typeRef {
+	s := typeString(expr, imports)
+	if ext, ok := o.ExternalTypes[s]; ok {
+		e := ext
+		return typeRef{Kind: kindExternal, External: &e, Expr: s}
+	}
+
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if k, goType := basicKind(t.Name); k != kindUnsupported {
+			return typeRef{Kind: k, GoType: goType, Expr: s}
+		}
+		if _, ok := src.structs[t.Name]; ok {
+			return typeRef{Kind: kindStruct, Struct: t.Name, Expr: s}
+		}
+		if b, ok := src.basics[t.Name]; ok {
+			if k, _ := basicKind(b); k != kindUnsupported {
+				return typeRef{Kind: k, GoType: "po_types." + t.Name, Expr: s}
+			}
+		}
+	case *ast.StarExpr:
+		r := src.typeRef(t.X, imports, o)
+		if r.Kind == kindUnsupported || r.Kind == kindExternal || r.List || r.Pointer {
+			return typeRef{Expr: s}
+		}
+		r.Pointer = true
+		r.Expr = s
+		return r
+	case *ast.ArrayType:
+		if t.Len != nil {
+			break
+		}
+		r := src.typeRef(t.Elt, imports, o)
+		switch {
+		case r.Kind == kindString && !r.Pointer && r.GoType == "string":
+			return typeRef{Kind: kindStringSlice, Expr: s}
+		case r.Kind == kindStruct && !r.List:
+			r.ElemPointer = r.Pointer
+			r.Pointer = false
+			r.List = true
+			r.Expr = s
+			return r
+		}
+	case *ast.MapType:
+		k, ok1 := t.Key.(*ast.Ident)
+		v, ok2 := t.Value.(*ast.Ident)
+		if ok1 && ok2 && k.Name == "string" && v.Name == "string" {
+			return typeRef{Kind: kindStringMap, Expr: s}
+		}
+	case *ast.SelectorExpr:
+		if s == "k8s.io/apimachinery/pkg/util/intstr.IntOrString" {
+			return typeRef{Kind: kindIntOrString, Expr: s}
+		}
+	}
+	return typeRef{Expr: s}
+}
+
+func basicKind(name string) (fieldKind, string) {
+	switch name {
+	case "string":
+		return kindString, name
+	case "bool":
+		return kindBool, name
+	case "int", "int32", "int64", "uint", "uint32", "uint64":
+		return kindInt, name
+	case "float32", "float64":
+		return kindFloat, name
+	}
+	return kindUnsupported, ""
+}
+
+// typeString renders the type expression with full package paths, e.g.
+// []*k8s.io/api/core/v1.Toleration.
+func typeString(expr ast.Expr, imports map[string]string) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return "*" + typeString(t.X, imports)
+	case *ast.ArrayType:
+		return "[]" + typeString(t.Elt, imports)
+	case *ast.MapType:
+		return "map[" + typeString(t.Key, imports) + "]" + typeString(t.Value, imports)
+	case *ast.SelectorExpr:
+		if id, ok := t.X.(*ast.Ident); ok {
+			if path, ok := imports[id.Name]; ok {
+				return path + "." + t.Sel.Name
+			}
+			return id.Name + "." + t.Sel.Name
+		}
+	}
+	return fmt.Sprintf("%T", expr)
+}
+
+func jsonTag(f *ast.Field) (string, string) {
+	if f.Tag == nil {
+		return "", ""
+	}
+	tag, err := strconv.Unquote(f.Tag.Value)
+	if err != nil {
+		return "", ""
+	}
+	v := reflect.StructTag(tag).Get("json")
+	parts := strings.SplitN(v, ",", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+func docText(cg *ast.CommentGroup) string {
+	if cg == nil {
+		return ""
+	}
+	lines := []string{}
+	for _, l := range strings.Split(cg.Text(), "\n") {
+		l = strings.TrimSpace(l)
+		// Skip code generation markers, e.g. +k8s:openapi-gen=true.
+		if l == "" || strings.HasPrefix(l, "+") {
+			continue
+		}
+		lines = append(lines, l)
+	}
+	return strings.Join(lines, " ")
+}
+
+// snakeCase converts JSON names to Terraform attribute names, e.g.
+// bearerTokenSecret to bearer_token_secret and externalURL to external_url.
+func snakeCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLower := i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]))
+			nextLower := i > 0 && i+1 < len(runes) && unicode.IsUpper(runes[i-1]) && unicode.IsLower(runes[i+1])
+			if prevLower || nextLower {
+				b.WriteRune('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
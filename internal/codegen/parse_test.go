@@ -0,0 +1,245 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testTypes = `package v1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// LogLevel is the log level.
+type LogLevel string
+
+// ExampleSpec is a specification of an example.
+// +k8s:openapi-gen=true
+type ExampleSpec struct {
+	// Log level of the example.
+	LogLevel LogLevel ` + "`json:\"logLevel,omitempty\"`" + `
+	Replicas *int32 ` + "`json:\"replicas,omitempty\"`" + `
+	ExternalURL string ` + "`json:\"externalURL,omitempty\"`" + `
+	Port intstr.IntOrString ` + "`json:\"port,omitempty\"`" + `
+	Labels map[string]string ` + "`json:\"labels,omitempty\"`" + `
+	Args []string ` + "`json:\"args,omitempty\"`" + `
+	Endpoints []*Endpoint ` + "`json:\"endpoints\"`" + `
+	Auth Auth ` + "`json:\"auth,omitempty\"`" + `
+	Secret *v1.SecretKeySelector ` + "`json:\"secret,omitempty\"`" + `
+	Volumes []v1.Volume ` + "`json:\"volumes,omitempty\"`" + `
+	internal string
+}
+
+type Endpoint struct {
+	Common ` + "`json:\",inline\"`" + `
+	Path string ` + "`json:\"path\"`" + `
+}
+
+type Common struct {
+	Interval string ` + "`json:\"interval\"`" + `
+}
+
+type Auth struct {
+	Password string ` + "`json:\"password\"`" + `
+}
+`
+
+func TestSnakeCase(t *testing.T) {
+	cases := map[string]string{
+		"port":              "port",
+		"bearerTokenSecret": "bearer_token_secret",
+		"externalURL":       "external_url",
+		"caFile":            "ca_file",
+		"tlsConfig":         "tls_config",
+		"scrapeInterval":    "scrape_interval",
+		"podMonitorLabel":   "pod_monitor_label",
+		"APIVersion":        "api_version",
+		"http2":             "http2",
+	}
+	for in, expected := range cases {
+		if out := snakeCase(in); out != expected {
+			t.Errorf("snakeCase(%q) = %q, expected %q", in, out, expected)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "codegen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "types.go"), []byte(testTypes), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := parsePackage(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	o := &overrides{
+		Roots: []string{"ExampleSpec"},
+		Fields: map[string]fieldOverride{
+			"Auth.Password": {Sensitive: true},
+			"Endpoint.Path": {Required: true},
+		},
+		ExternalTypes: map[string]externalType{
+			"*k8s.io/api/core/v1.SecretKeySelector": {
+				Schema:      "SecretKeySelectorSchema()",
+				MaxItems:    1,
+				Expand:      "expandSecretKeyRef",
+				ExpandError: true,
+				Flatten:     "flattenSecretKeyRef",
+				FlattenNil:  true,
+			},
+		},
+	}
+	structs, err := src.collect(o.Roots, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := []string{}
+	for _, st := range structs {
+		names = append(names, st.Name)
+	}
+	if strings.Join(names, ",") != "Auth,Endpoint,ExampleSpec" {
+		t.Fatalf("Unexpected structs %v", names)
+	}
+
+	spec := structs[2]
+	if spec.Doc != "ExampleSpec is a specification of an example." {
+		t.Errorf("Unexpected doc %q", spec.Doc)
+	}
+	if len(spec.Skipped) != 1 || !strings.Contains(spec.Skipped[0], "[]k8s.io/api/core/v1.Volume") {
+		t.Errorf("Expected volumes to be skipped, got %v", spec.Skipped)
+	}
+	kinds := map[string]fieldKind{}
+	for _, f := range spec.Fields {
+		kinds[f.Attr] = f.Type.Kind
+	}
+	expected := map[string]fieldKind{
+		"log_level":    kindString,
+		"replicas":     kindInt,
+		"external_url": kindString,
+		"port":         kindIntOrString,
+		"labels":       kindStringMap,
+		"args":         kindStringSlice,
+		"endpoints":    kindStruct,
+		"auth":         kindStruct,
+		"secret":       kindExternal,
+	}
+	for k, v := range expected {
+		if kinds[k] != v {
+			t.Errorf("Expected %s to be of kind %d, got %d", k, v, kinds[k])
+		}
+	}
+	if f := structs[1].Fields; len(f) != 2 || f[0].Attr != "interval" || !f[1].Required {
+		t.Errorf("Expected inlined interval and required path, got %#v", f)
+	}
+
+	g := &generator{pkg: "prometheus_operator", structs: structs, roots: o.Roots}
+	code, err := g.source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{
+		"func genExampleSpecSchema() map[string]*schema.Schema",
+		"func genExpandExampleSpec(l []interface{}) (*po_types.ExampleSpec, error)",
+		"func genFlattenExampleSpec(in po_types.ExampleSpec) ([]interface{}, error)",
+		"obj.LogLevel = po_types.LogLevel(v)",
+		"p := int32(v)",
+		"obj.Port = intstr.Parse(v)",
+		"obj.Endpoints = make([]*po_types.Endpoint, len(v))",
+		"att[\"secret\"] = flattenSecretKeyRef(in.Secret)",
+		"Sensitive: true,",
+		"// Not generated: Volumes: unsupported type []k8s.io/api/core/v1.Volume.",
+	} {
+		if !strings.Contains(string(code), s) {
+			t.Errorf("Expected generated code to contain %q", s)
+		}
+	}
+
+	tests, err := g.tests()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(tests), "func TestGenExampleSpec_roundTrip(t *testing.T)") {
+		t.Errorf("Expected round-trip test of ExampleSpec, got:\n%s", tests)
+	}
+}
+
+func TestGenerate_overrides(t *testing.T) {
+	dir, err := ioutil.TempDir("", "codegen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "types.go"), []byte(testTypes), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := parsePackage(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	o := &overrides{
+		Roots: []string{"ExampleSpec"},
+		Structs: map[string]structOverride{
+			"ExampleSpec": {Include: []string{"LogLevel", "Replicas", "Args", "Endpoints", "Auth"}},
+			"Auth":        {Extra: "authExtraSchema()"},
+		},
+		Fields: map[string]fieldOverride{
+			"ExampleSpec.LogLevel": {ForceNew: true, Default: `"info"`, ValidateFunc: `validation.StringInSlice([]string{"info", "debug"}, false)`},
+			"ExampleSpec.Args":     {Set: true},
+			"ExampleSpec.Replicas": {Description: "Number of replicas."},
+		},
+		ExternalTypes: map[string]externalType{},
+	}
+	structs, err := src.collect(o.Roots, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := structs[2]
+	if strings.Join(spec.Excluded, ",") != "ExternalURL,Port,Labels,Secret,Volumes" {
+		t.Errorf("Unexpected excluded fields %v", spec.Excluded)
+	}
+
+	g := &generator{pkg: "prometheus_operator", structs: structs, roots: o.Roots}
+	code, err := g.source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Attributes are aligned by gofmt.
+	flat := strings.Join(strings.Fields(string(code)), " ")
+	for _, s := range []string{
+		"// Not included: ExternalURL, Port, Labels, Secret, Volumes.",
+		"ForceNew: true,",
+		`Default: "info",`,
+		`ValidateFunc: validation.StringInSlice([]string{"info", "debug"}, false),`,
+		`"github.com/hashicorp/terraform-plugin-sdk/helper/validation"`,
+		`Description: "Number of replicas.",`,
+		"Type: schema.TypeSet,",
+		`if s, ok := in["args"].(*schema.Set); ok && s.Len() > 0 {`,
+		"for k, v := range authExtraSchema() {",
+		"if !reflect.ValueOf(in.Auth).IsZero() {",
+	} {
+		if !strings.Contains(flat, s) {
+			t.Errorf("Expected generated code to contain %q", s)
+		}
+	}
+
+	o.Structs["ExampleSpec"] = structOverride{Include: []string{"Missing"}}
+	if _, err := src.collect(o.Roots, o); err == nil || !strings.Contains(err.Error(), "ExampleSpec.Missing") {
+		t.Errorf("Expected error for unknown included field, got %v", err)
+	}
+	o.Structs["ExampleSpec"] = structOverride{}
+	o.Fields = map[string]fieldOverride{"ExampleSpec.ExternalURL": {Set: true}}
+	if _, err := src.collect(o.Roots, o); err == nil || !strings.Contains(err.Error(), "only lists can be sets") {
+		t.Errorf("Expected error for set of a string, got %v", err)
+	}
+}
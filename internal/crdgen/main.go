@@ -0,0 +1,160 @@
+// Command crdgen embeds the validation schemas of the CustomResourceDefinitions
+// shipped with prometheus-operator into a Go source file.
+//
+// The openAPIV3Schema of every CRD in example/prometheus-operator-crd of the
+// operator module is written as JSON, keyed by kind. Descriptions are
+// dropped, as they aren't used for validation.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const header = "// Code generated by internal/crdgen. DO NOT EDIT.\n\n"
+
+type crd struct {
+	Spec struct {
+		Names struct {
+			Kind string `json:"kind"`
+		} `json:"names"`
+		Validation struct {
+			OpenAPIV3Schema map[string]interface{} `json:"openAPIV3Schema"`
+		} `json:"validation"`
+	} `json:"spec"`
+}
+
+func main() {
+	var (
+		module  = flag.String("module", "github.com/coreos/prometheus-operator", "module path of the operator")
+		dir     = flag.String("dir", "", "directory of the CRDs, looked up with go list if empty")
+		pkgName = flag.String("package", "prometheus_operator", "package name of the generated file")
+		out     = flag.String("out", "zz_generated_crds.go", "output file")
+	)
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("crdgen: ")
+
+	version := ""
+	if *dir == "" {
+		m, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}} {{.Version}}", *module).Output()
+		if err != nil {
+			log.Fatalf("Failed to find module %s: %s", *module, err)
+		}
+		fields := strings.Fields(string(m))
+		if len(fields) != 2 {
+			log.Fatalf("Unexpected output of go list: %q", m)
+		}
+		*dir, version = filepath.Join(fields[0], "example", "prometheus-operator-crd"), fields[1]
+	}
+
+	files, err := filepath.Glob(filepath.Join(*dir, "*.crd.yaml"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(files) == 0 {
+		log.Fatalf("No CRDs in %s", *dir)
+	}
+	schemas := map[string]string{}
+	for _, f := range files {
+		kind, s, err := readSchema(f)
+		if err != nil {
+			log.Fatalf("Failed to read %s: %s", f, err)
+		}
+		schemas[kind] = s
+	}
+
+	code, err := source(*pkgName, *module, version, schemas)
+	if err != nil {
+		log.Fatalf("Failed to format generated code: %s", err)
+	}
+	if err := ioutil.WriteFile(*out, code, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func readSchema(path string) (string, string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", "", err
+	}
+	c := &crd{}
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return "", "", err
+	}
+	if c.Spec.Names.Kind == "" || c.Spec.Validation.OpenAPIV3Schema == nil {
+		return "", "", fmt.Errorf("No kind or validation schema")
+	}
+	s, err := json.MarshalIndent(stripDescriptions(c.Spec.Validation.OpenAPIV3Schema), "", "  ")
+	if err != nil {
+		return "", "", err
+	}
+	if bytes.ContainsRune(s, '`') {
+		return "", "", fmt.Errorf("Schema contains a backquote")
+	}
+	return c.Spec.Names.Kind, string(s), nil
+}
+
+// stripDescriptions removes the description keywords of the schema, but
+// keeps properties named description.
+func stripDescriptions(v interface{}) interface{} {
+	switch s := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(s))
+		for k, e := range s {
+			switch {
+			case k == "description":
+				if _, ok := e.(string); ok {
+					continue
+				}
+				out[k] = stripDescriptions(e)
+			case k == "properties":
+				props := map[string]interface{}{}
+				for name, p := range e.(map[string]interface{}) {
+					props[name] = stripDescriptions(p)
+				}
+				out[k] = props
+			default:
+				out[k] = stripDescriptions(e)
+			}
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(s))
+		for i, e := range s {
+			out[i] = stripDescriptions(e)
+		}
+		return out
+	}
+	return v
+}
+
+func source(pkg, module, version string, schemas map[string]string) ([]byte, error) {
+	kinds := make([]string, 0, len(schemas))
+	for k := range schemas {
+		kinds = append(kinds, k)
+	}
+	sort.Strings(kinds)
+
+	b := &bytes.Buffer{}
+	b.WriteString(header)
+	fmt.Fprintf(b, "package %s\n\n", pkg)
+	fmt.Fprintf(b, "// crdSchemas holds the openAPIV3Schema of the CRDs of %s %s, by kind.\n", module, version)
+	b.WriteString("var crdSchemas = map[string]string{\n")
+	for _, k := range kinds {
+		fmt.Fprintf(b, "%q: `%s`,\n", k, schemas[k])
+	}
+	b.WriteString("}\n")
+	return format.Source(b.Bytes())
+}
@@ -87,7 +87,7 @@ func ExpandMetadata(in []interface{}) metav1.ObjectMeta {
 	return expandMetadata(in)
 }
 
-func PatchMetadata(keyPrefix, pathPrefix string, d *schema.ResourceData) PatchOperations {
+func PatchMetadata(keyPrefix, pathPrefix string, d changeGetter) PatchOperations {
 	return patchMetadata(keyPrefix, pathPrefix, d)
 }
 
@@ -175,6 +175,26 @@ func ExpandPersistentVolumeAccessModes(s []interface{}) []api.PersistentVolumeAc
 	return expandPersistentVolumeAccessModes(s)
 }
 
+func PersistentVolumeClaimSpecFields() map[string]*schema.Schema {
+	return persistentVolumeClaimSpecFields()
+}
+
+func FlattenPersistentVolumeClaimSpec(in api.PersistentVolumeClaimSpec) []interface{} {
+	return flattenPersistentVolumeClaimSpec(in)
+}
+
+func ExpandPersistentVolumeClaimSpec(l []interface{}) (*api.PersistentVolumeClaimSpec, error) {
+	return expandPersistentVolumeClaimSpec(l)
+}
+
+func FlattenEmptyDirVolumeSource(in *api.EmptyDirVolumeSource) []interface{} {
+	return flattenEmptyDirVolumeSource(in)
+}
+
+func ExpandEmptyDirVolumeSource(l []interface{}) *api.EmptyDirVolumeSource {
+	return expandEmptyDirVolumeSource(l)
+}
+
 func FlattenResourceQuotaSpec(in api.ResourceQuotaSpec) []interface{} {
 	return flattenResourceQuotaSpec(in)
 }
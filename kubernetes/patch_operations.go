@@ -152,3 +152,26 @@ func (o *RemoveOperation) String() string {
 	b, _ := o.MarshalJSON()
 	return string(b)
 }
+
+// TestOperation fails the whole patch unless the value at Path equals Value,
+// guarding operations addressing array elements by index against concurrent
+// changes of the array.
+type TestOperation struct {
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+	Op    string      `json:"op"`
+}
+
+func (o *TestOperation) GetPath() string {
+	return o.Path
+}
+
+func (o *TestOperation) MarshalJSON() ([]byte, error) {
+	o.Op = "test"
+	return json.Marshal(*o)
+}
+
+func (o *TestOperation) String() string {
+	b, _ := o.MarshalJSON()
+	return string(b)
+}
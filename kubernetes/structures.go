@@ -54,7 +54,13 @@ func expandMetadata(in []interface{}) metav1.ObjectMeta {
 	return meta
 }
 
-func patchMetadata(keyPrefix, pathPrefix string, d *schema.ResourceData) PatchOperations {
+// changeGetter is implemented by schema.ResourceData and schema.ResourceDiff.
+type changeGetter interface {
+	GetChange(key string) (interface{}, interface{})
+	HasChange(key string) bool
+}
+
+func patchMetadata(keyPrefix, pathPrefix string, d changeGetter) PatchOperations {
 	ops := make([]PatchOperation, 0, 0)
 	if d.HasChange(keyPrefix + "annotations") {
 		oldV, newV := d.GetChange(keyPrefix + "annotations")
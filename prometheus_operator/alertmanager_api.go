@@ -0,0 +1,79 @@
+package prometheus_operator
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// silenceMatcher, postableSilence and gettableSilence are the models of the
+// Alertmanager v2 API.
+type silenceMatcher struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsRegex bool   `json:"isRegex"`
+	IsEqual *bool  `json:"isEqual,omitempty"`
+}
+
+type postableSilence struct {
+	ID        string           `json:"id,omitempty"`
+	Matchers  []silenceMatcher `json:"matchers"`
+	StartsAt  time.Time        `json:"startsAt"`
+	EndsAt    time.Time        `json:"endsAt"`
+	CreatedBy string           `json:"createdBy"`
+	Comment   string           `json:"comment"`
+}
+
+type gettableSilence struct {
+	postableSilence
+	Status struct {
+		State string `json:"state"`
+	} `json:"status"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// postSilence creates the silence, or updates it if it has an ID, and
+// returns its ID. Alertmanager expires the silence and creates a new one
+// when an active silence is updated in a way that would change the alerts
+// it silenced so far.
+func postSilence(proxy apiProxy, s *postableSilence) (string, error) {
+	body, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	data, err := proxy(http.MethodPost, "/api/v2/silences").
+		Body(body).
+		Do().
+		Raw()
+	if err != nil {
+		return "", fmt.Errorf("%s: %s", err, data)
+	}
+	out := struct {
+		SilenceID string `json:"silenceID"`
+	}{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return "", fmt.Errorf("Failed to decode response of Alertmanager: %s", err)
+	}
+	return out.SilenceID, nil
+}
+
+func getSilence(proxy apiProxy, id string) (*gettableSilence, error) {
+	data, err := proxy(http.MethodGet, "/api/v2/silence/"+id).
+		Do().
+		Raw()
+	if err != nil {
+		return nil, err
+	}
+	out := &gettableSilence{}
+	if err := json.Unmarshal(data, out); err != nil {
+		return nil, fmt.Errorf("Failed to decode silence: %s", err)
+	}
+	return out, nil
+}
+
+func expireSilence(proxy apiProxy, id string) error {
+	return proxy(http.MethodDelete, "/api/v2/silence/"+id).
+		Do().
+		Error()
+}
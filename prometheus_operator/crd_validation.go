@@ -0,0 +1,320 @@
+package prometheus_operator
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// openAPISchema is the subset of OpenAPI v3 used by structural CRD schemas
+// which can be checked without the API server.
+type openAPISchema struct {
+	Type                 string                    `json:"type,omitempty"`
+	Enum                 []interface{}             `json:"enum,omitempty"`
+	Pattern              string                    `json:"pattern,omitempty"`
+	Minimum              *float64                  `json:"minimum,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+	Properties           map[string]*openAPISchema `json:"properties,omitempty"`
+	AdditionalProperties *openAPISchema            `json:"additionalProperties,omitempty"`
+	Items                *openAPISchema            `json:"items,omitempty"`
+	AnyOf                []*openAPISchema          `json:"anyOf,omitempty"`
+	IntOrString          bool                      `json:"x-kubernetes-int-or-string,omitempty"`
+
+	pattern *regexp.Regexp
+}
+
+var (
+	loadCRDSchemasOnce sync.Once
+	loadedCRDSchemas   map[string]*openAPISchema
+	loadCRDSchemasErr  error
+)
+
+// crdSchema returns the validation schema of the given kind, from the CRDs
+// of the operator embedded by go generate.
+func crdSchema(kind string) (*openAPISchema, error) {
+	loadCRDSchemasOnce.Do(func() {
+		loadedCRDSchemas, loadCRDSchemasErr = parseCRDSchemas(crdSchemas)
+	})
+	if loadCRDSchemasErr != nil {
+		return nil, loadCRDSchemasErr
+	}
+	s, ok := loadedCRDSchemas[kind]
+	if !ok {
+		return nil, fmt.Errorf("No schema for kind %q", kind)
+	}
+	return s, nil
+}
+
+func parseCRDSchemas(raw map[string]string) (map[string]*openAPISchema, error) {
+	schemas := make(map[string]*openAPISchema, len(raw))
+	for kind, r := range raw {
+		s := &openAPISchema{}
+		if err := json.Unmarshal([]byte(r), s); err != nil {
+			return nil, fmt.Errorf("Failed to parse %s schema: %s", kind, err)
+		}
+		if err := s.compile(); err != nil {
+			return nil, fmt.Errorf("Failed to compile %s schema: %s", kind, err)
+		}
+		schemas[kind] = s
+	}
+	return schemas, nil
+}
+
+// compile compiles the patterns of the schema.
+func (s *openAPISchema) compile() error {
+	if s.Pattern != "" {
+		p, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return err
+		}
+		s.pattern = p
+	}
+	children := append([]*openAPISchema{s.AdditionalProperties, s.Items}, s.AnyOf...)
+	for _, p := range s.Properties {
+		children = append(children, p)
+	}
+	for _, c := range children {
+		if c == nil {
+			continue
+		}
+		if err := c.compile(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate returns errors found in value, which has to be decoded from JSON.
+// Empty strings and nulls are treated as unset, the same way Go zero values
+// are omitted when objects are sent to the API server.
+func (s *openAPISchema) validate(path string, value interface{}) []string {
+	if value == nil {
+		return nil
+	}
+	if s.IntOrString {
+		switch value.(type) {
+		case string, float64:
+			return nil
+		}
+		return []string{fmt.Sprintf("%s: Invalid value: must be an integer or a string", path)}
+	}
+	if len(s.AnyOf) > 0 {
+		var errs []string
+		for _, a := range s.AnyOf {
+			e := a.validate(path, value)
+			if len(e) == 0 {
+				return nil
+			}
+			errs = append(errs, e...)
+		}
+		return errs
+	}
+
+	var errs []string
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if s.Type != "" && s.Type != "object" {
+			return []string{fmt.Sprintf("%s: Invalid value: must be of type %s", path, s.Type)}
+		}
+		for _, r := range s.Required {
+			if v[r] == nil {
+				errs = append(errs, fmt.Sprintf("%s.%s: Required value", path, r))
+			}
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			p, ok := s.Properties[k]
+			if !ok {
+				p = s.AdditionalProperties
+			}
+			if p != nil {
+				errs = append(errs, p.validate(path+"."+k, v[k])...)
+			}
+		}
+	case []interface{}:
+		if s.Type != "" && s.Type != "array" {
+			return []string{fmt.Sprintf("%s: Invalid value: must be of type %s", path, s.Type)}
+		}
+		if s.Items != nil {
+			for i, e := range v {
+				errs = append(errs, s.Items.validate(fmt.Sprintf("%s[%d]", path, i), e)...)
+			}
+		}
+	case string:
+		if s.Type != "" && s.Type != "string" {
+			return []string{fmt.Sprintf("%s: Invalid value: %q: must be of type %s", path, v, s.Type)}
+		}
+		if v == "" {
+			return nil
+		}
+		if len(s.Enum) > 0 && !inEnum(s.Enum, v) {
+			errs = append(errs, fmt.Sprintf("%s: Unsupported value: %q: supported values: %s", path, v, enumValues(s.Enum)))
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			errs = append(errs, fmt.Sprintf("%s: Invalid value: %q: must match %q", path, v, s.Pattern))
+		}
+	case float64:
+		if s.Type == "integer" && v != float64(int64(v)) {
+			errs = append(errs, fmt.Sprintf("%s: Invalid value: %v: must be an integer", path, v))
+		} else if s.Type != "" && s.Type != "integer" && s.Type != "number" {
+			return []string{fmt.Sprintf("%s: Invalid value: %v: must be of type %s", path, v, s.Type)}
+		}
+		if s.Minimum != nil && v < *s.Minimum {
+			errs = append(errs, fmt.Sprintf("%s: Invalid value: %v: must be greater than or equal to %v", path, v, *s.Minimum))
+		}
+	case bool:
+		if s.Type != "" && s.Type != "boolean" {
+			return []string{fmt.Sprintf("%s: Invalid value: %t: must be of type %s", path, v, s.Type)}
+		}
+	}
+	return errs
+}
+
+// validateCRDSchema checks the object against the schema of its kind.
+func validateCRDSchema(kind string, obj interface{}) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("Failed to marshal %s: %s", kind, err)
+	}
+	return validateCRDSchemaJSON(kind, data)
+}
+
+func validateCRDSchemaJSON(kind string, data []byte) error {
+	errs, err := crdSchemaErrors(kind, data)
+	if err != nil {
+		return err
+	}
+	return crdSchemaError(kind, errs)
+}
+
+// crdSchemaErrors returns the errors found in the object, each starting with
+// the path of the invalid value, e.g. Prometheus.spec.replicas.
+func crdSchemaErrors(kind string, data []byte) ([]string, error) {
+	s, err := crdSchema(kind)
+	if err != nil {
+		return nil, err
+	}
+	var value map[string]interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal %s: %s", kind, err)
+	}
+	// Metadata is validated by the Terraform schema.
+	delete(value, "apiVersion")
+	delete(value, "kind")
+	delete(value, "metadata")
+	delete(value, "status")
+
+	return s.validate(kind, value), nil
+}
+
+func crdSchemaError(kind string, errs []string) error {
+	if len(errs) > 0 {
+		return fmt.Errorf("%s is invalid:\n\t%s", kind, strings.Join(errs, "\n\t"))
+	}
+	return nil
+}
+
+// validateCRDSchemaDiff validates expanded objects against the embedded CRD
+// schemas, so invalid values are reported by plan without cluster access.
+// Values known only after apply are expanded as empty values, so errors at
+// or below them are ignored, while the rest of the object is validated.
+func validateCRDSchemaDiff(kind string, build objectBuilder) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		if clients, ok := meta.(*KubeClientsets); !ok || !clients.ValidateSchema {
+			return nil
+		}
+		unknown := unknownValues(d)
+		for _, k := range unknown {
+			if strings.HasPrefix(k, "spec_override_") {
+				// The overrides can change any value of the object.
+				log.Printf("[DEBUG] Skipping schema validation of %s %q, %s is known only after apply", kind, d.Id(), k)
+				return nil
+			}
+		}
+		body, err := build(d)
+		if err != nil {
+			return err
+		}
+		errs, err := crdSchemaErrors(kind, body)
+		if err != nil {
+			return err
+		}
+		known := []string{}
+		for _, e := range errs {
+			if k, ok := unknownErrorValue(e, unknown); ok {
+				log.Printf("[DEBUG] Ignoring schema error of %s %q, %s is known only after apply: %s", kind, d.Id(), k, e)
+				continue
+			}
+			known = append(known, e)
+		}
+		return crdSchemaError(kind, known)
+	}
+}
+
+// unknownErrorValue returns the unknown attribute the path of the error is
+// at or below. The JSON path of the error, e.g.
+// Prometheus.spec.alerting.alertmanagers[0].name, is compared with the
+// attribute, e.g. spec.0.alerting.0.alertmanagers.1.name, ignoring indices,
+// as blocks of a single item have none in JSON.
+func unknownErrorValue(e string, unknown []string) (string, bool) {
+	path := strings.SplitN(e, ": ", 2)[0]
+	segments := strings.Split(indexPattern.ReplaceAllString(path, ""), ".")[1:]
+	for _, k := range unknown {
+		attrs := []string{}
+		for _, a := range strings.Split(k, ".") {
+			if _, err := strconv.Atoi(a); err != nil {
+				attrs = append(attrs, a)
+			}
+		}
+		if len(attrs) > len(segments) {
+			continue
+		}
+		match := true
+		for i, a := range attrs {
+			match = match && attributeMatches(a, segments[i])
+		}
+		if match {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+var indexPattern = regexp.MustCompile(`\[\d+\]`)
+
+// attributeMatches compares attribute and JSON names, e.g. target_port and
+// targetPort. Pod template blocks are singular, e.g. container for
+// containers.
+func attributeMatches(attr, name string) bool {
+	a := strings.Replace(attr, "_", "", -1)
+	n := strings.ToLower(name)
+	return a == n || a+"s" == n
+}
+
+func inEnum(enum []interface{}, v string) bool {
+	for _, e := range enum {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func enumValues(enum []interface{}) string {
+	values := make([]string, len(enum))
+	for i, e := range enum {
+		values[i] = fmt.Sprintf("%q", e)
+	}
+	return strings.Join(values, ", ")
+}
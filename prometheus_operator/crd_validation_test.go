@@ -0,0 +1,153 @@
+package prometheus_operator
+
+import (
+	"strings"
+	"testing"
+
+	po_types "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestCRDSchemas_parse(t *testing.T) {
+	for _, kind := range []string{"Prometheus", "Alertmanager", "ServiceMonitor", "PodMonitor", "PrometheusRule"} {
+		if _, err := crdSchema(kind); err != nil {
+			t.Errorf("%s: %s", kind, err)
+		}
+	}
+}
+
+func TestValidateCRDSchema(t *testing.T) {
+	targetPort := intstr.FromInt(8080)
+	validMonitor := func() *po_types.ServiceMonitor {
+		return &po_types.ServiceMonitor{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "monitoring"},
+			Spec: po_types.ServiceMonitorSpec{
+				Selector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "example"}},
+				Endpoints: []po_types.Endpoint{
+					{
+						TargetPort: &targetPort,
+						Scheme:     "https",
+						Interval:   "30s",
+						TLSConfig: &po_types.TLSConfig{
+							CA: po_types.SecretOrConfigMap{
+								Secret: &api.SecretKeySelector{
+									LocalObjectReference: api.LocalObjectReference{Name: "tls"},
+									Key:                  "ca.crt",
+								},
+							},
+						},
+						RelabelConfigs: []*po_types.RelabelConfig{
+							{Action: "labeldrop", Regex: "pod_template_hash"},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	if err := validateCRDSchema("ServiceMonitor", validMonitor()); err != nil {
+		t.Fatalf("Expected valid ServiceMonitor, got %s", err)
+	}
+
+	cases := []struct {
+		name     string
+		kind     string
+		obj      func() interface{}
+		expected string
+	}{
+		{
+			name: "required endpoints",
+			kind: "ServiceMonitor",
+			obj: func() interface{} {
+				m := validMonitor()
+				m.Spec.Endpoints = nil
+				return m
+			},
+			expected: `ServiceMonitor.spec.endpoints: Required value`,
+		},
+		{
+			name: "required rules",
+			kind: "PrometheusRule",
+			obj: func() interface{} {
+				return &po_types.PrometheusRule{
+					Spec: po_types.PrometheusRuleSpec{
+						Groups: []po_types.RuleGroup{{Name: "empty"}},
+					},
+				}
+			},
+			expected: `PrometheusRule.spec.groups[0].rules: Required value`,
+		},
+		{
+			name: "alertmanager name",
+			kind: "Prometheus",
+			obj: func() interface{} {
+				return map[string]interface{}{
+					"spec": map[string]interface{}{
+						"alerting": map[string]interface{}{
+							"alertmanagers": []interface{}{map[string]interface{}{"namespace": "monitoring", "port": "web"}},
+						},
+					},
+				}
+			},
+			expected: `Prometheus.spec.alerting.alertmanagers[0].name: Required value`,
+		},
+		{
+			name: "port type",
+			kind: "ServiceMonitor",
+			obj: func() interface{} {
+				return map[string]interface{}{
+					"spec": map[string]interface{}{
+						"selector":  map[string]interface{}{},
+						"endpoints": []interface{}{map[string]interface{}{"port": 8080}},
+					},
+				}
+			},
+			expected: `ServiceMonitor.spec.endpoints[0].port: Invalid value: 8080: must be of type string`,
+		},
+		{
+			name: "target port type",
+			kind: "ServiceMonitor",
+			obj: func() interface{} {
+				return map[string]interface{}{
+					"spec": map[string]interface{}{
+						"selector":  map[string]interface{}{},
+						"endpoints": []interface{}{map[string]interface{}{"targetPort": true}},
+					},
+				}
+			},
+			expected: `ServiceMonitor.spec.endpoints[0].targetPort: Invalid value: true: must be of type string`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateCRDSchema(tc.kind, tc.obj())
+			if err == nil {
+				t.Fatal("Expected validation error")
+			}
+			if !strings.Contains(err.Error(), tc.expected) {
+				t.Errorf("Expected error to contain %q, got %q", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestUnknownErrorValue(t *testing.T) {
+	unknown := []string{"spec.0.alerting.0.alertmanagers.0.name", "spec.0.endpoints.1.target_port", "spec.0.container.0.image"}
+	cases := map[string]string{
+		`Prometheus.spec.alerting.alertmanagers[0].name: Required value`:                           "spec.0.alerting.0.alertmanagers.0.name",
+		`ServiceMonitor.spec.endpoints[1].targetPort: Invalid value: true: must be of type string`: "spec.0.endpoints.1.target_port",
+		`Prometheus.spec.containers[0].image: Required value`:                                      "spec.0.container.0.image",
+		`Prometheus.spec.alerting.alertmanagers[0].namespace: Required value`:                      "",
+		`Prometheus.spec.alerting: Invalid value: must be of type object`:                          "",
+		`Prometheus.spec.replicas: Invalid value: -1: must be greater than or equal to 0`:          "",
+	}
+	for e, expected := range cases {
+		k, ok := unknownErrorValue(e, unknown)
+		if k != expected || ok != (expected != "") {
+			t.Errorf("%s: expected %q, got %q", e, expected, k)
+		}
+	}
+}
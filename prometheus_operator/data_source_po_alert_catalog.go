@@ -0,0 +1,371 @@
+package prometheus_operator
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	restclient "k8s.io/client-go/rest"
+)
+
+func dataSourcePOAlertCatalog() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourcePOAlertCatalogRead,
+
+		Schema: map[string]*schema.Schema{
+			"namespaces": {
+				Type:        schema.TypeSet,
+				Description: "Namespaces of the PrometheusRules in the catalog. PrometheusRules of all namespaces are listed if not set.",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+			"label_selector": {
+				Type:        schema.TypeString,
+				Description: "Label selector of the PrometheusRules in the catalog, e.g. role=alert-rules.",
+				Optional:    true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, es []error) {
+					if _, err := metav1.ParseToLabelSelector(v.(string)); err != nil {
+						es = append(es, fmt.Errorf("%s: %s", k, err))
+					}
+					return
+				},
+			},
+			"alerts": {
+				Type:        schema.TypeList,
+				Description: "Alerting rules, sorted by name and owning object.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "Name of the alert.",
+							Computed:    true,
+						},
+						"expr": {
+							Type:        schema.TypeString,
+							Description: "Expression of the rule.",
+							Computed:    true,
+						},
+						"for": {
+							Type:        schema.TypeString,
+							Description: "Duration the expression has to return the alert before it fires.",
+							Computed:    true,
+						},
+						"severity": {
+							Type:        schema.TypeString,
+							Description: "Value of the severity label.",
+							Computed:    true,
+						},
+						"runbook_url": {
+							Type:        schema.TypeString,
+							Description: "Value of the runbook_url annotation.",
+							Computed:    true,
+						},
+						"object": {
+							Type:        schema.TypeString,
+							Description: "PrometheusRule of the rule, as namespace/name.",
+							Computed:    true,
+						},
+						"group": {
+							Type:        schema.TypeString,
+							Description: "Group of the rule.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"duplicate_alerts": {
+				Type:        schema.TypeMap,
+				Description: "Names of the alerts defined in more than one PrometheusRule, with the comma separated objects defining them. Copies made by target_namespaces of a resource are separate objects.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"recording_conflicts": {
+				Type:        schema.TypeList,
+				Description: "Series recorded by rules of more than one PrometheusRule with different expressions.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"record": {
+							Type:        schema.TypeString,
+							Description: "Name of the recorded series.",
+							Computed:    true,
+						},
+						"labels": {
+							Type:        schema.TypeMap,
+							Description: "Labels of the recording rules.",
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"objects": {
+							Type:        schema.TypeList,
+							Description: "PrometheusRules with rules recording the series, as namespace/name.",
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"json": {
+				Type:        schema.TypeString,
+				Description: "Alerts of the catalog as a JSON array.",
+				Computed:    true,
+			},
+			"markdown": {
+				Type:        schema.TypeString,
+				Description: "Alerts of the catalog as a Markdown document, e.g. for an on-call handbook.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// catalogAlert is an alerting rule of the catalog.
+type catalogAlert struct {
+	Name       string `json:"name"`
+	Expr       string `json:"expr"`
+	For        string `json:"for,omitempty"`
+	Severity   string `json:"severity,omitempty"`
+	RunbookURL string `json:"runbook_url,omitempty"`
+	Object     string `json:"object"`
+	Group      string `json:"group"`
+}
+
+// catalogRecording is a recording rule of the catalog.
+type catalogRecording struct {
+	record string
+	labels map[string]string
+	expr   string
+	object string
+}
+
+type prometheusRuleList struct {
+	Items []PrometheusRule `json:"items"`
+}
+
+func dataSourcePOAlertCatalogRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*KubeClientsets).MonitoringClient.RESTClient()
+	namespaces := expandStringSlice(d.Get("namespaces").(*schema.Set).List())
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+	sort.Strings(namespaces)
+
+	objects := []PrometheusRule{}
+	for _, ns := range namespaces {
+		log.Printf("[INFO] Listing PrometheusRules in namespace %q", ns)
+		list, err := listPrometheusRules(conn, ns, d.Get("label_selector").(string))
+		if err != nil {
+			return fmt.Errorf("Failed to list PrometheusRules: %s", err)
+		}
+		objects = append(objects, list...)
+	}
+
+	alerts, recordings, err := catalogRules(objects)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Received %d PrometheusRules with %d alerts", len(objects), len(alerts))
+
+	att := make([]interface{}, len(alerts))
+	for i, a := range alerts {
+		att[i] = map[string]interface{}{
+			"name":        a.Name,
+			"expr":        a.Expr,
+			"for":         a.For,
+			"severity":    a.Severity,
+			"runbook_url": a.RunbookURL,
+			"object":      a.Object,
+			"group":       a.Group,
+		}
+	}
+	if err := d.Set("alerts", att); err != nil {
+		return fmt.Errorf("Error setting `alerts`: %+v", err)
+	}
+	duplicates := duplicateAlerts(alerts)
+	if err := d.Set("duplicate_alerts", duplicates); err != nil {
+		return fmt.Errorf("Error setting `duplicate_alerts`: %+v", err)
+	}
+	if err := d.Set("recording_conflicts", recordingConflicts(recordings)); err != nil {
+		return fmt.Errorf("Error setting `recording_conflicts`: %+v", err)
+	}
+
+	data, err := json.MarshalIndent(alerts, "", "  ")
+	if err != nil {
+		return err
+	}
+	d.Set("json", string(data))
+	d.Set("markdown", alertCatalogMarkdown(alerts, duplicates))
+
+	d.SetId(fmt.Sprintf("%x", sha256.Sum256(data)))
+	return nil
+}
+
+// listPrometheusRules lists the PrometheusRules of a namespace, or of all
+// namespaces if it is empty.
+func listPrometheusRules(client restclient.Interface, namespace, selector string) ([]PrometheusRule, error) {
+	req := client.Get().Namespace(namespace).Resource("prometheusrules")
+	if selector != "" {
+		req = req.Param("labelSelector", selector)
+	}
+	data, err := req.Do().Raw()
+	if err != nil {
+		return nil, err
+	}
+	list := &prometheusRuleList{}
+	if err := json.Unmarshal(data, list); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// catalogRules returns the sorted alerting rules and the recording rules of
+// the objects, normalized from their flattened groups.
+func catalogRules(objects []PrometheusRule) ([]catalogAlert, []catalogRecording, error) {
+	alerts := []catalogAlert{}
+	recordings := []catalogRecording{}
+	for _, obj := range objects {
+		id := buildId(obj.ObjectMeta)
+		groups, err := flattenRuleGroup(obj.Spec.Groups)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, g := range groups {
+			group := g.(map[string]interface{})
+			rules, _ := group["rules"].([]interface{})
+			for _, r := range rules {
+				rule := r.(map[string]interface{})
+				l, _ := rule["labels"].(map[string]interface{})
+				labels := expandStringMap(l)
+				a, _ := rule["annotations"].(map[string]interface{})
+				annotations := expandStringMap(a)
+				if rule["record"].(string) != "" {
+					recordings = append(recordings, catalogRecording{
+						record: rule["record"].(string),
+						labels: labels,
+						expr:   rule["expr"].(string),
+						object: id,
+					})
+					continue
+				}
+				alerts = append(alerts, catalogAlert{
+					Name:       rule["alert"].(string),
+					Expr:       rule["expr"].(string),
+					For:        rule["for"].(string),
+					Severity:   labels["severity"],
+					RunbookURL: annotations["runbook_url"],
+					Object:     id,
+					Group:      group["name"].(string),
+				})
+			}
+		}
+	}
+	sort.SliceStable(alerts, func(i, j int) bool {
+		if alerts[i].Name != alerts[j].Name {
+			return alerts[i].Name < alerts[j].Name
+		}
+		return alerts[i].Object < alerts[j].Object
+	})
+	return alerts, recordings, nil
+}
+
+// duplicateAlerts returns the alert names defined in more than one object,
+// with the objects defining them. Several rules of the same alert in one
+// object, e.g. with warning and critical thresholds, aren't duplicates.
+func duplicateAlerts(alerts []catalogAlert) map[string]interface{} {
+	objects := map[string][]string{}
+	for _, a := range alerts {
+		if l := objects[a.Name]; len(l) == 0 || l[len(l)-1] != a.Object {
+			objects[a.Name] = append(l, a.Object)
+		}
+	}
+	out := map[string]interface{}{}
+	for name, l := range objects {
+		if len(l) > 1 {
+			out[name] = strings.Join(l, ",")
+		}
+	}
+	return out
+}
+
+// recordingConflicts returns the series recorded in more than one object
+// with different expressions, sorted by name and labels.
+func recordingConflicts(recordings []catalogRecording) []interface{} {
+	type series struct {
+		record  string
+		labels  map[string]string
+		exprs   map[string]bool
+		objects map[string]bool
+	}
+	bySeries := map[string]*series{}
+	for _, r := range recordings {
+		key := r.record + labelSetKey(r.labels)
+		s, ok := bySeries[key]
+		if !ok {
+			s = &series{record: r.record, labels: r.labels, exprs: map[string]bool{}, objects: map[string]bool{}}
+			bySeries[key] = s
+		}
+		s.exprs[r.expr] = true
+		s.objects[r.object] = true
+	}
+	keys := make([]string, 0, len(bySeries))
+	for k, s := range bySeries {
+		if len(s.objects) > 1 && len(s.exprs) > 1 {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	out := make([]interface{}, len(keys))
+	for i, k := range keys {
+		s := bySeries[k]
+		objects := make([]string, 0, len(s.objects))
+		for o := range s.objects {
+			objects = append(objects, o)
+		}
+		sort.Strings(objects)
+		out[i] = map[string]interface{}{
+			"record":  s.record,
+			"labels":  s.labels,
+			"objects": objects,
+		}
+	}
+	return out
+}
+
+func alertCatalogMarkdown(alerts []catalogAlert, duplicates map[string]interface{}) string {
+	b := &bytes.Buffer{}
+	b.WriteString("# Alert catalog\n")
+	for _, a := range alerts {
+		fmt.Fprintf(b, "\n## %s\n\n", a.Name)
+		if a.Severity != "" {
+			fmt.Fprintf(b, "- Severity: %s\n", a.Severity)
+		}
+		if a.For != "" {
+			fmt.Fprintf(b, "- For: %s\n", a.For)
+		}
+		fmt.Fprintf(b, "- Defined in: %s, group %s\n", a.Object, a.Group)
+		if a.RunbookURL != "" {
+			fmt.Fprintf(b, "- Runbook: %s\n", a.RunbookURL)
+		}
+		fmt.Fprintf(b, "\n```promql\n%s\n```\n", strings.TrimSpace(a.Expr))
+	}
+	if len(duplicates) > 0 {
+		names := make([]string, 0, len(duplicates))
+		for n := range duplicates {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		b.WriteString("\n## Duplicate alerts\n\n")
+		for _, n := range names {
+			fmt.Fprintf(b, "- %s: %s\n", n, strings.Replace(duplicates[n].(string), ",", ", ", -1))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,93 @@
+package prometheus_operator
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	monclientv1 "github.com/coreos/prometheus-operator/pkg/client/versioned/typed/monitoring/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	restclient "k8s.io/client-go/rest"
+)
+
+func TestDataSourcePOAlertCatalog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if s := r.URL.Query().Get("labelSelector"); s != "role=alert-rules" {
+			t.Errorf("Unexpected label selector %q", s)
+		}
+		switch r.URL.Path {
+		case "/apis/monitoring.coreos.com/v1/namespaces/monitoring/prometheusrules":
+			w.Write([]byte(`{"items":[
+				{"metadata":{"name":"baseline","namespace":"monitoring"},"spec":{"groups":[{"name":"node","rules":[
+					{"alert":"NodeDown","expr":"up{job=\"node\"} == 0","for":"5m","labels":{"severity":"critical"},"annotations":{"runbook_url":"https://runbooks/nodedown"}},
+					{"alert":"DiskFull","expr":"disk_free < 10","labels":{"severity":"warning"}},
+					{"alert":"DiskFull","expr":"disk_free < 5","labels":{"severity":"critical"}},
+					{"record":"job:up:sum","expr":"sum by (job) (up)"},
+					{"record":"instance:load:avg","expr":"avg by (instance) (node_load1)"}
+				]}]}}
+			]}`))
+		case "/apis/monitoring.coreos.com/v1/namespaces/team-a/prometheusrules":
+			w.Write([]byte(`{"items":[
+				{"metadata":{"name":"api","namespace":"team-a"},"spec":{"groups":[{"name":"api","rules":[
+					{"alert":"NodeDown","expr":"up == 0"},
+					{"record":"job:up:sum","expr":"count by (job) (up == 1)"},
+					{"record":"instance:load:avg","expr":"avg by (instance) (node_load1)"},
+					{"record":"job:up:sum","expr":"sum by (job) (up)","labels":{"team":"a"}}
+				]}]}}
+			]}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	m, err := monclientv1.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := schema.TestResourceDataRaw(t, dataSourcePOAlertCatalog().Schema, map[string]interface{}{
+		"namespaces":     []interface{}{"team-a", "monitoring"},
+		"label_selector": "role=alert-rules",
+	})
+	if err := dataSourcePOAlertCatalogRead(d, &KubeClientsets{MonitoringClient: m}); err != nil {
+		t.Fatal(err)
+	}
+
+	for k, v := range map[string]string{
+		"alerts.#":                        "4",
+		"alerts.0.name":                   "DiskFull",
+		"alerts.0.severity":               "warning",
+		"alerts.1.severity":               "critical",
+		"alerts.2.name":                   "NodeDown",
+		"alerts.2.object":                 "monitoring/baseline",
+		"alerts.2.group":                  "node",
+		"alerts.2.for":                    "5m",
+		"alerts.2.runbook_url":            "https://runbooks/nodedown",
+		"alerts.3.object":                 "team-a/api",
+		"duplicate_alerts.%":              "1",
+		"duplicate_alerts.NodeDown":       "monitoring/baseline,team-a/api",
+		"recording_conflicts.#":           "1",
+		"recording_conflicts.0.record":    "job:up:sum",
+		"recording_conflicts.0.labels.%":  "0",
+		"recording_conflicts.0.objects.0": "monitoring/baseline",
+		"recording_conflicts.0.objects.1": "team-a/api",
+	} {
+		if got := fmt.Sprint(d.Get(k)); got != v {
+			t.Errorf("Expected %s to be %q, got %q", k, v, got)
+		}
+	}
+
+	if j := d.Get("json").(string); !strings.Contains(j, `"runbook_url": "https://runbooks/nodedown"`) {
+		t.Errorf("Expected runbook in JSON catalog, got %s", j)
+	}
+	expected := "## NodeDown\n\n- Severity: critical\n- For: 5m\n- Defined in: monitoring/baseline, group node\n- Runbook: https://runbooks/nodedown\n\n```promql\nup{job=\"node\"} == 0\n```\n"
+	md := d.Get("markdown").(string)
+	if !strings.HasPrefix(md, "# Alert catalog\n") || !strings.Contains(md, expected) ||
+		!strings.HasSuffix(md, "## Duplicate alerts\n\n- NodeDown: monitoring/baseline, team-a/api\n") {
+		t.Errorf("Unexpected Markdown catalog:\n%s", md)
+	}
+}
@@ -0,0 +1,265 @@
+package prometheus_operator
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/prometheus/common/model"
+)
+
+func dataSourcePOPrometheusQuery() *schema.Resource {
+	pointFields := func() map[string]*schema.Schema {
+		return map[string]*schema.Schema{
+			"timestamp": {
+				Type:        schema.TypeFloat,
+				Description: "Time of the sample, in seconds since the epoch.",
+				Computed:    true,
+			},
+			"value": {
+				Type:        schema.TypeFloat,
+				Description: "Value of the sample, 0 if it is NaN or infinite.",
+				Computed:    true,
+			},
+			"raw_value": {
+				Type:        schema.TypeString,
+				Description: "Value of the sample as returned by Prometheus, e.g. NaN or +Inf.",
+				Computed:    true,
+			},
+		}
+	}
+	vector := pointFields()
+	vector["labels"] = &schema.Schema{
+		Type:        schema.TypeMap,
+		Description: "Labels of the series.",
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+
+	return &schema.Resource{
+		Read: dataSourcePOPrometheusQueryRead,
+
+		Schema: map[string]*schema.Schema{
+			"prometheus": {
+				Type:        schema.TypeString,
+				Description: "ID of the po_prometheus, as namespace/name. Its API is reached through the proxy of the API server.",
+				Required:    true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, es []error) {
+					if _, _, err := idParts(v.(string)); err != nil {
+						es = append(es, fmt.Errorf("%s: %s", k, err))
+					}
+					return
+				},
+			},
+			"service": {
+				Type:        schema.TypeString,
+				Description: "Service of the Prometheus API, optionally followed by a colon and the port. The first replica of the Prometheus is reached through its pod if not set.",
+				Optional:    true,
+			},
+			"query": {
+				Type:        schema.TypeString,
+				Description: "PromQL expression.",
+				Required:    true,
+			},
+			"time": {
+				Type:          schema.TypeString,
+				Description:   "Evaluation time of an instant query in RFC 3339 format. Defaults to the current time.",
+				Optional:      true,
+				ValidateFunc:  validation.ValidateRFC3339TimeString,
+				ConflictsWith: []string{"start", "end", "step"},
+			},
+			"start": {
+				Type:         schema.TypeString,
+				Description:  "Start of a range query in RFC 3339 format. Requires end and step.",
+				Optional:     true,
+				ValidateFunc: validation.ValidateRFC3339TimeString,
+			},
+			"end": {
+				Type:         schema.TypeString,
+				Description:  "End of a range query in RFC 3339 format.",
+				Optional:     true,
+				ValidateFunc: validation.ValidateRFC3339TimeString,
+			},
+			"step": {
+				Type:         schema.TypeString,
+				Description:  "Resolution of a range query, e.g. 30s or 5m.",
+				Optional:     true,
+				ValidateFunc: validatePrometheusDuration,
+			},
+			"timeout": {
+				Type:         schema.TypeString,
+				Description:  "Evaluation timeout, e.g. 10s.",
+				Optional:     true,
+				ValidateFunc: validatePrometheusDuration,
+			},
+			"result_type": {
+				Type:        schema.TypeString,
+				Description: "Type of the result, one of vector, matrix, scalar or string.",
+				Computed:    true,
+			},
+			"vector": {
+				Type:        schema.TypeList,
+				Description: "Samples of a vector result.",
+				Computed:    true,
+				Elem:        &schema.Resource{Schema: vector},
+			},
+			"matrix": {
+				Type:        schema.TypeList,
+				Description: "Series of a matrix result.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"labels": {
+							Type:        schema.TypeMap,
+							Description: "Labels of the series.",
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"values": {
+							Type:        schema.TypeList,
+							Description: "Samples of the series.",
+							Computed:    true,
+							Elem:        &schema.Resource{Schema: pointFields()},
+						},
+					},
+				},
+			},
+			"scalar": {
+				Type:        schema.TypeList,
+				Description: "Sample of a scalar or string result.",
+				Computed:    true,
+				Elem:        &schema.Resource{Schema: pointFields()},
+			},
+			"warnings": {
+				Type:        schema.TypeList,
+				Description: "Warnings returned by Prometheus, e.g. about partial data.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func validatePrometheusDuration(v interface{}, k string) (ws []string, es []error) {
+	if _, err := model.ParseDuration(v.(string)); err != nil {
+		es = append(es, fmt.Errorf("%s: %s", k, err))
+	}
+	return
+}
+
+func dataSourcePOPrometheusQueryRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*KubeClientsets).MainClientset.CoreV1().RESTClient()
+	proxy, err := prometheusProxy(conn, d)
+	if err != nil {
+		return err
+	}
+
+	path, params, err := prometheusQueryParams(d)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Querying Prometheus %s: %v", d.Get("prometheus").(string), params)
+	data, warnings, err := prometheusAPI(proxy, path, params)
+	if err != nil {
+		return fmt.Errorf("Failed to query Prometheus: %s", err)
+	}
+	result := &prometheusQueryResult{}
+	if err := json.Unmarshal(data, result); err != nil {
+		return fmt.Errorf("Failed to decode query result: %s", err)
+	}
+	log.Printf("[INFO] Received %s result, warnings: %v", result.ResultType, warnings)
+
+	att, err := flattenPrometheusQueryResult(result)
+	if err != nil {
+		return err
+	}
+	for k, v := range att {
+		if err := d.Set(k, v); err != nil {
+			return fmt.Errorf("Error setting `%s`: %+v", k, err)
+		}
+	}
+	d.Set("result_type", result.ResultType)
+	d.Set("warnings", warnings)
+
+	body, _ := json.Marshal(params)
+	d.SetId(fmt.Sprintf("%s/%x", d.Get("prometheus").(string), sha256.Sum256(append([]byte(path), body...))))
+	return nil
+}
+
+// prometheusQueryParams returns the API path and parameters of the query,
+// which is a range query if start is set.
+func prometheusQueryParams(d resourceGetter) (string, map[string]string, error) {
+	params := map[string]string{
+		"query":   d.Get("query").(string),
+		"timeout": d.Get("timeout").(string),
+	}
+	if d.Get("start").(string) == "" {
+		if d.Get("end").(string) != "" || d.Get("step").(string) != "" {
+			return "", nil, fmt.Errorf("Range queries require start, end and step, start is not set")
+		}
+		params["time"] = d.Get("time").(string)
+		return "/api/v1/query", params, nil
+	}
+	for _, k := range []string{"start", "end", "step"} {
+		if params[k] = d.Get(k).(string); params[k] == "" {
+			return "", nil, fmt.Errorf("Range queries require start, end and step, %s is not set", k)
+		}
+	}
+	return "/api/v1/query_range", params, nil
+}
+
+// flattenPrometheusQueryResult returns the attribute holding the result,
+// and empty lists for the other result types.
+func flattenPrometheusQueryResult(in *prometheusQueryResult) (map[string]interface{}, error) {
+	att := map[string]interface{}{
+		"vector": []interface{}{},
+		"matrix": []interface{}{},
+		"scalar": []interface{}{},
+	}
+	switch in.ResultType {
+	case "vector", "matrix":
+		samples := []prometheusSample{}
+		if err := json.Unmarshal(in.Result, &samples); err != nil {
+			return nil, fmt.Errorf("Failed to decode %s result: %s", in.ResultType, err)
+		}
+		l := make([]interface{}, len(samples))
+		for i, s := range samples {
+			if in.ResultType == "vector" {
+				m := flattenPrometheusPoint(s.Value)
+				m["labels"] = s.Metric
+				l[i] = m
+				continue
+			}
+			values := make([]interface{}, len(s.Values))
+			for j, p := range s.Values {
+				values[j] = flattenPrometheusPoint(p)
+			}
+			l[i] = map[string]interface{}{
+				"labels": s.Metric,
+				"values": values,
+			}
+		}
+		att[in.ResultType] = l
+	case "scalar", "string":
+		p := prometheusPoint{}
+		if err := json.Unmarshal(in.Result, &p); err != nil {
+			return nil, fmt.Errorf("Failed to decode %s result: %s", in.ResultType, err)
+		}
+		att["scalar"] = []interface{}{flattenPrometheusPoint(p)}
+	default:
+		return nil, fmt.Errorf("Unexpected result type %q", in.ResultType)
+	}
+	return att, nil
+}
+
+func flattenPrometheusPoint(p prometheusPoint) map[string]interface{} {
+	v, _ := p.float()
+	return map[string]interface{}{
+		"timestamp": p.timestamp(),
+		"value":     v,
+		"raw_value": p.value(),
+	}
+}
@@ -0,0 +1,132 @@
+package prometheus_operator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// testPrometheusProxy is the proxy path of the first replica of the
+// Prometheus monitoring/k8s.
+const testPrometheusProxy = testPrometheusPodProxy + "-0:9090/proxy"
+
+// testPrometheus returns a Prometheus stand-in answering API paths with the
+// given bodies, behind the pod proxy path of the API server.
+func testPrometheus(t *testing.T, responses map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		for path, body := range responses {
+			if r.URL.Path == testPrometheusProxy+path {
+				if body == "" {
+					w.WriteHeader(http.StatusBadRequest)
+					w.Write([]byte(`{"status":"error","errorType":"bad_data","error":"parse error at char 4"}`))
+					return
+				}
+				w.Write([]byte(body))
+				return
+			}
+		}
+		t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+}
+
+func TestDataSourcePOPrometheusQuery_vector(t *testing.T) {
+	server := testPrometheus(t, map[string]string{
+		"/api/v1/query": `{"status":"success","data":{"resultType":"vector","result":[
+			{"metric":{"job":"api"},"value":[1577934245.5,"3"]},
+			{"metric":{"job":"db"},"value":[1577934245.5,"NaN"]}
+		]},"warnings":["partial data"]}`,
+	})
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, dataSourcePOPrometheusQuery().Schema, map[string]interface{}{
+		"prometheus": "monitoring/k8s",
+		"query":      "up",
+	})
+	if err := dataSourcePOPrometheusQueryRead(d, testProxyClientsets(t, server)); err != nil {
+		t.Fatal(err)
+	}
+	if d.Get("result_type").(string) != "vector" || d.Get("vector.#").(int) != 2 || d.Get("matrix.#").(int) != 0 {
+		t.Fatalf("Unexpected result %#v", d.State())
+	}
+	if v := d.Get("vector.0.value").(float64); v != 3 {
+		t.Errorf("Expected value 3, got %v", v)
+	}
+	if v := d.Get("vector.0.labels.job").(string); v != "api" {
+		t.Errorf("Expected label job=api, got %q", v)
+	}
+	if v := d.Get("vector.1.raw_value").(string); v != "NaN" {
+		t.Errorf("Expected raw value NaN, got %q", v)
+	}
+	if v := d.Get("warnings.0").(string); v != "partial data" {
+		t.Errorf("Expected warning, got %q", v)
+	}
+}
+
+func TestDataSourcePOPrometheusQuery_matrix(t *testing.T) {
+	server := testPrometheus(t, map[string]string{
+		"/api/v1/query_range": `{"status":"success","data":{"resultType":"matrix","result":[
+			{"metric":{"job":"api"},"values":[[1577934240,"1"],[1577934270,"2"]]}
+		]}}`,
+	})
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, dataSourcePOPrometheusQuery().Schema, map[string]interface{}{
+		"prometheus": "monitoring/k8s",
+		"query":      "up",
+		"start":      "2020-01-02T03:04:00Z",
+		"end":        "2020-01-02T03:04:30Z",
+		"step":       "30s",
+	})
+	if err := dataSourcePOPrometheusQueryRead(d, testProxyClientsets(t, server)); err != nil {
+		t.Fatal(err)
+	}
+	if d.Get("result_type").(string) != "matrix" || d.Get("matrix.0.values.#").(int) != 2 {
+		t.Fatalf("Unexpected result %#v", d.State())
+	}
+	if v := d.Get("matrix.0.values.1.timestamp").(float64); v != 1577934270 {
+		t.Errorf("Expected timestamp 1577934270, got %v", v)
+	}
+}
+
+func TestDataSourcePOPrometheusQuery_error(t *testing.T) {
+	server := testPrometheus(t, map[string]string{"/api/v1/query": ""})
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, dataSourcePOPrometheusQuery().Schema, map[string]interface{}{
+		"prometheus": "monitoring/k8s",
+		"query":      "up{",
+	})
+	err := dataSourcePOPrometheusQueryRead(d, testProxyClientsets(t, server))
+	if err == nil || err.Error() != "Failed to query Prometheus: Prometheus returned bad_data error: parse error at char 4" {
+		t.Errorf("Expected error of Prometheus, got %v", err)
+	}
+}
+
+func TestDataSourcePOPrometheusQuery_service(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/api/v1/namespaces/monitoring/services/thanos-querier:http/proxy/api/v1/query" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"status":"success","data":{"resultType":"scalar","result":[1577934245.5,"1"]}}`))
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, dataSourcePOPrometheusQuery().Schema, map[string]interface{}{
+		"prometheus": "monitoring/k8s",
+		"service":    "thanos-querier:http",
+		"query":      "1",
+	})
+	if err := dataSourcePOPrometheusQueryRead(d, testProxyClientsets(t, server)); err != nil {
+		t.Fatal(err)
+	}
+	if d.Get("result_type").(string) != "scalar" {
+		t.Errorf("Unexpected result %#v", d.State())
+	}
+}
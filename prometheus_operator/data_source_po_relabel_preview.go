@@ -0,0 +1,103 @@
+package prometheus_operator
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/pkg/relabel"
+)
+
+func dataSourcePORelabelPreview() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourcePORelabelPreviewRead,
+
+		Schema: map[string]*schema.Schema{
+			"relabel_configs": {
+				Type:        schema.TypeList,
+				Description: "Relabel configs applied in order, as in the relabelings of endpoints.",
+				Required:    true,
+				Elem: &schema.Resource{
+					Schema: genRelabelConfigSchema(),
+				},
+			},
+			"samples": {
+				Type:        schema.TypeList,
+				Description: "Label sets the relabel configs are applied to, e.g. the labels of a discovered target.",
+				Required:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"labels": {
+							Type:        schema.TypeMap,
+							Description: "Labels of the sample.",
+							Required:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"results": {
+				Type:        schema.TypeList,
+				Description: "Label sets after relabeling, in the order of the samples.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"labels": {
+							Type:        schema.TypeMap,
+							Description: "Labels of the sample after relabeling, empty if it was dropped.",
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"dropped": {
+							Type:        schema.TypeBool,
+							Description: "Whether the sample was dropped by a keep or drop action.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePORelabelPreviewRead(d *schema.ResourceData, meta interface{}) error {
+	configs := []*relabel.Config{}
+	for i, v := range d.Get("relabel_configs").([]interface{}) {
+		in, err := genExpandRelabelConfig([]interface{}{v})
+		if err != nil {
+			return err
+		}
+		c, err := prometheusRelabelConfig(*in)
+		if err != nil {
+			return fmt.Errorf("Invalid relabel_configs.%d: %s", i, err)
+		}
+		configs = append(configs, c)
+	}
+
+	results := []interface{}{}
+	for _, v := range d.Get("samples").([]interface{}) {
+		sample := map[string]string{}
+		if v != nil {
+			for k, l := range v.(map[string]interface{})["labels"].(map[string]interface{}) {
+				sample[k] = l.(string)
+			}
+		}
+		out := relabel.Process(labels.FromMap(sample), configs...)
+		results = append(results, map[string]interface{}{
+			"labels":  out.Map(),
+			"dropped": out == nil,
+		})
+	}
+	if err := d.Set("results", results); err != nil {
+		return fmt.Errorf("Error setting `results`: %+v", err)
+	}
+
+	data, err := json.Marshal([]interface{}{d.Get("relabel_configs"), d.Get("samples")})
+	if err != nil {
+		return err
+	}
+	d.SetId(fmt.Sprintf("%x", sha256.Sum256(data)))
+	return nil
+}
@@ -0,0 +1,351 @@
+package prometheus_operator
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/prometheus/common/model"
+)
+
+// backtestMaxPoints is the number of steps of one range query, below the
+// limit of 11000 points per series of Prometheus.
+var backtestMaxPoints = 10000
+
+func dataSourcePORuleBacktest() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourcePORuleBacktestRead,
+
+		Schema: map[string]*schema.Schema{
+			"prometheus": {
+				Type:        schema.TypeString,
+				Description: "ID of the po_prometheus, as namespace/name. Its API is reached through the proxy of the API server.",
+				Required:    true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, es []error) {
+					if _, _, err := idParts(v.(string)); err != nil {
+						es = append(es, fmt.Errorf("%s: %s", k, err))
+					}
+					return
+				},
+			},
+			"service": {
+				Type:        schema.TypeString,
+				Description: "Service of the Prometheus API, optionally followed by a colon and the port. The first replica of the Prometheus is reached through its pod if not set.",
+				Optional:    true,
+			},
+			"rule": {
+				Type:        schema.TypeList,
+				Description: "Alerting rule, as in the groups of po_prometheus_rule.",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: genRuleSchema(),
+				},
+			},
+			"start": {
+				Type:          schema.TypeString,
+				Description:   "Start of the backtest in RFC 3339 format.",
+				Optional:      true,
+				ValidateFunc:  validation.ValidateRFC3339TimeString,
+				ConflictsWith: []string{"lookback"},
+			},
+			"end": {
+				Type:         schema.TypeString,
+				Description:  "End of the backtest in RFC 3339 format. Defaults to the current time.",
+				Optional:     true,
+				ValidateFunc: validation.ValidateRFC3339TimeString,
+			},
+			"lookback": {
+				Type:         schema.TypeString,
+				Description:  "Duration of the backtest before end, if start is not set. Defaults to 7d.",
+				Optional:     true,
+				ValidateFunc: validatePrometheusDuration,
+			},
+			"step": {
+				Type:         schema.TypeString,
+				Description:  "Interval between evaluations of the rule, usually the interval of its group, e.g. 1m.",
+				Required:     true,
+				ValidateFunc: validatePrometheusDuration,
+			},
+			"evaluations": {
+				Type:        schema.TypeInt,
+				Description: "Number of evaluations of the rule.",
+				Computed:    true,
+			},
+			"firing_count": {
+				Type:        schema.TypeInt,
+				Description: "Number of times an alert of the rule would have started firing.",
+				Computed:    true,
+			},
+			"alerts": {
+				Type:        schema.TypeList,
+				Description: "Alerts which would have fired, by label set.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"labels": {
+							Type:        schema.TypeMap,
+							Description: "Labels of the alert, without expanding templates of the rule labels.",
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"firing_count": {
+							Type:        schema.TypeInt,
+							Description: "Number of times the alert would have started firing.",
+							Computed:    true,
+						},
+						"firing_seconds": {
+							Type:        schema.TypeFloat,
+							Description: "Total time the alert would have been firing.",
+							Computed:    true,
+						},
+						"intervals": {
+							Type:        schema.TypeList,
+							Description: "Intervals in which the alert would have been firing.",
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"start": {
+										Type:        schema.TypeString,
+										Description: "Evaluation at which the alert would have started firing.",
+										Computed:    true,
+									},
+									"end": {
+										Type:        schema.TypeString,
+										Description: "Evaluation at which the alert would have been resolved, or the end of the backtest.",
+										Computed:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// backtestAlert is the firing history of the alert of one label set.
+type backtestAlert struct {
+	labels    map[string]string
+	intervals [][2]time.Time
+}
+
+func dataSourcePORuleBacktestRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*KubeClientsets).MainClientset.CoreV1().RESTClient()
+	proxy, err := prometheusProxy(conn, d)
+	if err != nil {
+		return err
+	}
+	rules, err := expandRules(d.Get("rule").([]interface{}))
+	if err != nil {
+		return err
+	}
+	rule := rules[0]
+	if rule.Alert == "" {
+		return fmt.Errorf("rule.0.alert is not set, only alerting rules can be backtested")
+	}
+	var hold time.Duration
+	if rule.For != "" {
+		f, err := model.ParseDuration(rule.For)
+		if err != nil {
+			return fmt.Errorf("Failed to parse rule.0.for: %s", err)
+		}
+		hold = time.Duration(f)
+	}
+	start, end, step, err := backtestRange(d, time.Now())
+	if err != nil {
+		return err
+	}
+
+	timestamps := []time.Time{}
+	for t := start; !t.After(end); t = t.Add(step) {
+		timestamps = append(timestamps, t)
+	}
+	log.Printf("[INFO] Backtesting alert %s over %d evaluations from %s to %s", rule.Alert, len(timestamps), start, end)
+
+	series := map[string]map[int64]bool{}
+	seriesLabels := map[string]map[string]string{}
+	for i := 0; i < len(timestamps); i += backtestMaxPoints {
+		last := i + backtestMaxPoints - 1
+		if last >= len(timestamps) {
+			last = len(timestamps) - 1
+		}
+		data, _, err := prometheusAPI(proxy, "/api/v1/query_range", map[string]string{
+			"query": rule.Expr.String(),
+			"start": timestamps[i].Format(time.RFC3339),
+			"end":   timestamps[last].Format(time.RFC3339),
+			"step":  fmt.Sprintf("%gs", step.Seconds()),
+		})
+		if err != nil {
+			return fmt.Errorf("Failed to query Prometheus: %s", err)
+		}
+		result := &prometheusQueryResult{}
+		if err := json.Unmarshal(data, result); err != nil {
+			return fmt.Errorf("Failed to decode query result: %s", err)
+		}
+		samples := []prometheusSample{}
+		if err := json.Unmarshal(result.Result, &samples); err != nil || result.ResultType != "matrix" {
+			return fmt.Errorf("Failed to decode %s result of range query: %v", result.ResultType, err)
+		}
+		for _, s := range samples {
+			labels := alertLabels(s.Metric, rule.Labels)
+			key := labelSetKey(labels)
+			if series[key] == nil {
+				series[key] = map[int64]bool{}
+				seriesLabels[key] = labels
+			}
+			for _, p := range s.Values {
+				series[key][int64(math.Round(p.timestamp()))] = true
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(series))
+	for k := range series {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	alerts := []backtestAlert{}
+	for _, k := range keys {
+		intervals := simulateAlert(timestamps, series[k], hold)
+		if len(intervals) > 0 {
+			alerts = append(alerts, backtestAlert{labels: seriesLabels[k], intervals: intervals})
+		}
+	}
+
+	att, count := flattenBacktestAlerts(alerts)
+	if err := d.Set("alerts", att); err != nil {
+		return fmt.Errorf("Error setting `alerts`: %+v", err)
+	}
+	d.Set("firing_count", count)
+	d.Set("evaluations", len(timestamps))
+
+	d.SetId(fmt.Sprintf("%s/%s/%d-%d", d.Get("prometheus").(string), rule.Alert, start.Unix(), end.Unix()))
+	return nil
+}
+
+// backtestRange returns the evaluation times of the backtest, aligned to
+// whole seconds.
+func backtestRange(d resourceGetter, now time.Time) (time.Time, time.Time, time.Duration, error) {
+	s, err := model.ParseDuration(d.Get("step").(string))
+	if err != nil {
+		return time.Time{}, time.Time{}, 0, err
+	}
+	step := time.Duration(s)
+	if step < time.Second || step%time.Second != 0 {
+		return time.Time{}, time.Time{}, 0, fmt.Errorf("step must be a whole number of seconds, got %s", step)
+	}
+	end := now
+	if v := d.Get("end").(string); v != "" {
+		if end, err = time.Parse(time.RFC3339, v); err != nil {
+			return time.Time{}, time.Time{}, 0, err
+		}
+	}
+	end = end.Truncate(time.Second)
+	var start time.Time
+	if v := d.Get("start").(string); v != "" {
+		if start, err = time.Parse(time.RFC3339, v); err != nil {
+			return time.Time{}, time.Time{}, 0, err
+		}
+	} else {
+		lookback := model.Duration(7 * 24 * time.Hour)
+		if v := d.Get("lookback").(string); v != "" {
+			if lookback, err = model.ParseDuration(v); err != nil {
+				return time.Time{}, time.Time{}, 0, err
+			}
+		}
+		start = end.Add(-time.Duration(lookback))
+	}
+	start = start.Truncate(time.Second)
+	if !start.Before(end) {
+		return time.Time{}, time.Time{}, 0, fmt.Errorf("Start of the backtest %s is not before its end %s", start, end)
+	}
+	return start.UTC(), end.UTC(), step, nil
+}
+
+// simulateAlert returns the intervals in which the alert of a series fires,
+// given the evaluations at which the expression returned the series. Like
+// the rule manager, the alert is pending from the first such evaluation,
+// fires once it was pending for hold, and is resolved at the first
+// evaluation without the series.
+func simulateAlert(timestamps []time.Time, active map[int64]bool, hold time.Duration) [][2]time.Time {
+	out := [][2]time.Time{}
+	var activeSince, firingSince time.Time
+	pending, firing := false, false
+	for _, t := range timestamps {
+		if !active[t.Unix()] {
+			if firing {
+				out = append(out, [2]time.Time{firingSince, t})
+			}
+			pending, firing = false, false
+			continue
+		}
+		if !pending && !firing {
+			pending, activeSince = true, t
+		}
+		if pending && t.Sub(activeSince) >= hold {
+			pending, firing, firingSince = false, true, t
+		}
+	}
+	if firing {
+		out = append(out, [2]time.Time{firingSince, timestamps[len(timestamps)-1]})
+	}
+	return out
+}
+
+// alertLabels returns the labels of the alert of a series, without the
+// metric name and with the labels of the rule.
+func alertLabels(metric, rule map[string]string) map[string]string {
+	out := map[string]string{}
+	for k, v := range metric {
+		if k != model.MetricNameLabel {
+			out[k] = v
+		}
+	}
+	for k, v := range rule {
+		out[k] = v
+	}
+	return out
+}
+
+func labelSetKey(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", k, v))
+	}
+	sort.Strings(pairs)
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// flattenBacktestAlerts returns the alerts and the total number of times
+// they started firing.
+func flattenBacktestAlerts(in []backtestAlert) ([]interface{}, int) {
+	count := 0
+	att := make([]interface{}, len(in))
+	for i, a := range in {
+		intervals := make([]interface{}, len(a.intervals))
+		seconds := 0.0
+		for j, v := range a.intervals {
+			intervals[j] = map[string]interface{}{
+				"start": v[0].Format(time.RFC3339),
+				"end":   v[1].Format(time.RFC3339),
+			}
+			seconds += v[1].Sub(v[0]).Seconds()
+		}
+		count += len(a.intervals)
+		att[i] = map[string]interface{}{
+			"labels":         a.labels,
+			"firing_count":   len(a.intervals),
+			"firing_seconds": seconds,
+			"intervals":      intervals,
+		}
+	}
+	return att, count
+}
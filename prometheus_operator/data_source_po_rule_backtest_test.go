@@ -0,0 +1,115 @@
+package prometheus_operator
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestSimulateAlert(t *testing.T) {
+	start := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+	timestamps := []time.Time{}
+	for i := 0; i < 10; i++ {
+		timestamps = append(timestamps, start.Add(time.Duration(i)*time.Minute))
+	}
+	active := func(steps ...int) map[int64]bool {
+		out := map[int64]bool{}
+		for _, i := range steps {
+			out[timestamps[i].Unix()] = true
+		}
+		return out
+	}
+
+	cases := []struct {
+		active   map[int64]bool
+		hold     time.Duration
+		expected string
+	}{
+		{active(), 0, "[]"},
+		{active(1, 2), 0, "[[1 3]]"},
+		// Pending for 2m from step 1, firing at step 3 until resolved at step 5.
+		{active(1, 2, 3, 4), 2 * time.Minute, "[[3 5]]"},
+		// A gap resets the pending alert.
+		{active(1, 2, 4, 5), 2 * time.Minute, "[]"},
+		{active(0, 1, 2, 6, 7, 8, 9), time.Minute, "[[1 3] [7 9]]"},
+	}
+	for i, c := range cases {
+		steps := [][2]int{}
+		for _, v := range simulateAlert(timestamps, c.active, c.hold) {
+			steps = append(steps, [2]int{int(v[0].Sub(start) / time.Minute), int(v[1].Sub(start) / time.Minute)})
+		}
+		if got := fmt.Sprint(steps); got != c.expected {
+			t.Errorf("Case %d: expected intervals %s, got %s", i, c.expected, got)
+		}
+	}
+}
+
+func TestDataSourcePORuleBacktest(t *testing.T) {
+	defer func(n int) { backtestMaxPoints = n }(backtestMaxPoints)
+	backtestMaxPoints = 4
+
+	start := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC).Unix()
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != testPrometheusProxy+"/api/v1/query_range" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+		q := r.URL.Query()
+		queries = append(queries, q.Get("start")+" "+q.Get("end")+" "+q.Get("step"))
+		w.Header().Set("Content-Type", "application/json")
+		// The series of instance a is returned from step 1 to 5, of b only at step 2.
+		values := ""
+		for i := int64(0); i < 8; i++ {
+			ts := start + i*60
+			if q.Get("start") <= time.Unix(ts, 0).UTC().Format(time.RFC3339) && time.Unix(ts, 0).UTC().Format(time.RFC3339) <= q.Get("end") && i >= 1 && i <= 5 {
+				if values != "" {
+					values += ","
+				}
+				values += fmt.Sprintf(`[%d,"1"]`, ts)
+			}
+		}
+		fmt.Fprintf(w, `{"status":"success","data":{"resultType":"matrix","result":[
+			{"metric":{"__name__":"up","instance":"a"},"values":[%s]},
+			{"metric":{"__name__":"up","instance":"b"},"values":[[%d,"1"]]}
+		]}}`, values, start+120)
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, dataSourcePORuleBacktest().Schema, map[string]interface{}{
+		"prometheus": "monitoring/k8s",
+		"rule": []interface{}{map[string]interface{}{
+			"alert":  "InstanceUp",
+			"expr":   "up == 1",
+			"for":    "2m",
+			"labels": map[string]interface{}{"severity": "none"},
+		}},
+		"start": "2020-01-02T00:00:00Z",
+		"end":   "2020-01-02T00:07:00Z",
+		"step":  "1m",
+	})
+	if err := dataSourcePORuleBacktestRead(d, testProxyClientsets(t, server)); err != nil {
+		t.Fatal(err)
+	}
+	expectedQueries := "[2020-01-02T00:00:00Z 2020-01-02T00:03:00Z 60s 2020-01-02T00:04:00Z 2020-01-02T00:07:00Z 60s]"
+	if got := fmt.Sprint(queries); got != expectedQueries {
+		t.Errorf("Expected queries %s, got %s", expectedQueries, got)
+	}
+	for k, v := range map[string]string{
+		"evaluations":                "8",
+		"firing_count":               "1",
+		"alerts.#":                   "1",
+		"alerts.0.labels.instance":   "a",
+		"alerts.0.labels.severity":   "none",
+		"alerts.0.firing_seconds":    "180",
+		"alerts.0.intervals.0.start": "2020-01-02T00:03:00Z",
+		"alerts.0.intervals.0.end":   "2020-01-02T00:06:00Z",
+	} {
+		if got := fmt.Sprint(d.Get(k)); got != v {
+			t.Errorf("Expected %s to be %q, got %q", k, v, got)
+		}
+	}
+}
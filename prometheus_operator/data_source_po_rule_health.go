@@ -0,0 +1,233 @@
+package prometheus_operator
+
+import (
+	"fmt"
+	"log"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourcePORuleHealth() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourcePORuleHealthRead,
+
+		Schema: map[string]*schema.Schema{
+			"prometheus": {
+				Type:        schema.TypeString,
+				Description: "ID of the po_prometheus, as namespace/name. Its API is reached through the proxy of the API server.",
+				Required:    true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, es []error) {
+					if _, _, err := idParts(v.(string)); err != nil {
+						es = append(es, fmt.Errorf("%s: %s", k, err))
+					}
+					return
+				},
+			},
+			"service": {
+				Type:        schema.TypeString,
+				Description: "Service of the Prometheus API, optionally followed by a colon and the port. The first replica of the Prometheus is reached through its pod if not set.",
+				Optional:    true,
+			},
+			"prometheus_rules": {
+				Type:        schema.TypeSet,
+				Description: "PrometheusRules whose groups are returned, as namespace/name. The groups of a sharded po_prometheus_rule are in the objects listed in its shards. PrometheusRules without loaded groups are listed in missing_prometheus_rules. All groups are returned if not set.",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: func(v interface{}, k string) (ws []string, es []error) {
+						if _, _, err := idParts(v.(string)); err != nil {
+							es = append(es, fmt.Errorf("%s: %s", k, err))
+						}
+						return
+					},
+				},
+				Set: schema.HashString,
+			},
+			"groups": {
+				Type:        schema.TypeList,
+				Description: "Rule groups loaded by Prometheus.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "Name of the group.",
+							Computed:    true,
+						},
+						"file": {
+							Type:        schema.TypeString,
+							Description: "Rule file of the group.",
+							Computed:    true,
+						},
+						"interval": {
+							Type:        schema.TypeFloat,
+							Description: "Evaluation interval of the group in seconds.",
+							Computed:    true,
+						},
+						"last_evaluation": {
+							Type:        schema.TypeString,
+							Description: "Time of the last evaluation of the group.",
+							Computed:    true,
+						},
+						"evaluation_time": {
+							Type:        schema.TypeFloat,
+							Description: "Duration of the last evaluation of the group in seconds.",
+							Computed:    true,
+						},
+						"rules": {
+							Type:        schema.TypeList,
+							Description: "Rules of the group.",
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Description: "Name of the alert or of the recorded series.",
+										Computed:    true,
+									},
+									"type": {
+										Type:        schema.TypeString,
+										Description: "Type of the rule, alerting or recording.",
+										Computed:    true,
+									},
+									"query": {
+										Type:        schema.TypeString,
+										Description: "Expression of the rule.",
+										Computed:    true,
+									},
+									"health": {
+										Type:        schema.TypeString,
+										Description: "Health of the last evaluation, one of ok, err or unknown if the rule wasn't evaluated yet.",
+										Computed:    true,
+									},
+									"last_error": {
+										Type:        schema.TypeString,
+										Description: "Error of the last evaluation.",
+										Computed:    true,
+									},
+									"last_evaluation": {
+										Type:        schema.TypeString,
+										Description: "Time of the last evaluation of the rule.",
+										Computed:    true,
+									},
+									"evaluation_time": {
+										Type:        schema.TypeFloat,
+										Description: "Duration of the last evaluation of the rule in seconds.",
+										Computed:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"missing_prometheus_rules": {
+				Type:        schema.TypeList,
+				Description: "PrometheusRules of prometheus_rules of which Prometheus loaded no group, because the object doesn't exist, isn't selected by the Prometheus, wasn't reloaded yet or has no groups.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"unhealthy_rules": {
+				Type:        schema.TypeInt,
+				Description: "Number of rules of the groups whose last evaluation failed.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourcePORuleHealthRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*KubeClientsets).MainClientset.CoreV1().RESTClient()
+	proxy, err := prometheusProxy(conn, d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading rules of Prometheus %s", d.Get("prometheus").(string))
+	all, err := getRuleGroups(proxy)
+	if err != nil {
+		return fmt.Errorf("Failed to read rules of Prometheus: %s", err)
+	}
+
+	// files maps the rule files of the PrometheusRules to their ID.
+	files := map[string]string{}
+	if v, ok := d.Get("prometheus_rules").(*schema.Set); ok {
+		for _, id := range expandStringSlice(v.List()) {
+			ns, name, err := idParts(id)
+			if err != nil {
+				return err
+			}
+			files[ruleFileName(ns, name)] = id
+		}
+	}
+	groups := []prometheusRuleGroupStatus{}
+	loaded := map[string]bool{}
+	for _, g := range all {
+		file := path.Base(g.File)
+		if _, ok := files[file]; len(files) == 0 || ok {
+			groups = append(groups, g)
+			loaded[file] = true
+		}
+	}
+	missing := []string{}
+	for file, id := range files {
+		if !loaded[file] {
+			missing = append(missing, id)
+		}
+	}
+	sort.Strings(missing)
+	log.Printf("[INFO] Received %d rule groups, %d selected", len(all), len(groups))
+	if len(missing) > 0 {
+		log.Printf("[WARN] Prometheus %s loaded no group of %v", d.Get("prometheus").(string), missing)
+	}
+
+	att, unhealthy := flattenRuleGroupStatus(groups)
+	if err := d.Set("groups", att); err != nil {
+		return fmt.Errorf("Error setting `groups`: %+v", err)
+	}
+	d.Set("unhealthy_rules", unhealthy)
+	if err := d.Set("missing_prometheus_rules", missing); err != nil {
+		return fmt.Errorf("Error setting `missing_prometheus_rules`: %+v", err)
+	}
+
+	ids := expandStringSlice(d.Get("prometheus_rules").(*schema.Set).List())
+	sort.Strings(ids)
+	d.SetId(d.Get("prometheus").(string) + "/" + strings.Join(ids, ","))
+	return nil
+}
+
+// flattenRuleGroupStatus returns the groups and the number of rules whose
+// last evaluation failed.
+func flattenRuleGroupStatus(in []prometheusRuleGroupStatus) ([]interface{}, int) {
+	unhealthy := 0
+	att := make([]interface{}, len(in))
+	for i, g := range in {
+		rules := make([]interface{}, len(g.Rules))
+		for j, r := range g.Rules {
+			if r.Health == "err" {
+				unhealthy++
+			}
+			rules[j] = map[string]interface{}{
+				"name":            r.Name,
+				"type":            r.Type,
+				"query":           r.Query,
+				"health":          r.Health,
+				"last_error":      r.LastError,
+				"last_evaluation": r.LastEvaluation,
+				"evaluation_time": r.EvaluationTime,
+			}
+		}
+		att[i] = map[string]interface{}{
+			"name":            g.Name,
+			"file":            g.File,
+			"interval":        g.Interval,
+			"last_evaluation": g.LastEvaluation,
+			"evaluation_time": g.EvaluationTime,
+			"rules":           rules,
+		}
+	}
+	return att, unhealthy
+}
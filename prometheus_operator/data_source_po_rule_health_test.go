@@ -0,0 +1,58 @@
+package prometheus_operator
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestDataSourcePORuleHealth(t *testing.T) {
+	server := testPrometheus(t, map[string]string{
+		"/api/v1/rules": `{"status":"success","data":{"groups":[
+			{"name":"api","file":"/etc/prometheus/rules/prometheus-k8s-rulefiles-0/monitoring-api.yaml","interval":30,
+			 "lastEvaluation":"2020-01-02T03:04:05Z","evaluationTime":0.002,"rules":[
+				{"name":"job:up:sum","type":"recording","query":"sum by(job) (up)","health":"ok","evaluationTime":0.001},
+				{"name":"APIDown","type":"alerting","query":"up * on(instance) kube_pod_info == 0","health":"err",
+				 "lastError":"found duplicate series for the match group","evaluationTime":0.001}
+			]},
+			{"name":"db","file":"/etc/prometheus/rules/prometheus-k8s-rulefiles-0/monitoring-db.yaml","interval":30,"rules":[
+				{"name":"DBDown","type":"alerting","query":"up == 0","health":"err","lastError":"query timed out"}
+			]}
+		]}}`,
+	})
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, dataSourcePORuleHealth().Schema, map[string]interface{}{
+		"prometheus":       "monitoring/k8s",
+		"prometheus_rules": []interface{}{"monitoring/api", "monitoring/web"},
+	})
+	if err := dataSourcePORuleHealthRead(d, testProxyClientsets(t, server)); err != nil {
+		t.Fatal(err)
+	}
+	if n := d.Get("groups.#").(int); n != 1 {
+		t.Fatalf("Expected only the group of monitoring/api, got %d groups", n)
+	}
+	if n := d.Get("unhealthy_rules").(int); n != 1 {
+		t.Errorf("Expected 1 unhealthy rule, got %d", n)
+	}
+	if v := d.Get("missing_prometheus_rules").([]interface{}); len(v) != 1 || v[0] != "monitoring/web" {
+		t.Errorf("Expected monitoring/web to be missing, got %v", v)
+	}
+	if v := d.Get("groups.0.rules.1.last_error").(string); v != "found duplicate series for the match group" {
+		t.Errorf("Unexpected last error %q", v)
+	}
+	if v := d.Get("groups.0.evaluation_time").(float64); v != 0.002 {
+		t.Errorf("Unexpected evaluation time %v", v)
+	}
+
+	d.Set("prometheus_rules", []interface{}{})
+	if err := dataSourcePORuleHealthRead(d, testProxyClientsets(t, server)); err != nil {
+		t.Fatal(err)
+	}
+	if n := d.Get("unhealthy_rules").(int); n != 2 {
+		t.Errorf("Expected 2 unhealthy rules of all groups, got %d", n)
+	}
+	if n := d.Get("missing_prometheus_rules.#").(int); n != 0 {
+		t.Errorf("Expected no missing PrometheusRule, got %d", n)
+	}
+}
@@ -0,0 +1,124 @@
+package prometheus_operator
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func dataSourcePOStandardRules() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourcePOStandardRulesRead,
+
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:         schema.TypeString,
+				Description:  "Version of the standard rules of the provider. Released versions are never changed, so pinning one keeps the rules stable across provider upgrades. Defaults to standard_rules_version of the provider, or to the latest version.",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(standardRuleVersions(), false),
+			},
+			"bundles": {
+				Type:        schema.TypeSet,
+				Description: "Bundles of rules returned, among node, kubelet, apiserver, prometheus and alertmanager. All bundles are returned if not set.",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(standardRuleBundleNames(), false),
+				},
+				Set: schema.HashString,
+			},
+			"selectors": {
+				Type:        schema.TypeMap,
+				Description: "Label matchers of the series of each bundle, e.g. node = \"job=\\\"node-exporter\\\"\". Defaults to the jobs of kube-prometheus. An empty string selects the series of all jobs.",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"thresholds": {
+				Type:        schema.TypeMap,
+				Description: "Thresholds of the alerts, overriding the defaults: node_filesystem_free_percent (10), node_memory_used_percent (90), node_cpu_used_percent (90), kubelet_pleg_duration_seconds (10), apiserver_error_percent (3), apiserver_latency_seconds (1) and alertmanager_notification_failure_percent (1).",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeFloat},
+			},
+			"severities": {
+				Type:        schema.TypeMap,
+				Description: "Values of the severity label replacing the critical and warning severities of the rules, e.g. critical = \"page\".",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"runbook_url": {
+				Type:        schema.TypeString,
+				Description: "Base URL of the runbooks. If set, the runbook_url annotation of every alert is the base URL followed by the lowercase name of the alert.",
+				Optional:    true,
+			},
+			"labels": {
+				Type:         schema.TypeMap,
+				Description:  "Labels added to every alert.",
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ValidateFunc: validateLabels,
+			},
+			"annotations": {
+				Type:        schema.TypeMap,
+				Description: "Annotations added to every alert.",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"groups": {
+				Type:        schema.TypeList,
+				Description: "Rule groups, one per bundle, in the form of the groups of po_prometheus_rule.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: computedSchema(RuleGroupSchema()),
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePOStandardRulesRead(d *schema.ResourceData, meta interface{}) error {
+	version := d.Get("version").(string)
+	if clients, ok := meta.(*KubeClientsets); version == "" && ok {
+		version = clients.StandardRulesVersion
+	}
+	if version == "" {
+		version = standardRulesLatest
+	}
+	c := &standardRulesConfig{
+		Version:     version,
+		Bundles:     map[string]bool{},
+		Selectors:   expandStringMap(d.Get("selectors").(map[string]interface{})),
+		Thresholds:  map[string]float64{},
+		Severities:  expandStringMap(d.Get("severities").(map[string]interface{})),
+		RunbookURL:  d.Get("runbook_url").(string),
+		Labels:      expandStringMap(d.Get("labels").(map[string]interface{})),
+		Annotations: expandStringMap(d.Get("annotations").(map[string]interface{})),
+	}
+	for _, b := range expandStringSlice(d.Get("bundles").(*schema.Set).List()) {
+		c.Bundles[b] = true
+	}
+	for k, v := range d.Get("thresholds").(map[string]interface{}) {
+		c.Thresholds[k] = v.(float64)
+	}
+
+	log.Printf("[INFO] Rendering version %s of the standard rules", c.Version)
+	groups, err := c.ruleGroups()
+	if err != nil {
+		return err
+	}
+	if err := d.Set("groups", groups); err != nil {
+		return fmt.Errorf("Error setting `groups`: %+v", err)
+	}
+	d.Set("version", c.Version)
+
+	data, err := json.Marshal(groups)
+	if err != nil {
+		return err
+	}
+	d.SetId(fmt.Sprintf("%s-%x", c.Version, sha256.Sum256(data)))
+	return nil
+}
@@ -0,0 +1,77 @@
+package prometheus_operator
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestStandardRules_versions(t *testing.T) {
+	if _, ok := standardRules[standardRulesLatest]; !ok {
+		t.Fatalf("Latest version %s of the standard rules doesn't exist", standardRulesLatest)
+	}
+	// Every expression of every version renders and parses, with the default
+	// selectors and without any.
+	for _, version := range standardRuleVersions() {
+		for _, selectors := range []map[string]string{nil, {"node": "", "kubelet": "", "apiserver": "", "prometheus": "", "alertmanager": ""}} {
+			c := &standardRulesConfig{Version: version, Selectors: selectors}
+			if _, err := c.ruleGroups(); err != nil {
+				t.Errorf("Version %s: %s", version, err)
+			}
+		}
+	}
+}
+
+func TestDataSourcePOStandardRules(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dataSourcePOStandardRules().Schema, map[string]interface{}{
+		"bundles":     []interface{}{"node", "alertmanager"},
+		"selectors":   map[string]interface{}{"node": `job="node"`},
+		"thresholds":  map[string]interface{}{"node_filesystem_free_percent": 5.5},
+		"severities":  map[string]interface{}{"critical": "page"},
+		"runbook_url": "https://runbooks.example.com/",
+		"labels":      map[string]interface{}{"team": "platform"},
+	})
+	if err := dataSourcePOStandardRulesRead(d, &KubeClientsets{}); err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range map[string]string{
+		"version":                                  standardRulesLatest,
+		"groups.#":                                 "2",
+		"groups.0.name":                            "standard-node",
+		"groups.1.name":                            "standard-alertmanager",
+		"groups.0.rules.0.alert":                   "NodeExporterDown",
+		"groups.0.rules.0.expr":                    `up{job="node"} == 0`,
+		"groups.0.rules.0.for":                     "5m",
+		"groups.0.rules.0.labels.severity":         "page",
+		"groups.0.rules.0.labels.team":             "platform",
+		"groups.0.rules.0.annotations.runbook_url": "https://runbooks.example.com/nodeexporterdown",
+		"groups.0.rules.1.labels.severity":         "warning",
+		"groups.1.rules.0.expr":                    `max_over_time(alertmanager_config_last_reload_successful{job="alertmanager-main"}[5m]) == 0`,
+		"groups.0.rules.0.annotations.description": "Node exporter {{ $labels.instance }} has been unreachable for 5 minutes.",
+	} {
+		if got := fmt.Sprint(d.Get(k)); got != v {
+			t.Errorf("Expected %s to be %q, got %q", k, v, got)
+		}
+	}
+	if expr := d.Get("groups.0.rules.1.expr").(string); !strings.Contains(expr, `node_filesystem_avail_bytes{job="node",fstype!=""}`) ||
+		!strings.Contains(expr, "< 5.5") {
+		t.Errorf("Expected selector and threshold in filesystem alert, got %s", expr)
+	}
+
+	// The version pinned in the provider is used if the data source doesn't
+	// pin one.
+	d.Set("version", "")
+	if err := dataSourcePOStandardRulesRead(d, &KubeClientsets{StandardRulesVersion: "0"}); err == nil ||
+		!strings.Contains(err.Error(), `Unknown version "0"`) {
+		t.Errorf("Expected unknown version of the provider, got %v", err)
+	}
+
+	d.Set("version", "")
+	d.Set("thresholds", map[string]interface{}{"node_disk_percent": 1.0})
+	if err := dataSourcePOStandardRulesRead(d, &KubeClientsets{}); err == nil ||
+		!strings.Contains(err.Error(), `Unknown threshold "node_disk_percent"`) {
+		t.Errorf("Expected unknown threshold, got %v", err)
+	}
+}
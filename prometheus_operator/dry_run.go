@@ -0,0 +1,129 @@
+package prometheus_operator
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+	restclient "k8s.io/client-go/rest"
+)
+
+// resourceGetter is implemented by both *schema.ResourceData and
+// *schema.ResourceDiff, so objects sent during plan are expanded by the same
+// code as the ones sent during apply.
+type resourceGetter interface {
+	Id() string
+	Get(key string) interface{}
+	GetChange(key string) (interface{}, interface{})
+	HasChange(key string) bool
+}
+
+// objectBuilder returns the JSON body used to create the object.
+type objectBuilder func(d resourceGetter) ([]byte, error)
+
+type patchBuilder func(d resourceGetter) ([]byte, error)
+
+// serverSetMetadata are metadata attributes populated by the API server,
+// which are always unknown before the object is created.
+var serverSetMetadata = map[string]bool{
+	"metadata.0.generation":       true,
+	"metadata.0.resource_version": true,
+	"metadata.0.self_link":        true,
+	"metadata.0.uid":              true,
+}
+
+// dryRunDiff submits the object, or the patch for existing objects, with
+// dryRun=All, so that schema errors and admission webhook rejections are
+// reported during plan instead of apply.
+func dryRunDiff(resource string, build objectBuilder, patch patchBuilder) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		clients, ok := meta.(*KubeClientsets)
+		if !ok || !clients.DryRun {
+			return nil
+		}
+		if k, ok := unknownValue(d); ok {
+			log.Printf("[DEBUG] Skipping dry-run of %s %q, %s is known only after apply", resource, d.Id(), k)
+			return nil
+		}
+		client := clients.MonitoringClient.RESTClient()
+
+		if d.Id() == "" || d.HasChange("metadata.0.name") || d.HasChange("metadata.0.namespace") {
+			body, err := build(d)
+			if err != nil {
+				return err
+			}
+			namespace := d.Get("metadata.0.namespace").(string)
+			log.Printf("[INFO] Dry-run creating %s in namespace %q: %s", resource, namespace, body)
+			return dryRunCreate(client, resource, namespace, body)
+		}
+
+		if !d.HasChange("metadata") && !d.HasChange("spec") && !hasSpecOverrideChange(d) {
+			return nil
+		}
+		namespace, name, err := idParts(d.Id())
+		if err != nil {
+			return err
+		}
+		data, err := patch(d)
+		if err != nil {
+			return err
+		}
+		log.Printf("[INFO] Dry-run updating %s %q: %v", resource, name, string(data))
+		return dryRunPatch(client, resource, namespace, name, data)
+	}
+}
+
+func dryRunCreate(client restclient.Interface, resource, namespace string, body []byte) error {
+	err := client.Post().
+		Namespace(namespace).
+		Resource(resource).
+		Param("dryRun", "All").
+		Body(body).
+		Do().
+		Error()
+	if err != nil {
+		return fmt.Errorf("Dry-run create of %s was rejected: %s", resource, err)
+	}
+	return nil
+}
+
+func dryRunPatch(client restclient.Interface, resource, namespace, name string, data []byte) error {
+	err := client.Patch(pkgApi.JSONPatchType).
+		Namespace(namespace).
+		Resource(resource).
+		Name(name).
+		Param("dryRun", "All").
+		Body(data).
+		Do().
+		Error()
+	if err != nil {
+		return fmt.Errorf("Dry-run update of %s %q was rejected: %s", resource, name, err)
+	}
+	return nil
+}
+
+// unknownValue returns the first configured value which depends on other
+// resources and can't be sent to the API server yet. Counts of optional
+// computed blocks are ignored, as they are left empty on apply as well.
+func unknownValue(d *schema.ResourceDiff) (string, bool) {
+	if keys := unknownValues(d); len(keys) > 0 {
+		return keys[0], true
+	}
+	return "", false
+}
+
+// unknownValues returns all such values, see unknownValue.
+func unknownValues(d *schema.ResourceDiff) []string {
+	keys := []string{}
+	for _, k := range d.GetChangedKeysPrefix("") {
+		if k == "id" || serverSetMetadata[k] || strings.HasSuffix(k, ".#") || strings.HasSuffix(k, ".%") {
+			continue
+		}
+		if !d.NewValueKnown(k) {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,96 @@
+package prometheus_operator
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+const webhookDenied = `{"kind":"Status","apiVersion":"v1","status":"Failure","message":"admission webhook \"prometheusrulevalidate.monitoring.coreos.com\" denied the request: Rules are not valid","reason":"BadRequest","code":400}`
+
+func TestDryRunCreate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/apis/monitoring.coreos.com/v1/namespaces/monitoring/prometheusrules" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if v := r.URL.Query().Get("dryRun"); v != "All" {
+			t.Errorf("Expected dryRun=All, got %q", v)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(webhookDenied))
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourcePOPrometheusRule().Schema, map[string]interface{}{
+		"metadata": []interface{}{
+			map[string]interface{}{
+				"name":      "broken",
+				"namespace": "monitoring",
+			},
+		},
+		"spec": []interface{}{
+			map[string]interface{}{
+				"groups": []interface{}{
+					map[string]interface{}{
+						"name": "example",
+						"rules": []interface{}{
+							map[string]interface{}{
+								"alert": "Broken",
+								"expr":  "up ==",
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	body, err := prometheusRuleObject(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := testMonitoringClient(t, server, 0)
+	err = dryRunCreate(m.RESTClient(), "prometheusrules", "monitoring", body)
+	if err == nil {
+		t.Fatal("Expected webhook rejection to be reported")
+	}
+	if !strings.Contains(err.Error(), "Rules are not valid") {
+		t.Errorf("Expected webhook message in error, got %q", err)
+	}
+}
+
+func TestDryRunPatch(t *testing.T) {
+	var ops []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/apis/monitoring.coreos.com/v1/namespaces/monitoring/servicemonitors/example" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if v := r.URL.Query().Get("dryRun"); v != "All" {
+			t.Errorf("Expected dryRun=All, got %q", v)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/json-patch+json" {
+			t.Errorf("Expected JSON patch, got %q", ct)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &ops); err != nil {
+			t.Error(err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"kind":"ServiceMonitor","apiVersion":"monitoring.coreos.com/v1","metadata":{"name":"example","namespace":"monitoring"}}`))
+	}))
+	defer server.Close()
+
+	m := testMonitoringClient(t, server, 0)
+	if err := dryRunPatch(m.RESTClient(), "servicemonitors", "monitoring", "example", []byte(`[{"op":"add","path":"/metadata/labels/team","value":"sre"}]`)); err != nil {
+		t.Fatal(err)
+	}
+	if len(ops) != 1 || ops[0]["path"] != "/metadata/labels/team" {
+		t.Errorf("Expected patch to be sent unchanged, got %#v", ops)
+	}
+}
@@ -0,0 +1,382 @@
+package prometheus_operator
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	restclient "k8s.io/client-go/rest"
+)
+
+var fanOutKeys = []string{"target_namespaces", "target_namespace_selector"}
+
+// fanOutAnnotation marks the copies managed by the provider with the
+// namespace/name of the object they copy.
+const fanOutAnnotation = "terraform-provider-po/copy-of"
+
+// withFanOut adds the attributes copying the object into other namespaces
+// to the schema of a resource. Attributes in conflicts can't be combined
+// with them.
+func withFanOut(s map[string]*schema.Schema, conflicts ...string) map[string]*schema.Schema {
+	s["target_namespaces"] = &schema.Schema{
+		Type:          schema.TypeSet,
+		Description:   "Namespaces in which identical copies of the object are managed, besides the namespace of its metadata. Copies are marked with the annotation " + fanOutAnnotation + ", existing objects without it aren't taken over.",
+		Optional:      true,
+		Elem:          &schema.Schema{Type: schema.TypeString},
+		Set:           schema.HashString,
+		ConflictsWith: append([]string{"target_namespace_selector"}, conflicts...),
+	}
+	s["target_namespace_selector"] = &schema.Schema{
+		Type:          schema.TypeList,
+		Description:   "Selector of namespaces in which identical copies of the object are managed, besides the namespace of its metadata. Namespaces are selected again on every refresh. Copies are marked with the annotation " + fanOutAnnotation + ", existing objects without it aren't taken over.",
+		Optional:      true,
+		MaxItems:      1,
+		Elem:          &schema.Resource{Schema: labelSelectorFields(true)},
+		ConflictsWith: append([]string{"target_namespaces"}, conflicts...),
+	}
+	s["target_namespace_status"] = &schema.Schema{
+		Type:        schema.TypeMap,
+		Description: "Checksum of the labels, annotations and spec of the copy in every target namespace. Missing copies and copies changed outside of Terraform show up as changes.",
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+	return s
+}
+
+func isFanOut(d resourceGetter) bool {
+	for _, k := range fanOutKeys {
+		if v, ok := d.Get(k).(*schema.Set); ok && v.Len() > 0 {
+			return true
+		}
+		if v, ok := d.Get(k).([]interface{}); ok && len(v) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// targetNamespaces returns the sorted namespaces the object is copied to.
+// The namespace of the object itself and terminating namespaces are left
+// out.
+func targetNamespaces(d resourceGetter, namespaces corev1.NamespaceInterface) ([]string, error) {
+	home := d.Get("metadata.0.namespace").(string)
+	out := []string{}
+	if v, ok := d.Get("target_namespaces").(*schema.Set); ok && v.Len() > 0 {
+		for _, ns := range expandStringSlice(v.List()) {
+			if ns != home {
+				out = append(out, ns)
+			}
+		}
+		sort.Strings(out)
+		return out, nil
+	}
+	l, ok := d.Get("target_namespace_selector").([]interface{})
+	if !ok || len(l) == 0 {
+		return out, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(expandLabelSelector(l))
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse target_namespace_selector: %s", err)
+	}
+	list, err := namespaces.List(metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list target namespaces: %s", err)
+	}
+	for _, ns := range list.Items {
+		if ns.Name != home && ns.Status.Phase != v1.NamespaceTerminating {
+			out = append(out, ns.Name)
+		}
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// fanOutCopy returns the body of the copy of the object in namespace,
+// annotated with the namespace/name of the object.
+func fanOutCopy(body []byte, name, namespace string) (map[string]interface{}, error) {
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(body, &obj); err != nil {
+		return nil, err
+	}
+	metadata := map[string]interface{}{
+		"name":      name,
+		"namespace": namespace,
+	}
+	annotations := map[string]interface{}{}
+	if m, ok := obj["metadata"].(map[string]interface{}); ok {
+		if v, ok := m["labels"]; ok {
+			metadata["labels"] = v
+		}
+		if v, ok := m["annotations"].(map[string]interface{}); ok {
+			for k, a := range v {
+				annotations[k] = a
+			}
+		}
+		home, _ := m["namespace"].(string)
+		annotations[fanOutAnnotation] = home + "/" + name
+	}
+	metadata["annotations"] = annotations
+	obj["metadata"] = metadata
+	return obj, nil
+}
+
+// copyOf returns the object the object is a copy of, or "" if it isn't one.
+func copyOf(obj map[string]interface{}) string {
+	metadata, _ := obj["metadata"].(map[string]interface{})
+	annotations, _ := metadata["annotations"].(map[string]interface{})
+	v, _ := annotations[fanOutAnnotation].(string)
+	return v
+}
+
+// objectChecksum returns the checksum of the labels, annotations and spec
+// of the object, which are the fields kept identical in copies.
+func objectChecksum(obj map[string]interface{}) (string, error) {
+	metadata, _ := obj["metadata"].(map[string]interface{})
+	fields := map[string]interface{}{
+		"labels":      metadata["labels"],
+		"annotations": metadata["annotations"],
+		"spec":        obj["spec"],
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data))[:16], nil
+}
+
+// fanOutDiff plans the status of the copies, so the resource is updated when
+// a copy is missing or changed, or target namespaces appear or disappear.
+func fanOutDiff(build objectBuilder) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		o, _ := d.GetChange("target_namespace_status")
+		if !isFanOut(d) {
+			if len(o.(map[string]interface{})) > 0 {
+				return d.SetNew("target_namespace_status", map[string]interface{}{})
+			}
+			return nil
+		}
+		keys := []string{}
+		for _, k := range append([]string{"metadata.0.name", "metadata.0.namespace", "metadata.0.labels", "metadata.0.annotations", "spec"}, fanOutKeys...) {
+			keys = append(keys, d.GetChangedKeysPrefix(k)...)
+		}
+		for _, k := range keys {
+			if !d.NewValueKnown(k) {
+				log.Printf("[DEBUG] Copies of %q are known only after apply, %s is unknown", d.Id(), k)
+				return d.SetNewComputed("target_namespace_status")
+			}
+		}
+
+		namespaces, err := targetNamespaces(d, meta.(*KubeClientsets).MainClientset.CoreV1().Namespaces())
+		if err != nil {
+			return err
+		}
+		body, err := build(d)
+		if err != nil {
+			return err
+		}
+		status := map[string]interface{}{}
+		for _, ns := range namespaces {
+			obj, err := fanOutCopy(body, d.Get("metadata.0.name").(string), ns)
+			if err != nil {
+				return err
+			}
+			if status[ns], err = objectChecksum(obj); err != nil {
+				return err
+			}
+		}
+		if !reflect.DeepEqual(status, o) {
+			return d.SetNew("target_namespace_status", status)
+		}
+		return nil
+	}
+}
+
+// applyFanOut creates or replaces the copies of the object in the target
+// namespaces and deletes the copies from namespaces no longer targeted.
+func applyFanOut(d *schema.ResourceData, meta interface{}, resource string, build objectBuilder) error {
+	clients := meta.(*KubeClientsets)
+	client := clients.MonitoringClient.RESTClient()
+	o, _ := d.GetChange("target_namespace_status")
+	previous := o.(map[string]interface{})
+	if !isFanOut(d) && len(previous) == 0 {
+		return nil
+	}
+	_, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	namespaces, err := targetNamespaces(d, clients.MainClientset.CoreV1().Namespaces())
+	if err != nil {
+		return err
+	}
+	body, err := build(d)
+	if err != nil {
+		return err
+	}
+	status := map[string]interface{}{}
+	for _, ns := range namespaces {
+		obj, err := fanOutCopy(body, name, ns)
+		if err != nil {
+			return err
+		}
+		_, owned := previous[ns]
+		if err := upsertObject(client, resource, obj, owned); err != nil {
+			return fmt.Errorf("Failed to copy %s %q to namespace %q: %s", resource, name, ns, err)
+		}
+		if status[ns], err = objectChecksum(obj); err != nil {
+			return err
+		}
+	}
+	for ns := range previous {
+		if _, ok := status[ns]; ok {
+			continue
+		}
+		if err := deleteObject(client, resource, ns, name); err != nil {
+			return err
+		}
+	}
+	return d.Set("target_namespace_status", status)
+}
+
+// readFanOut reads the checksums of the copies of the object. Missing copies
+// are left out of the status.
+func readFanOut(d *schema.ResourceData, meta interface{}, resource string) error {
+	client := meta.(*KubeClientsets).MonitoringClient.RESTClient()
+	_, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	status := map[string]interface{}{}
+	for ns := range d.Get("target_namespace_status").(map[string]interface{}) {
+		data, err := client.Get().
+			Namespace(ns).
+			Resource(resource).
+			Name(name).
+			Do().
+			Raw()
+		if err != nil {
+			if errors.IsNotFound(err) {
+				log.Printf("[DEBUG] Copy of %s %q was not found in Namespace %q", resource, name, ns)
+				continue
+			}
+			return err
+		}
+		obj := map[string]interface{}{}
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return err
+		}
+		if status[ns], err = objectChecksum(obj); err != nil {
+			return err
+		}
+	}
+	return d.Set("target_namespace_status", status)
+}
+
+func deleteFanOut(d *schema.ResourceData, meta interface{}, resource string) error {
+	client := meta.(*KubeClientsets).MonitoringClient.RESTClient()
+	_, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	for ns := range d.Get("target_namespace_status").(map[string]interface{}) {
+		if err := deleteObject(client, resource, ns, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// upsertObject creates the object, or replaces the labels, annotations and
+// spec of the existing one. Unless owned, which is the case of copies in the
+// state, the existing object is taken over only if it is annotated as a copy
+// of the same object, so objects managed otherwise aren't overwritten.
+func upsertObject(client restclient.Interface, resource string, obj map[string]interface{}, owned bool) error {
+	metadata := obj["metadata"].(map[string]interface{})
+	namespace, _ := metadata["namespace"].(string)
+	name, _ := metadata["name"].(string)
+	body, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Creating %s %q in namespace %q: %s", resource, name, namespace, body)
+	err = client.Post().
+		Namespace(namespace).
+		Resource(resource).
+		Body(body).
+		Do().
+		Error()
+	if !errors.IsAlreadyExists(err) {
+		return err
+	}
+	if !owned {
+		data, err := client.Get().
+			Namespace(namespace).
+			Resource(resource).
+			Name(name).
+			Do().
+			Raw()
+		if err != nil {
+			return err
+		}
+		existing := map[string]interface{}{}
+		if err := json.Unmarshal(data, &existing); err != nil {
+			return err
+		}
+		if copyOf(existing) != copyOf(obj) {
+			return fmt.Errorf("the object already exists without the annotation %s=%s and isn't taken over", fanOutAnnotation, copyOf(obj))
+		}
+	}
+
+	data, err := replaceObjectPatch(obj)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Updating %s %q in namespace %q: %s", resource, name, namespace, data)
+	return client.Patch(pkgApi.JSONPatchType).
+		Namespace(namespace).
+		Resource(resource).
+		Name(name).
+		Body(data).
+		Do().
+		Error()
+}
+
+// replaceObjectPatch sets the labels, annotations and spec of an existing
+// object. Add operations replace members which already exist.
+func replaceObjectPatch(obj map[string]interface{}) ([]byte, error) {
+	metadata := obj["metadata"].(map[string]interface{})
+	ops := []map[string]interface{}{}
+	for _, k := range []string{"labels", "annotations"} {
+		v, ok := metadata[k]
+		if !ok {
+			v = map[string]interface{}{}
+		}
+		ops = append(ops, map[string]interface{}{"op": "add", "path": "/metadata/" + k, "value": v})
+	}
+	ops = append(ops, map[string]interface{}{"op": "add", "path": "/spec", "value": obj["spec"]})
+	return json.Marshal(ops)
+}
+
+func deleteObject(client restclient.Interface, resource, namespace, name string) error {
+	log.Printf("[INFO] Deleting %s %q in namespace %q", resource, name, namespace)
+	err := client.Delete().
+		Namespace(namespace).
+		Resource(resource).
+		Name(name).
+		Do().
+		Error()
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("Failed to delete %s %q in namespace %q: %s", resource, name, namespace, err)
+	}
+	return nil
+}
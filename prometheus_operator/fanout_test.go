@@ -0,0 +1,168 @@
+package prometheus_operator
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+)
+
+func TestTargetNamespaces(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/namespaces" || r.URL.Query().Get("labelSelector") != "tenant=true" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"kind":"NamespaceList","apiVersion":"v1","items":[
+			{"metadata":{"name":"tenant-b"},"status":{"phase":"Active"}},
+			{"metadata":{"name":"monitoring"},"status":{"phase":"Active"}},
+			{"metadata":{"name":"tenant-old"},"status":{"phase":"Terminating"}},
+			{"metadata":{"name":"tenant-a"},"status":{"phase":"Active"}}
+		]}`))
+	}))
+	defer server.Close()
+	k, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourcePOServiceMonitor().Schema, map[string]interface{}{
+		"metadata": []interface{}{map[string]interface{}{
+			"name":      "app",
+			"namespace": "monitoring",
+		}},
+		"target_namespace_selector": []interface{}{map[string]interface{}{
+			"match_labels": map[string]interface{}{"tenant": "true"},
+		}},
+	})
+	namespaces, err := targetNamespaces(d, k.CoreV1().Namespaces())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"tenant-a", "tenant-b"}; !reflect.DeepEqual(namespaces, expected) {
+		t.Errorf("Expected %v, got %v", expected, namespaces)
+	}
+
+	d = schema.TestResourceDataRaw(t, resourcePOServiceMonitor().Schema, map[string]interface{}{
+		"metadata": []interface{}{map[string]interface{}{
+			"name":      "app",
+			"namespace": "monitoring",
+		}},
+		"target_namespaces": []interface{}{"tenant-c", "monitoring", "tenant-a"},
+	})
+	namespaces, err = targetNamespaces(d, k.CoreV1().Namespaces())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"tenant-a", "tenant-c"}; !reflect.DeepEqual(namespaces, expected) {
+		t.Errorf("Expected %v, got %v", expected, namespaces)
+	}
+}
+
+func TestFanOutCopyChecksum(t *testing.T) {
+	body := []byte(`{"kind":"ServiceMonitor","metadata":{"name":"app","namespace":"monitoring","generateName":"app-","labels":{"team":"a"}},"spec":{"endpoints":[{"port":"web"}]}}`)
+	obj, err := fanOutCopy(body, "app", "tenant-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"name":        "app",
+		"namespace":   "tenant-a",
+		"labels":      map[string]interface{}{"team": "a"},
+		"annotations": map[string]interface{}{fanOutAnnotation: "monitoring/app"},
+	}
+	if !reflect.DeepEqual(obj["metadata"], expected) {
+		t.Errorf("Expected metadata %v, got %v", expected, obj["metadata"])
+	}
+
+	// The copy read back from the API server has server-set fields.
+	read := map[string]interface{}{}
+	json.Unmarshal([]byte(`{"kind":"ServiceMonitor","metadata":{"name":"app","namespace":"tenant-a","uid":"1234","resourceVersion":"42","labels":{"team":"a"},"annotations":{"terraform-provider-po/copy-of":"monitoring/app"}},"spec":{"endpoints":[{"port":"web"}]}}`), &read)
+	want, err := objectChecksum(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := objectChecksum(read)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want != got {
+		t.Errorf("Expected checksum of the copy read back to match, got %s and %s", want, got)
+	}
+
+	read["spec"] = map[string]interface{}{"endpoints": []interface{}{map[string]interface{}{"port": "metrics"}}}
+	if changed, _ := objectChecksum(read); changed == want {
+		t.Error("Expected checksum to change with the spec")
+	}
+}
+
+func TestUpsertObject(t *testing.T) {
+	requests := []string{}
+	existing := `{"kind":"ServiceMonitor","apiVersion":"monitoring.coreos.com/v1","metadata":{"name":"app","namespace":"tenant-a"}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"AlreadyExists","code":409}`))
+		case http.MethodGet:
+			w.Write([]byte(existing))
+		case http.MethodPatch:
+			body, _ := ioutil.ReadAll(r.Body)
+			ops := []map[string]interface{}{}
+			if err := json.Unmarshal(body, &ops); err != nil || len(ops) != 3 || ops[2]["path"] != "/spec" {
+				t.Errorf("Unexpected patch %s", body)
+			}
+			w.Write([]byte(existing))
+		}
+	}))
+	defer server.Close()
+
+	obj, err := fanOutCopy([]byte(`{"metadata":{"name":"app","namespace":"monitoring"},"spec":{}}`), "app", "tenant-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := testMonitoringClient(t, server, 0)
+	if err := upsertObject(m.RESTClient(), "servicemonitors", obj, true); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"POST /apis/monitoring.coreos.com/v1/namespaces/tenant-a/servicemonitors",
+		"PATCH /apis/monitoring.coreos.com/v1/namespaces/tenant-a/servicemonitors/app",
+	}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Errorf("Expected requests %v, got %v", expected, requests)
+	}
+
+	// Objects which aren't in the state are taken over only if annotated as
+	// copies of the same object.
+	requests = []string{}
+	err = upsertObject(m.RESTClient(), "servicemonitors", obj, false)
+	if err == nil || !strings.Contains(err.Error(), "without the annotation terraform-provider-po/copy-of=monitoring/app") {
+		t.Errorf("Expected unannotated object not to be taken over, got %v", err)
+	}
+	expected = []string{
+		"POST /apis/monitoring.coreos.com/v1/namespaces/tenant-a/servicemonitors",
+		"GET /apis/monitoring.coreos.com/v1/namespaces/tenant-a/servicemonitors/app",
+	}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Errorf("Expected requests %v, got %v", expected, requests)
+	}
+
+	requests = []string{}
+	existing = `{"kind":"ServiceMonitor","apiVersion":"monitoring.coreos.com/v1","metadata":{"name":"app","namespace":"tenant-a","annotations":{"terraform-provider-po/copy-of":"monitoring/app"}}}`
+	if err := upsertObject(m.RESTClient(), "servicemonitors", obj, false); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(requests); n != 3 {
+		t.Errorf("Expected the annotated copy to be updated, got requests %v", requests)
+	}
+}
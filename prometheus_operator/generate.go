@@ -0,0 +1,9 @@
+package prometheus_operator
+
+// Schema, expand and flatten functions of operator types prefixed with gen
+// are generated from github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1,
+// and the CRD validation schemas from the CRDs of the operator module.
+// Run go generate after updating the operator dependency.
+
+//go:generate go run ../internal/codegen -overrides ../internal/codegen/overrides.json
+//go:generate go run ../internal/crdgen
@@ -93,7 +93,7 @@ func expandMetadata(in []interface{}) metav1.ObjectMeta {
 	return k8s.ExpandMetadata(in)
 }
 
-func patchMetadata(keyPrefix, pathPrefix string, d *schema.ResourceData) k8s.PatchOperations {
+func patchMetadata(keyPrefix, pathPrefix string, d resourceGetter) k8s.PatchOperations {
 	return k8s.PatchMetadata(keyPrefix, pathPrefix, d)
 }
 
@@ -314,4 +314,24 @@ func labelSelectorFields(updatable bool) map[string]*schema.Schema {
 
 func validateLabels(value interface{}, key string) (ws []string, es []error) {
 	return k8s.ValidateLabels(value, key)
-}
\ No newline at end of file
+}
+
+func persistentVolumeClaimSpecFields() map[string]*schema.Schema {
+	return k8s.PersistentVolumeClaimSpecFields()
+}
+
+func flattenPersistentVolumeClaimSpec(in v1.PersistentVolumeClaimSpec) []interface{} {
+	return k8s.FlattenPersistentVolumeClaimSpec(in)
+}
+
+func expandPersistentVolumeClaimSpec(l []interface{}) (*v1.PersistentVolumeClaimSpec, error) {
+	return k8s.ExpandPersistentVolumeClaimSpec(l)
+}
+
+func flattenEmptyDirVolumeSource(in *v1.EmptyDirVolumeSource) []interface{} {
+	return k8s.FlattenEmptyDirVolumeSource(in)
+}
+
+func expandEmptyDirVolumeSource(l []interface{}) *v1.EmptyDirVolumeSource {
+	return k8s.ExpandEmptyDirVolumeSource(l)
+}
@@ -0,0 +1,261 @@
+package prometheus_operator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	po_types "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+)
+
+var manifestFormats = []string{"json", "yaml"}
+
+// manifestPollInterval is the interval of status checks while waiting for
+// a condition.
+var manifestPollInterval = 5 * time.Second
+
+// manifestSchema returns the manifest_<format> attribute holding the object.
+func manifestSchema(format string) *schema.Schema {
+	keys := []string{}
+	for _, f := range manifestFormats {
+		keys = append(keys, "manifest_"+f)
+	}
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ExactlyOneOf: keys,
+		Description:  fmt.Sprintf("The %s object in %s. Only fields set here are compared with the object in the cluster.", po_types.SchemeGroupVersion.Group, strings.ToUpper(format)),
+		ValidateFunc: func(v interface{}, k string) (ws []string, es []error) {
+			if _, err := parseManifest(v.(string)); err != nil {
+				es = append(es, fmt.Errorf("%s: %s", k, err))
+			}
+			return
+		},
+		DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+			o, err := parseObject(old, "manifest")
+			if err != nil {
+				return false
+			}
+			n, err := parseObject(new, "manifest")
+			if err != nil {
+				return false
+			}
+			return reflect.DeepEqual(o, n)
+		},
+	}
+}
+
+// parseManifest decodes the manifest and checks it identifies an object
+// of the monitoring.coreos.com group.
+func parseManifest(s string) (map[string]interface{}, error) {
+	m, err := parseObject(s, "manifest")
+	if err != nil {
+		return nil, err
+	}
+	u := &unstructured.Unstructured{Object: m}
+	gv, err := k8sschema.ParseGroupVersion(u.GetAPIVersion())
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case gv.Group != po_types.SchemeGroupVersion.Group:
+		return nil, fmt.Errorf("apiVersion %q is not in the %s group", u.GetAPIVersion(), po_types.SchemeGroupVersion.Group)
+	case u.GetKind() == "":
+		return nil, fmt.Errorf("kind is required")
+	case u.GetName() == "":
+		return nil, fmt.Errorf("metadata.name is required")
+	}
+	return m, nil
+}
+
+// manifest returns the attribute holding the manifest and its parsed value,
+// or an empty attribute name if none is set.
+func manifest(d resourceGetter) (string, map[string]interface{}, error) {
+	for _, f := range manifestFormats {
+		k := "manifest_" + f
+		if v, ok := d.Get(k).(string); ok && v != "" {
+			m, err := parseManifest(v)
+			if err != nil {
+				return "", nil, fmt.Errorf("Failed to parse %s: %s", k, err)
+			}
+			return k, m, nil
+		}
+	}
+	return "", nil, nil
+}
+
+// oldManifest returns the manifest as it was before the current change.
+func oldManifest(d resourceGetter) (map[string]interface{}, error) {
+	for _, f := range manifestFormats {
+		o, _ := d.GetChange("manifest_" + f)
+		if v, ok := o.(string); ok && v != "" {
+			return parseObject(v, "manifest")
+		}
+	}
+	return map[string]interface{}{}, nil
+}
+
+// manifestObject returns the object to submit, in the default namespace if
+// none is set.
+func manifestObject(m map[string]interface{}) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: m}
+	u = u.DeepCopy()
+	if u.GetNamespace() == "" {
+		u.SetNamespace("default")
+	}
+	return u
+}
+
+func buildManifestId(u *unstructured.Unstructured) string {
+	return strings.Join([]string{u.GetAPIVersion(), u.GetKind(), u.GetNamespace(), u.GetName()}, "/")
+}
+
+// manifestIdParts splits IDs of the form apiVersion/kind/namespace/name.
+func manifestIdParts(id string) (k8sschema.GroupVersionKind, string, string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 5 {
+		err := fmt.Errorf("Unexpected ID format (%q), expected apiVersion/kind/namespace/name, e.g. monitoring.coreos.com/v1/Probe/monitoring/example", id)
+		return k8sschema.GroupVersionKind{}, "", "", err
+	}
+	gvk := k8sschema.GroupVersionKind{Group: parts[0], Version: parts[1], Kind: parts[2]}
+	return gvk, parts[3], parts[4], nil
+}
+
+// manifestResource looks up the resource serving kind, so CRDs released
+// after this provider can be managed as well.
+func manifestResource(client discovery.DiscoveryInterface, gvk k8sschema.GroupVersionKind) (k8sschema.GroupVersionResource, bool, error) {
+	gv := gvk.GroupVersion()
+	list, err := client.ServerResourcesForGroupVersion(gv.String())
+	if err != nil {
+		return k8sschema.GroupVersionResource{}, false, fmt.Errorf("Failed to discover resources of %s: %s", gv, err)
+	}
+	for _, r := range list.APIResources {
+		if r.Kind == gvk.Kind && !strings.Contains(r.Name, "/") {
+			return gv.WithResource(r.Name), r.Namespaced, nil
+		}
+	}
+	return k8sschema.GroupVersionResource{}, false, fmt.Errorf("Kind %s is not served by %s, is its CRD installed?", gvk.Kind, gv)
+}
+
+func manifestClient(meta interface{}, gvk k8sschema.GroupVersionKind, namespace string) (dynamic.ResourceInterface, error) {
+	clients := meta.(*KubeClientsets)
+	gvr, namespaced, err := manifestResource(clients.MainClientset.Discovery(), gvk)
+	if err != nil {
+		return nil, err
+	}
+	if !namespaced {
+		return clients.DynamicClient.Resource(gvr), nil
+	}
+	return clients.DynamicClient.Resource(gvr).Namespace(namespace), nil
+}
+
+// projectFields returns the parts of live which are set in config, so
+// fields defaulted by the API server or set by others aren't reported as
+// drift. Lists of the same length are projected element by element.
+func projectFields(live, config interface{}) interface{} {
+	switch c := config.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			return live
+		}
+		out := map[string]interface{}{}
+		for k, cv := range c {
+			if lv, ok := l[k]; ok {
+				out[k] = projectFields(lv, cv)
+			}
+		}
+		return out
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok || len(l) != len(c) {
+			return live
+		}
+		out := make([]interface{}, len(l))
+		for i := range l {
+			out[i] = projectFields(l[i], c[i])
+		}
+		return out
+	}
+	return live
+}
+
+// mergePatch returns the JSON merge patch (RFC 7386) turning old into new.
+// Fields removed from the manifest are removed from the object.
+func mergePatch(old, new map[string]interface{}) map[string]interface{} {
+	patch := map[string]interface{}{}
+	for k := range old {
+		if _, ok := new[k]; !ok {
+			patch[k] = nil
+		}
+	}
+	for k, nv := range new {
+		ov, ok := old[k]
+		if ok && reflect.DeepEqual(ov, nv) {
+			continue
+		}
+		om, oIsMap := ov.(map[string]interface{})
+		nm, nIsMap := nv.(map[string]interface{})
+		if ok && oIsMap && nIsMap {
+			patch[k] = mergePatch(om, nm)
+			continue
+		}
+		patch[k] = nv
+	}
+	return patch
+}
+
+// importedManifest returns the manifest of imported objects, which only
+// identifies the object. Updates merge the configured manifest into the
+// object and remove the fields of the previous manifest missing from it, so
+// the fields of the live object must not end up in the state.
+func importedManifest(u *unstructured.Unstructured) map[string]interface{} {
+	out := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	out.SetAPIVersion(u.GetAPIVersion())
+	out.SetKind(u.GetKind())
+	out.SetName(u.GetName())
+	if u.GetNamespace() != "" {
+		out.SetNamespace(u.GetNamespace())
+	}
+	return out.Object
+}
+
+// waitForCondition polls the object until the status condition of type
+// condition has the given status.
+func waitForCondition(client dynamic.ResourceInterface, name, condition, status string, timeout time.Duration) error {
+	last := "unknown"
+	err := wait.PollImmediate(manifestPollInterval, timeout, func() (bool, error) {
+		u, err := client.Get(name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		conditions, _, err := unstructured.NestedSlice(u.Object, "status", "conditions")
+		if err != nil {
+			return false, err
+		}
+		for _, c := range conditions {
+			m, ok := c.(map[string]interface{})
+			if !ok || m["type"] != condition {
+				continue
+			}
+			last = fmt.Sprint(m["status"])
+			if msg, ok := m["message"].(string); ok && msg != "" {
+				last += " (" + msg + ")"
+			}
+			return m["status"] == status, nil
+		}
+		return false, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return fmt.Errorf("Timed out waiting for condition %s of %q to be %s, last status: %s", condition, name, status, last)
+	}
+	return err
+}
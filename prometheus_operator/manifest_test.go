@@ -0,0 +1,234 @@
+package prometheus_operator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	restclient "k8s.io/client-go/rest"
+)
+
+func TestParseManifest(t *testing.T) {
+	cases := []struct {
+		manifest string
+		expected string
+	}{
+		{
+			manifest: "apiVersion: monitoring.coreos.com/v1\nkind: Probe\nmetadata:\n  name: example\n",
+		},
+		{
+			manifest: `{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "example"}}`,
+			expected: `apiVersion "apps/v1" is not in the monitoring.coreos.com group`,
+		},
+		{
+			manifest: `{"apiVersion": "monitoring.coreos.com/v1", "metadata": {"name": "example"}}`,
+			expected: "kind is required",
+		},
+		{
+			manifest: `{"apiVersion": "monitoring.coreos.com/v1", "kind": "Probe", "metadata": {"generateName": "example-"}}`,
+			expected: "metadata.name is required",
+		},
+		{
+			manifest: "- a\n- b\n",
+			expected: "manifest must be an object",
+		},
+	}
+	for _, c := range cases {
+		_, err := parseManifest(c.manifest)
+		switch {
+		case c.expected == "" && err != nil:
+			t.Errorf("Unexpected error for %q: %s", c.manifest, err)
+		case c.expected != "" && (err == nil || !strings.Contains(err.Error(), c.expected)):
+			t.Errorf("Expected error %q for %q, got %v", c.expected, c.manifest, err)
+		}
+	}
+}
+
+func TestManifestId(t *testing.T) {
+	m, err := parseManifest(`{"apiVersion": "monitoring.coreos.com/v1alpha1", "kind": "AlertmanagerConfig", "metadata": {"name": "example"}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := buildManifestId(manifestObject(m))
+	if id != "monitoring.coreos.com/v1alpha1/AlertmanagerConfig/default/example" {
+		t.Fatalf("Unexpected ID %q", id)
+	}
+
+	gvk, namespace, name, err := manifestIdParts(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := k8sschema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1alpha1", Kind: "AlertmanagerConfig"}
+	if gvk != expected || namespace != "default" || name != "example" {
+		t.Errorf("Unexpected ID parts %v %q %q", gvk, namespace, name)
+	}
+
+	if _, _, _, err := manifestIdParts("monitoring/example"); err == nil {
+		t.Error("Expected an error for an ID without apiVersion and kind")
+	}
+}
+
+func TestProjectFields(t *testing.T) {
+	live := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":            "example",
+			"resourceVersion": "42",
+			"labels":          map[string]interface{}{"app": "example", "added-by": "someone"},
+		},
+		"spec": map[string]interface{}{
+			"interval": "60s",
+			"module":   "http_2xx",
+			"targets": []interface{}{
+				map[string]interface{}{"url": "https://example.com", "labels": map[string]interface{}{"env": "prod"}},
+			},
+		},
+	}
+	config := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":   "example",
+			"labels": map[string]interface{}{"app": "example"},
+		},
+		"spec": map[string]interface{}{
+			"interval": "30s",
+			"targets": []interface{}{
+				map[string]interface{}{"url": "https://example.com"},
+			},
+			"prober": map[string]interface{}{"url": "blackbox:9115"},
+		},
+	}
+
+	expected := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":   "example",
+			"labels": map[string]interface{}{"app": "example"},
+		},
+		"spec": map[string]interface{}{
+			"interval": "60s",
+			"targets": []interface{}{
+				map[string]interface{}{"url": "https://example.com"},
+			},
+		},
+	}
+	if out := projectFields(live, config); !reflect.DeepEqual(out, expected) {
+		t.Errorf("Expected only configured fields with live values, got %#v", out)
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	old := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":   "example",
+			"labels": map[string]interface{}{"app": "example", "team": "a"},
+		},
+		"spec": map[string]interface{}{
+			"interval": "30s",
+			"module":   "http_2xx",
+		},
+	}
+	new := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":   "example",
+			"labels": map[string]interface{}{"app": "example", "team": "b"},
+		},
+		"spec": map[string]interface{}{
+			"interval": "30s",
+			"targets":  []interface{}{"https://example.com"},
+		},
+	}
+
+	expected := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{"team": "b"},
+		},
+		"spec": map[string]interface{}{
+			"module":  nil,
+			"targets": []interface{}{"https://example.com"},
+		},
+	}
+	if patch := mergePatch(old, new); !reflect.DeepEqual(patch, expected) {
+		t.Errorf("Unexpected patch %#v", patch)
+	}
+}
+
+func TestImportedManifest(t *testing.T) {
+	live := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "monitoring.coreos.com/v1",
+		"kind":       "Probe",
+		"metadata": map[string]interface{}{
+			"name":            "example",
+			"namespace":       "monitoring",
+			"uid":             "1234",
+			"resourceVersion": "42",
+			"labels":          map[string]interface{}{"app": "example"},
+		},
+		"spec":   map[string]interface{}{"interval": "30s", "module": "http_2xx"},
+		"status": map[string]interface{}{},
+	}}
+	config := map[string]interface{}{
+		"apiVersion": "monitoring.coreos.com/v1",
+		"kind":       "Probe",
+		"metadata":   map[string]interface{}{"name": "example", "namespace": "monitoring"},
+		"spec":       map[string]interface{}{"interval": "1m"},
+	}
+
+	// The first update after the import only sets the configured fields.
+	expected := map[string]interface{}{
+		"spec": map[string]interface{}{"interval": "1m"},
+	}
+	if patch := mergePatch(importedManifest(live), config); !reflect.DeepEqual(patch, expected) {
+		t.Errorf("Unexpected patch %#v", patch)
+	}
+}
+
+func TestWaitForCondition(t *testing.T) {
+	defer func(d time.Duration) { manifestPollInterval = d }(manifestPollInterval)
+	manifestPollInterval = time.Millisecond
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apis/monitoring.coreos.com/v1/namespaces/monitoring/thanosrulers/example" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		status := "False"
+		if atomic.AddInt32(&requests, 1) >= 3 {
+			status = "True"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"apiVersion": "monitoring.coreos.com/v1",
+			"kind": "ThanosRuler",
+			"metadata": {"name": "example", "namespace": "monitoring"},
+			"status": {"conditions": [
+				{"type": "Reconciled", "status": "True"},
+				{"type": "Available", "status": "` + status + `", "message": "waiting for pods"}
+			]}
+		}`))
+	}))
+	defer server.Close()
+
+	dc, err := dynamic.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	gvr := k8sschema.GroupVersionResource{Group: "monitoring.coreos.com", Version: "v1", Resource: "thanosrulers"}
+	client := dc.Resource(gvr).Namespace("monitoring")
+
+	if err := waitForCondition(client, "example", "Available", "True", time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 3 {
+		t.Errorf("Expected 3 requests, got %d", n)
+	}
+
+	err = waitForCondition(client, "example", "Reconciled", "False", 20*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "Timed out waiting for condition Reconciled") {
+		t.Errorf("Expected timeout, got %v", err)
+	}
+}
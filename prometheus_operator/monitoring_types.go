@@ -0,0 +1,159 @@
+package prometheus_operator
+
+import (
+	po_types "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Kinds and fields added to monitoring.coreos.com after the vendored operator
+// release. New kinds are submitted through the dynamic client, and their
+// types follow the operator API of the release which introduced them.
+
+var (
+	probeGVR       = po_types.SchemeGroupVersion.WithResource("probes")
+	thanosRulerGVR = po_types.SchemeGroupVersion.WithResource("thanosrulers")
+)
+
+// PrometheusRule is po_types.PrometheusRule with the rule group fields added
+// for Thanos Ruler.
+type PrometheusRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              PrometheusRuleSpec `json:"spec"`
+}
+
+// PrometheusRuleSpec contains specification parameters for a Rule.
+type PrometheusRuleSpec struct {
+	// Content of Prometheus rule file
+	Groups []RuleGroup `json:"groups,omitempty"`
+}
+
+// RuleGroup is a list of sequentially evaluated recording and alerting rules.
+type RuleGroup struct {
+	po_types.RuleGroup `json:",inline"`
+	// How Thanos Ruler handles partial responses of the queries, abort or warn.
+	// Ignored by Prometheus.
+	PartialResponseStrategy string `json:"partial_response_strategy,omitempty"`
+}
+
+// Probe defines monitoring for a set of static targets or ingresses,
+// scraped through a prober such as blackbox exporter.
+type Probe struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              ProbeSpec `json:"spec"`
+}
+
+// ProbeSpec contains specification parameters for a Probe.
+type ProbeSpec struct {
+	// The job name assigned to scraped metrics by default.
+	JobName string `json:"jobName,omitempty"`
+	// Specification for the prober to use for probing targets.
+	ProberSpec ProberSpec `json:"prober,omitempty"`
+	// The module to use for probing specifying how to probe the target.
+	Module string `json:"module,omitempty"`
+	// Targets defines a set of static and/or dynamically discovered targets to be probed.
+	Targets ProbeTargets `json:"targets,omitempty"`
+	// Interval at which targets are probed using the configured prober.
+	Interval string `json:"interval,omitempty"`
+	// Timeout for scraping metrics from the Prometheus exporter.
+	ScrapeTimeout string `json:"scrapeTimeout,omitempty"`
+}
+
+// ProberSpec contains specification parameters for the Prober used for probing.
+type ProberSpec struct {
+	// Mandatory URL of the prober.
+	URL string `json:"url"`
+	// HTTP scheme to use for scraping. Defaults to http.
+	Scheme string `json:"scheme,omitempty"`
+	// Path to collect metrics from. Defaults to /probe.
+	Path string `json:"path,omitempty"`
+}
+
+// ProbeTargets defines a set of static and dynamically discovered targets
+// for the prober.
+type ProbeTargets struct {
+	StaticConfig *ProbeTargetStaticConfig `json:"staticConfig,omitempty"`
+	Ingress      *ProbeTargetIngress      `json:"ingress,omitempty"`
+}
+
+// ProbeTargetStaticConfig defines the set of static targets considered for probing.
+type ProbeTargetStaticConfig struct {
+	// Targets is a list of URLs to probe using the configured prober.
+	Targets []string `json:"static,omitempty"`
+	// Labels assigned to all metrics scraped from the targets.
+	Labels map[string]string `json:"labels,omitempty"`
+	// RelabelConfigs to apply to samples before ingestion.
+	RelabelConfigs []*po_types.RelabelConfig `json:"relabelingConfigs,omitempty"`
+}
+
+// ProbeTargetIngress defines the set of Ingress objects considered for probing.
+type ProbeTargetIngress struct {
+	// Select Ingress objects by labels.
+	Selector metav1.LabelSelector `json:"selector,omitempty"`
+	// Select Ingress objects by namespace.
+	NamespaceSelector po_types.NamespaceSelector `json:"namespaceSelector,omitempty"`
+	// RelabelConfigs to apply to samples before ingestion.
+	RelabelConfigs []*po_types.RelabelConfig `json:"relabelingConfigs,omitempty"`
+}
+
+// ThanosRuler defines a Thanos Ruler deployment evaluating PrometheusRules
+// against Thanos Query endpoints.
+type ThanosRuler struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              ThanosRulerSpec `json:"spec"`
+}
+
+// ThanosRulerSpec is a specification of the desired behavior of the Thanos Ruler.
+type ThanosRulerSpec struct {
+	// Thanos container image URL.
+	Image string `json:"image,omitempty"`
+	// Number of thanos ruler instances to deploy.
+	Replicas *int32 `json:"replicas,omitempty"`
+	// ServiceAccountName is the name of the ServiceAccount to use to run the Thanos Ruler Pods.
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// Priority class assigned to the Pods.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// Define which Nodes the Pods are scheduled on.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Resources defines the resource requirements for single Pods.
+	Resources v1.ResourceRequirements `json:"resources,omitempty"`
+	// If specified, the pod's tolerations.
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+	// SecurityContext holds pod-level security attributes and common container settings.
+	SecurityContext *v1.PodSecurityContext `json:"securityContext,omitempty"`
+	// Storage spec to specify how storage shall be used.
+	Storage *po_types.StorageSpec `json:"storage,omitempty"`
+	// Volumes allows configuration of additional volumes on the output StatefulSet definition.
+	Volumes []v1.Volume `json:"volumes,omitempty"`
+	// ObjectStorageConfig configures object storage in Thanos.
+	ObjectStorageConfig *v1.SecretKeySelector `json:"objectStorageConfig,omitempty"`
+	// ListenLocal makes the Thanos ruler listen on loopback.
+	ListenLocal bool `json:"listenLocal,omitempty"`
+	// QueryEndpoints defines Thanos querier endpoints from which to query metrics.
+	QueryEndpoints []string `json:"queryEndpoints,omitempty"`
+	// Define URLs to send alerts to Alertmanager.
+	AlertManagersURL []string `json:"alertmanagersUrl,omitempty"`
+	// A label selector to select which PrometheusRules to mount for alerting and recording.
+	RuleSelector *metav1.LabelSelector `json:"ruleSelector,omitempty"`
+	// Namespaces to be selected for Rules discovery.
+	RuleNamespaceSelector *metav1.LabelSelector `json:"ruleNamespaceSelector,omitempty"`
+	// Log level for ThanosRuler to be configured with.
+	LogLevel string `json:"logLevel,omitempty"`
+	// Log format for ThanosRuler to be configured with.
+	LogFormat string `json:"logFormat,omitempty"`
+	// Port name used for the pods and governing service. This defaults to web
+	PortName string `json:"portName,omitempty"`
+	// Interval between consecutive evaluations.
+	EvaluationInterval string `json:"evaluationInterval,omitempty"`
+	// Time duration ThanosRuler shall retain data for.
+	Retention string `json:"retention,omitempty"`
+	// Containers allows injecting additional containers or modifying operator generated containers.
+	Containers []v1.Container `json:"containers,omitempty"`
+	// InitContainers allows adding initContainers to the pod definition.
+	InitContainers []v1.Container `json:"initContainers,omitempty"`
+	// Labels configure the external label pairs to ThanosRuler.
+	Labels map[string]string `json:"labels,omitempty"`
+}
@@ -0,0 +1,215 @@
+package prometheus_operator
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/promql"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	namespaceLabel = "namespace"
+
+	// enforceInject adds the namespace matcher to the selectors missing it,
+	// enforceVerify rejects them.
+	enforceInject = "inject"
+	enforceVerify = "verify"
+)
+
+// enforceNamespaceMatcher adds the matcher namespace="<namespace>" to every
+// vector and matrix selector of the expression which doesn't have it, like
+// prom-label-proxy. It fails if a selector has another matcher of the
+// namespace label, and returns whether the expression was changed.
+func enforceNamespaceMatcher(expr promql.Node, namespace string) (bool, error) {
+	changed := false
+	var err error
+	enforce := func(matchers []*labels.Matcher) []*labels.Matcher {
+		for _, m := range matchers {
+			if m.Name != namespaceLabel {
+				continue
+			}
+			if m.Type != labels.MatchEqual || m.Value != namespace {
+				err = fmt.Errorf("selector matcher %s conflicts with namespace %q", m, namespace)
+			}
+			return matchers
+		}
+		changed = true
+		return append(matchers, &labels.Matcher{Type: labels.MatchEqual, Name: namespaceLabel, Value: namespace})
+	}
+	promql.Inspect(expr, func(node promql.Node, path []promql.Node) error {
+		switch n := node.(type) {
+		case *promql.VectorSelector:
+			n.LabelMatchers = enforce(n.LabelMatchers)
+		case *promql.MatrixSelector:
+			n.LabelMatchers = enforce(n.LabelMatchers)
+		}
+		return err
+	})
+	return changed, err
+}
+
+// namespacedExpr returns the expression with the namespace matcher on every
+// selector. Expressions which already have it are returned unchanged, the
+// others are formatted by the PromQL printer.
+func namespacedExpr(expr, namespace string) (string, error) {
+	node, err := promql.ParseExpr(expr)
+	if err != nil {
+		return "", err
+	}
+	changed, err := enforceNamespaceMatcher(node, namespace)
+	if err != nil || !changed {
+		return expr, err
+	}
+	return node.String(), nil
+}
+
+// verifyNamespacedExpr fails if a selector of the expression doesn't have
+// the namespace matcher.
+func verifyNamespacedExpr(expr, namespace string) error {
+	node, err := promql.ParseExpr(expr)
+	if err != nil {
+		return err
+	}
+	changed, err := enforceNamespaceMatcher(node, namespace)
+	if err != nil {
+		return err
+	}
+	if changed {
+		return fmt.Errorf("selectors without matcher %s=%q, expected %s", namespaceLabel, namespace, node)
+	}
+	return nil
+}
+
+// injectNamespaceLabel rewrites the expressions of the groups if the
+// namespace label is injected into po_prometheus_rule.
+func injectNamespaceLabel(d resourceGetter, groups []RuleGroup) error {
+	if d.Get("enforce_namespace_label").(string) != enforceInject {
+		return nil
+	}
+	namespace := d.Get("metadata.0.namespace").(string)
+	for i, g := range groups {
+		for j, r := range g.Rules {
+			if r.Expr.Type != intstr.String {
+				continue
+			}
+			expr, err := namespacedExpr(r.Expr.StrVal, namespace)
+			if err != nil {
+				return fmt.Errorf("Failed to enforce namespace label in group %q, rule %d: %s", g.Name, j, err)
+			}
+			groups[i].Rules[j].Expr = intstr.FromString(expr)
+		}
+	}
+	return nil
+}
+
+// configuredNamespacedExprs keeps the configured expressions in the groups
+// read from the cluster if they differ only by the injected namespace
+// matchers, so they don't show a diff.
+func configuredNamespacedExprs(d resourceGetter, groups []interface{}) {
+	if d.Get("enforce_namespace_label").(string) != enforceInject {
+		return
+	}
+	namespace := d.Get("metadata.0.namespace").(string)
+	for i, g := range groups {
+		rules, _ := g.(map[string]interface{})["rules"].([]interface{})
+		for j, r := range rules {
+			rule := r.(map[string]interface{})
+			configured, ok := d.Get(fmt.Sprintf("spec.0.groups.%d.rules.%d.expr", i, j)).(string)
+			if !ok || configured == "" || configured == rule["expr"] {
+				continue
+			}
+			if expr, err := namespacedExpr(configured, namespace); err == nil && expr == rule["expr"] {
+				rule["expr"] = configured
+			}
+		}
+	}
+}
+
+// enforceNamespaceLabelDiff rejects rule expressions reading series of other
+// namespaces, if enforce_namespace_label is set in the resource or in the
+// provider. The expressions of the whole object are checked, including the
+// spec overrides. Objects copied to other namespaces are rejected. Objects
+// with values known only after apply are checked by Create and Update.
+func enforceNamespaceLabelDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if namespaceLabelMode(d, meta) == "" {
+		return nil
+	}
+	if isFanOut(d) {
+		// Copies would keep the matchers of the namespace of the object.
+		return fmt.Errorf("PrometheusRule %q can't be copied to other namespaces, enforce_namespace_label is set in the provider", d.Get("metadata.0.name"))
+	}
+	if k, ok := unknownValue(d); ok {
+		log.Printf("[DEBUG] Deferring namespace label check of %q to apply, %s is known only after apply", d.Id(), k)
+		return nil
+	}
+	return verifyNamespaceLabel(d, meta)
+}
+
+func namespaceLabelMode(d resourceGetter, meta interface{}) string {
+	mode := d.Get("enforce_namespace_label").(string)
+	if clients, ok := meta.(*KubeClientsets); mode == "" && ok && clients.EnforceNamespaceLabel {
+		mode = enforceVerify
+	}
+	return mode
+}
+
+// verifyNamespaceLabel checks the expressions of the PrometheusRule built
+// from d, see enforceNamespaceLabelDiff.
+func verifyNamespaceLabel(d resourceGetter, meta interface{}) error {
+	if namespaceLabelMode(d, meta) == "" {
+		return nil
+	}
+	body, err := prometheusRuleObject(d)
+	if err != nil {
+		return err
+	}
+	obj := &PrometheusRule{}
+	if err := json.Unmarshal(body, obj); err != nil {
+		return err
+	}
+	return verifyNamespacedGroups(obj.Spec.Groups, obj.Namespace, fmt.Sprintf("PrometheusRule %q", obj.Name))
+}
+
+// ruleGroupNamespaceLabelDiff rejects rule expressions of a group appended to
+// a PrometheusRule which read series of other namespaces, if
+// enforce_namespace_label is set in the provider.
+func ruleGroupNamespaceLabelDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if k, ok := unknownValue(d); ok {
+		log.Printf("[DEBUG] Deferring namespace label check of %q to apply, %s is known only after apply", d.Id(), k)
+		return nil
+	}
+	return verifyRuleGroupNamespaceLabel(d, meta)
+}
+
+func verifyRuleGroupNamespaceLabel(d resourceGetter, meta interface{}) error {
+	if clients, ok := meta.(*KubeClientsets); !ok || !clients.EnforceNamespaceLabel {
+		return nil
+	}
+	group, err := expandPrometheusRuleGroup(d)
+	if err != nil {
+		return err
+	}
+	return verifyNamespacedGroups([]RuleGroup{*group}, d.Get("namespace").(string), fmt.Sprintf("Group %q of PrometheusRule %q", group.Name, d.Get("prometheus_rule")))
+}
+
+func verifyNamespacedGroups(groups []RuleGroup, namespace, object string) error {
+	msg := ""
+	for _, g := range groups {
+		for j, r := range g.Rules {
+			if r.Expr.Type != intstr.String {
+				continue
+			}
+			if err := verifyNamespacedExpr(r.Expr.StrVal, namespace); err != nil {
+				msg += fmt.Sprintf("\n  group %q, rule %d: %s", g.Name, j, err)
+			}
+		}
+	}
+	if msg != "" {
+		return fmt.Errorf("Rules of %s must only read series of namespace %q:%s", object, namespace, msg)
+	}
+	return nil
+}
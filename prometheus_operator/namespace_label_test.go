@@ -0,0 +1,159 @@
+package prometheus_operator
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestNamespacedExpr(t *testing.T) {
+	cases := []struct {
+		expr     string
+		expected string
+		err      string
+	}{
+		{`up{namespace="team-a"} == 0`, `up{namespace="team-a"} == 0`, ""},
+		{`up == 0`, `up{namespace="team-a"} == 0`, ""},
+		{
+			`sum by(job) (rate(http_requests_total{code=~"5.."}[5m])) / sum by(job) (rate(http_requests_total[5m]))`,
+			`sum by(job) (rate(http_requests_total{code=~"5..",namespace="team-a"}[5m])) / sum by(job) (rate(http_requests_total{namespace="team-a"}[5m]))`,
+			"",
+		},
+		{`max_over_time(up[1h:5m])`, `max_over_time(up{namespace="team-a"}[1h:5m])`, ""},
+		{`vector(1)`, `vector(1)`, ""},
+		{`up{namespace="team-b"}`, "", `selector matcher namespace="team-b" conflicts with namespace "team-a"`},
+		{`up{namespace=~"team-.*"}`, "", `selector matcher namespace=~"team-.*" conflicts with namespace "team-a"`},
+		{`up{`, "", "parse error"},
+	}
+	for _, c := range cases {
+		got, err := namespacedExpr(c.expr, "team-a")
+		if c.err != "" {
+			if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Errorf("%s: expected error %q, got %v", c.expr, c.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", c.expr, err)
+		} else if got != c.expected {
+			t.Errorf("%s: expected %s, got %s", c.expr, c.expected, got)
+		}
+	}
+
+	if err := verifyNamespacedExpr(`up{namespace="team-a"} / on(instance) node_load1`, "team-a"); err == nil ||
+		!strings.Contains(err.Error(), `expected up{namespace="team-a"} / on(instance) node_load1{namespace="team-a"}`) {
+		t.Errorf("Expected selector without namespace matcher, got %v", err)
+	}
+}
+
+func TestEnforceNamespaceLabel_inject(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourcePOPrometheusRule().Schema, map[string]interface{}{
+		"metadata": []interface{}{map[string]interface{}{
+			"name":      "example",
+			"namespace": "team-a",
+		}},
+		"spec": []interface{}{map[string]interface{}{
+			"groups": []interface{}{map[string]interface{}{
+				"name": "example",
+				"rules": []interface{}{
+					map[string]interface{}{"alert": "Down", "expr": "up == 0"},
+					map[string]interface{}{"record": "job:up:sum", "expr": `sum by (job) (up{namespace="team-a"})`},
+				},
+			}},
+		}},
+		"enforce_namespace_label": "inject",
+	})
+
+	body, err := prometheusRuleObject(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	obj := &PrometheusRule{}
+	if err := json.Unmarshal(body, obj); err != nil {
+		t.Fatal(err)
+	}
+	rules := obj.Spec.Groups[0].Rules
+	if got := rules[0].Expr.String(); got != `up{namespace="team-a"} == 0` {
+		t.Errorf("Expected injected namespace matcher, got %s", got)
+	}
+	if got := rules[1].Expr.String(); got != `sum by (job) (up{namespace="team-a"})` {
+		t.Errorf("Expected unchanged expression, got %s", got)
+	}
+
+	// The expressions read back from the cluster are stored as configured.
+	spec, err := flattenPrometheusRuleSpec(obj.Spec, d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Set("spec", spec); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.Get("spec.0.groups.0.rules.0.expr"); got != "up == 0" {
+		t.Errorf("Expected configured expression in state, got %s", got)
+	}
+
+	// Expressions changed outside of Terraform are read as they are.
+	obj.Spec.Groups[0].Rules[0].Expr.StrVal = `up{namespace="team-a"} == 1`
+	spec, err = flattenPrometheusRuleSpec(obj.Spec, d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.Set("spec", spec)
+	if got := d.Get("spec.0.groups.0.rules.0.expr"); got != `up{namespace="team-a"} == 1` {
+		t.Errorf("Expected expression of the cluster in state, got %s", got)
+	}
+}
+
+func TestEnforceNamespaceLabel_fanOut(t *testing.T) {
+	_, es := resourcePOPrometheusRule().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"metadata": []interface{}{map[string]interface{}{
+			"name":      "example",
+			"namespace": "team-a",
+		}},
+		"spec":                    []interface{}{map[string]interface{}{}},
+		"enforce_namespace_label": "inject",
+		"target_namespaces":       []interface{}{"team-b"},
+	}))
+	if len(es) == 0 {
+		t.Fatal("Expected enforce_namespace_label to conflict with target_namespaces")
+	}
+}
+
+func TestVerifyNamespaceLabel_provider(t *testing.T) {
+	meta := &KubeClientsets{EnforceNamespaceLabel: true}
+	rule := schema.TestResourceDataRaw(t, resourcePOPrometheusRule().Schema, map[string]interface{}{
+		"metadata": []interface{}{map[string]interface{}{
+			"name":      "example",
+			"namespace": "team-a",
+		}},
+		"spec": []interface{}{map[string]interface{}{
+			"groups": []interface{}{map[string]interface{}{
+				"name":  "example",
+				"rules": []interface{}{map[string]interface{}{"alert": "Down", "expr": "up == 0"}},
+			}},
+		}},
+	})
+	if err := verifyNamespaceLabel(rule, meta); err == nil || !strings.Contains(err.Error(), `group "example", rule 0`) {
+		t.Errorf("Expected rule without namespace matcher to be rejected, got %v", err)
+	}
+	if err := verifyNamespaceLabel(rule, &KubeClientsets{}); err != nil {
+		t.Errorf("Expected no check without enforce_namespace_label, got %s", err)
+	}
+
+	group := schema.TestResourceDataRaw(t, resourcePOPrometheusRuleGroup().Schema, map[string]interface{}{
+		"namespace":       "team-a",
+		"prometheus_rule": "shared",
+		"name":            "example",
+		"rules": []interface{}{
+			map[string]interface{}{"alert": "Down", "expr": `up{namespace="team-a"} == 0`},
+			map[string]interface{}{"alert": "Other", "expr": `up{namespace="team-b"} == 0`},
+		},
+	})
+	err := verifyRuleGroupNamespaceLabel(group, meta)
+	if err == nil || !strings.Contains(err.Error(), `Group "example" of PrometheusRule "shared"`) || !strings.Contains(err.Error(), "rule 1") {
+		t.Errorf("Expected second rule of the group to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,158 @@
+package prometheus_operator
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	v1 "k8s.io/api/core/v1"
+)
+
+// podTemplate holds the pod-level fields of specs which the operator deploys
+// as a StatefulSet.
+type podTemplate struct {
+	Containers      []v1.Container
+	InitContainers  []v1.Container
+	NodeSelector    map[string]string
+	Resources       v1.ResourceRequirements
+	SecurityContext *v1.PodSecurityContext
+	Tolerations     []v1.Toleration
+	Volumes         []v1.Volume
+}
+
+// withPodTemplate adds the pod-level attributes to the spec schema s of the
+// given kind.
+func withPodTemplate(kind string, s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["container"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		ForceNew:    true,
+		Description: fmt.Sprintf("Containers allows injecting additional containers into the %s Pods.", kind),
+		Elem: &schema.Resource{
+			Schema: containerFields(true, false),
+		},
+	}
+	s["init_container"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		ForceNew:    true,
+		Description: fmt.Sprintf("InitContainers allows adding initContainers to the %s Pods.", kind),
+		Elem: &schema.Resource{
+			Schema: containerFields(true, true),
+		},
+	}
+	s["node_selector"] = &schema.Schema{
+		Type:        schema.TypeMap,
+		Optional:    true,
+		Description: fmt.Sprintf("Define which Nodes the %s Pods are scheduled on.", kind),
+	}
+	s["security_context"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "SecurityContext holds pod-level security attributes and common container settings. Optional: Defaults to empty. . More info: http://releases.k8s.io/HEAD/docs/design/security_context.md",
+		Elem: &schema.Resource{
+			Schema: SecurityContextSchema(),
+		},
+	}
+	s["resources"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Computed:    true,
+		Description: fmt.Sprintf("Define resources requests and limits for single %s Pods. More info: https://kubernetes.io/docs/reference/generated/kubernetes-api/v1.11/#resourcerequirements-v1-core", kind),
+		Elem: &schema.Resource{
+			Schema: resourcesField(),
+		},
+	}
+	s["volume"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Computed:    true,
+		Description: "List of volumes that can be mounted by containers belonging to the pod. More info: http://kubernetes.io/docs/user-guide/volumes",
+		Elem:        volumeSchema(),
+	}
+	s["toleration"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "If specified, the pod's toleration. Optional: Defaults to empty",
+		Elem: &schema.Resource{
+			Schema: TolerationSchema(),
+		},
+	}
+	return s
+}
+
+func expandPodTemplate(in map[string]interface{}) (*podTemplate, error) {
+	obj := &podTemplate{}
+
+	if v, ok := in["container"].([]interface{}); ok && len(v) > 0 {
+		cs, err := expandContainers(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.Containers = cs
+	}
+	if v, ok := in["init_container"].([]interface{}); ok && len(v) > 0 {
+		cs, err := expandContainers(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.InitContainers = cs
+	}
+	if v, ok := in["node_selector"].(map[string]interface{}); ok {
+		nodeSelectors := make(map[string]string)
+		for k, v := range v {
+			if val, ok := v.(string); ok {
+				nodeSelectors[k] = val
+			}
+		}
+		obj.NodeSelector = nodeSelectors
+	}
+	if v, ok := in["resources"].([]interface{}); ok && len(v) > 0 {
+		crr, err := expandContainerResourceRequirements(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.Resources = *crr
+	}
+	if v, ok := in["security_context"].([]interface{}); ok && len(v) > 0 {
+		obj.SecurityContext = expandPodSecurityContext(v)
+	}
+	if v, ok := in["toleration"].([]interface{}); ok && len(v) > 0 {
+		ts, err := expandTolerations(v)
+		if err != nil {
+			return obj, err
+		}
+		for _, t := range ts {
+			obj.Tolerations = append(obj.Tolerations, *t)
+		}
+	}
+	if v, ok := in["volume"].([]interface{}); ok && len(v) > 0 {
+		cs, err := expandVolumes(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.Volumes = cs
+	}
+	return obj, nil
+}
+
+// flattenPodTemplate sets the pod-level attributes read back from the
+// cluster in att. Resources and volumes are computed, node selectors and
+// tolerations are kept as configured.
+func flattenPodTemplate(in podTemplate, att map[string]interface{}) error {
+	if in.SecurityContext != nil {
+		att["security_context"] = flattenPodSecurityContext(in.SecurityContext)
+	}
+	containers, err := flattenContainers(in.Containers)
+	if err != nil {
+		return err
+	}
+	att["container"] = containers
+	initContainers, err := flattenContainers(in.InitContainers)
+	if err != nil {
+		return err
+	}
+	att["init_container"] = initContainers
+	return nil
+}
@@ -0,0 +1,116 @@
+package prometheus_operator
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+)
+
+// prometheusResponse is the envelope of the responses of the Prometheus
+// HTTP API.
+type prometheusResponse struct {
+	Status    string          `json:"status"`
+	Data      json.RawMessage `json:"data"`
+	ErrorType string          `json:"errorType"`
+	Error     string          `json:"error"`
+	Warnings  []string        `json:"warnings"`
+}
+
+// prometheusQueryResult is the data of query and query_range responses.
+// Result is a list of samples or series, a sample for scalars and strings.
+type prometheusQueryResult struct {
+	ResultType string          `json:"resultType"`
+	Result     json.RawMessage `json:"result"`
+}
+
+// prometheusSample is an instant vector element, or a range vector element
+// when Values is set.
+type prometheusSample struct {
+	Metric map[string]string `json:"metric"`
+	Value  prometheusPoint   `json:"value"`
+	Values []prometheusPoint `json:"values"`
+}
+
+// prometheusPoint is a [timestamp, "value"] pair. Values are strings, as
+// they may be NaN or infinite.
+type prometheusPoint [2]interface{}
+
+func (p prometheusPoint) timestamp() float64 {
+	t, _ := p[0].(float64)
+	return t
+}
+
+func (p prometheusPoint) value() string {
+	v, _ := p[1].(string)
+	return v
+}
+
+// float returns the value of the point, and false if it is not finite.
+func (p prometheusPoint) float() (float64, bool) {
+	f, err := strconv.ParseFloat(p.value(), 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, false
+	}
+	return f, true
+}
+
+// prometheusAPI calls path of the Prometheus HTTP API through the proxy,
+// and returns the data and warnings of the response. Errors reported by
+// Prometheus are returned with their message.
+func prometheusAPI(proxy apiProxy, path string, params map[string]string) (json.RawMessage, []string, error) {
+	req := proxy(http.MethodGet, path)
+	for k, v := range params {
+		if v != "" {
+			req = req.Param(k, v)
+		}
+	}
+	data, err := req.Do().Raw()
+	out := &prometheusResponse{}
+	if decodeErr := json.Unmarshal(data, out); decodeErr != nil || out.Status == "" {
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, fmt.Errorf("Unexpected response of Prometheus: %s", data)
+	}
+	if out.Status != "success" {
+		return nil, out.Warnings, fmt.Errorf("Prometheus returned %s error: %s", out.ErrorType, out.Error)
+	}
+	return out.Data, out.Warnings, nil
+}
+
+// prometheusRuleGroupStatus is a group of the Prometheus rules API.
+// Evaluation times are reported by Prometheus 2.16 and later.
+type prometheusRuleGroupStatus struct {
+	Name           string                 `json:"name"`
+	File           string                 `json:"file"`
+	Interval       float64                `json:"interval"`
+	LastEvaluation string                 `json:"lastEvaluation"`
+	EvaluationTime float64                `json:"evaluationTime"`
+	Rules          []prometheusRuleStatus `json:"rules"`
+}
+
+type prometheusRuleStatus struct {
+	Name           string  `json:"name"`
+	Type           string  `json:"type"`
+	Query          string  `json:"query"`
+	Health         string  `json:"health"`
+	LastError      string  `json:"lastError"`
+	LastEvaluation string  `json:"lastEvaluation"`
+	EvaluationTime float64 `json:"evaluationTime"`
+}
+
+func getRuleGroups(proxy apiProxy) ([]prometheusRuleGroupStatus, error) {
+	data, _, err := prometheusAPI(proxy, "/api/v1/rules", nil)
+	if err != nil {
+		return nil, err
+	}
+	out := struct {
+		Groups []prometheusRuleGroupStatus `json:"groups"`
+	}{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("Failed to decode rules: %s", err)
+	}
+	return out.Groups, nil
+}
@@ -5,13 +5,19 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	monclientv1 "github.com/coreos/prometheus-operator/pkg/client/versioned/typed/monitoring/v1"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/logging"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	"github.com/mitchellh/go-homedir"
+	"k8s.io/client-go/dynamic"
 	kubernetes "k8s.io/client-go/kubernetes"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	restclient "k8s.io/client-go/rest"
@@ -76,6 +82,12 @@ func Provider() terraform.ResourceProvider {
 					"~/.kube/config"),
 				Description: "Path to the kube config file, defaults to ~/.kube/config",
 			},
+			"config_paths": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "A list of paths to kube config files which are merged, as with the KUBECONFIG variable. Can be set with KUBE_CONFIG_PATHS environment variable. Takes precedence over config_path.",
+			},
 			"config_context": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -99,6 +111,63 @@ func Provider() terraform.ResourceProvider {
 				DefaultFunc: schema.EnvDefaultFunc("KUBE_TOKEN", ""),
 				Description: "Token to authenticate an service account",
 			},
+			"token_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_TOKEN_FILE", ""),
+				Description: "Path to a file containing a token to authenticate with. The file is re-read periodically, so projected service account tokens can be used.",
+			},
+			"proxy_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_PROXY_URL", ""),
+				Description: "URL of the HTTP proxy to use for requests to the Kubernetes master.",
+			},
+			"tls_server_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_TLS_SERVER_NAME", ""),
+				Description: "Server name used to verify the certificate of the Kubernetes master, if it differs from the host name.",
+			},
+			"impersonate": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Identity to impersonate when accessing the Kubernetes master.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"user": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Username to impersonate.",
+						},
+						"groups": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Groups to impersonate.",
+						},
+						"extra": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Extra fields of the impersonated user.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"values": {
+										Type:     schema.TypeList,
+										Required: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 			"load_config_file": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -133,16 +202,85 @@ func Provider() terraform.ResourceProvider {
 				},
 				Description: "",
 			},
+			"qps": {
+				Type:        schema.TypeFloat,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_QPS", 0.0),
+				Description: "Maximum queries per second to the Kubernetes master. Defaults to the client-go default of 5.",
+			},
+			"burst": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_BURST", 0),
+				Description: "Maximum burst for throttling requests to the Kubernetes master. Defaults to the client-go default of 10.",
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("PO_MAX_RETRIES", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Number of times monitoring API requests are retried on throttling (429), server errors (5xx) and admission webhook timeouts.",
+			},
+			"retry_backoff": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("PO_RETRY_BACKOFF", "1s"),
+				ValidateFunc: validateDuration,
+				Description:  "Initial delay between retries, doubled on every attempt with random jitter.",
+			},
+			"validate_references": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("PO_VALIDATE_REFERENCES", false),
+				Description: "Check during plan that secrets, config maps, service accounts, priority classes and endpoints referenced by resources exist in the cluster.",
+			},
+			"validate_schema": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("PO_VALIDATE_SCHEMA", true),
+				Description: "Validate resources against the embedded monitoring.coreos.com CRD schemas during plan. Doesn't require access to the Kubernetes master. Defaults to true.",
+			},
+			"dry_run": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("PO_DRY_RUN", false),
+				Description: "Submit every change to the Kubernetes master with server-side dry-run during plan, so schema errors and admission webhook rejections are reported before apply.",
+			},
+			"enforce_namespace_label": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("PO_ENFORCE_NAMESPACE_LABEL", false),
+				Description: "Reject po_prometheus_rule and po_prometheus_rule_group whose expressions have selectors without a matcher of their own namespace, unless enforce_namespace_label is set to inject in po_prometheus_rule. Such po_prometheus_rule can't be copied to other namespaces.",
+			},
+			"standard_rules_version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("PO_STANDARD_RULES_VERSION", ""),
+				ValidateFunc: validation.StringInSlice(standardRuleVersions(), false),
+				Description:  "Version of the rules of po_standard_rules which don't pin one. Defaults to the latest version.",
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
+			"po_alert_catalog": dataSourcePOAlertCatalog(),
+			"po_prometheus_query": dataSourcePOPrometheusQuery(),
+			"po_relabel_preview": dataSourcePORelabelPreview(),
+			"po_rule_backtest": dataSourcePORuleBacktest(),
+			"po_rule_health": dataSourcePORuleHealth(),
+			"po_standard_rules": dataSourcePOStandardRules(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
 			"po_alertmanager": resourcePOAlertmanager(),
+			"po_alertmanager_silence": resourcePOAlertmanagerSilence(),
 			"po_service_monitor": resourcePOServiceMonitor(),
 			"po_prometheus": resourcePOPrometheus(),
 			"po_prometheus_rule": resourcePOPrometheusRule(),
+			"po_prometheus_rule_group": resourcePOPrometheusRuleGroup(),
+			"po_probe": resourcePOProbe(),
+			"po_thanos_ruler": resourcePOThanosRuler(),
+			"po_slo": resourcePOSLO(),
+			"po_manifest": resourcePOManifest(),
 		},
 	}
 
@@ -163,10 +301,66 @@ type KubeClientsets struct {
 	MainClientset       *kubernetes.Clientset
 	AggregatorClientset *aggregator.Clientset
 	MonitoringClient *monclientv1.MonitoringV1Client
+	DynamicClient    dynamic.Interface
+
+	ValidateSchema     bool
+	ValidateReferences bool
+	DryRun             bool
+
+	EnforceNamespaceLabel bool
+	StandardRulesVersion  string
 }
 
 func providerConfigure(d *schema.ResourceData, terraformVersion string) (interface{}, error) {
+	cfg, err := providerRestConfig(d, terraformVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	k, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to configure: %s", err)
+	}
 
+	a, err := aggregator.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to configure: %s", err)
+	}
+
+	mcfg := restclient.CopyConfig(cfg)
+	if retries := d.Get("max_retries").(int); retries > 0 {
+		backoff, err := time.ParseDuration(d.Get("retry_backoff").(string))
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse retry_backoff: %s", err)
+		}
+		mcfg.WrapTransport = chainTransportWrappers(mcfg.WrapTransport, retryTransportWrapper(retries, backoff))
+	}
+
+	m, err := monclientv1.NewForConfig(mcfg)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to configure: %s", err)
+	}
+
+	dc, err := dynamic.NewForConfig(mcfg)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to configure: %s", err)
+	}
+
+	return &KubeClientsets{
+		MainClientset:       k,
+		AggregatorClientset: a,
+		MonitoringClient:    m,
+		DynamicClient:       dc,
+		ValidateSchema:      d.Get("validate_schema").(bool),
+		ValidateReferences:  d.Get("validate_references").(bool),
+		DryRun:              d.Get("dry_run").(bool),
+
+		EnforceNamespaceLabel: d.Get("enforce_namespace_label").(bool),
+		StandardRulesVersion:  d.Get("standard_rules_version").(string),
+	}, nil
+}
+
+func providerRestConfig(d *schema.ResourceData, terraformVersion string) (*restclient.Config, error) {
 	var cfg *restclient.Config
 	var err error
 	if d.Get("load_config_file").(bool) {
@@ -217,6 +411,23 @@ func providerConfigure(d *schema.ResourceData, terraformVersion string) (interfa
 	if v, ok := d.GetOk("token"); ok {
 		cfg.BearerToken = v.(string)
 	}
+	if v, ok := d.GetOk("token_file"); ok {
+		// client-go re-reads the file periodically, which keeps
+		// short-lived projected service account tokens fresh.
+		cfg.BearerTokenFile = v.(string)
+	}
+	if v, ok := d.GetOk("tls_server_name"); ok {
+		cfg.ServerName = v.(string)
+	}
+	if v, ok := d.GetOk("impersonate"); ok {
+		cfg.Impersonate = expandImpersonationConfig(v.([]interface{}))
+	}
+	if v, ok := d.GetOk("qps"); ok {
+		cfg.QPS = float32(v.(float64))
+	}
+	if v, ok := d.GetOk("burst"); ok {
+		cfg.Burst = v.(int)
+	}
 
 	if v, ok := d.GetOk("exec"); ok {
 		exec := &clientcmdapi.ExecConfig{}
@@ -233,39 +444,96 @@ func providerConfigure(d *schema.ResourceData, terraformVersion string) (interfa
 		cfg.ExecProvider = exec
 	}
 
+	if v, ok := d.GetOk("proxy_url"); ok {
+		proxyURL, err := url.Parse(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse proxy_url: %s", err)
+		}
+		cfg.WrapTransport = chainTransportWrappers(cfg.WrapTransport, proxyTransportWrapper(proxyURL))
+	}
+
 	if logging.IsDebugOrHigher() {
 		log.Printf("[DEBUG] Enabling HTTP requests/responses tracing")
-		cfg.WrapTransport = func(rt http.RoundTripper) http.RoundTripper {
+		cfg.WrapTransport = chainTransportWrappers(cfg.WrapTransport, func(rt http.RoundTripper) http.RoundTripper {
 			return logging.NewTransport("Kubernetes", rt)
-		}
+		})
 	}
 
-	k, err := kubernetes.NewForConfig(cfg)
-	if err != nil {
-		return nil, fmt.Errorf("Failed to configure: %s", err)
+	return cfg, nil
+}
+
+func expandImpersonationConfig(l []interface{}) restclient.ImpersonationConfig {
+	obj := restclient.ImpersonationConfig{}
+	if len(l) == 0 || l[0] == nil {
+		return obj
+	}
+	in := l[0].(map[string]interface{})
+	obj.UserName = in["user"].(string)
+	if v, ok := in["groups"].([]interface{}); ok && len(v) > 0 {
+		obj.Groups = expandStringSlice(v)
+	}
+	if v, ok := in["extra"].([]interface{}); ok && len(v) > 0 {
+		obj.Extra = make(map[string][]string, len(v))
+		for _, e := range v {
+			extra := e.(map[string]interface{})
+			name := extra["name"].(string)
+			obj.Extra[name] = append(obj.Extra[name], expandStringSlice(extra["values"].([]interface{}))...)
+		}
 	}
+	return obj
+}
 
-	a, err := aggregator.NewForConfig(cfg)
-	if err != nil {
-		return nil, fmt.Errorf("Failed to configure: %s", err)
+func validateDuration(value interface{}, key string) (ws []string, es []error) {
+	if _, err := time.ParseDuration(value.(string)); err != nil {
+		es = append(es, fmt.Errorf("%s: %s", key, err))
 	}
+	return
+}
 
-	m, err := monclientv1.NewForConfig(cfg)
-	if err != nil {
-		return nil, fmt.Errorf("Failed to configure: %s", err)
+// proxyTransportWrapper routes requests through the given HTTP proxy,
+// keeping TLS settings of the transport built by client-go.
+func proxyTransportWrapper(proxyURL *url.URL) func(http.RoundTripper) http.RoundTripper {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		t, ok := rt.(*http.Transport)
+		if !ok {
+			log.Printf("[WARN] Unable to set proxy on transport of type %T", rt)
+			return rt
+		}
+		// The transport is shared between clients with the same TLS settings.
+		t = t.Clone()
+		t.Proxy = http.ProxyURL(proxyURL)
+		return t
 	}
+}
 
-	return &KubeClientsets{k, a, m}, nil
+func chainTransportWrappers(wrappers ...func(http.RoundTripper) http.RoundTripper) func(http.RoundTripper) http.RoundTripper {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		for _, w := range wrappers {
+			if w != nil {
+				rt = w(rt)
+			}
+		}
+		return rt
+	}
 }
 
 func tryLoadingConfigFile(d *schema.ResourceData) (*restclient.Config, error) {
-	path, err := homedir.Expand(d.Get("config_path").(string))
+	loader := &clientcmd.ClientConfigLoadingRules{}
+
+	paths, err := configPaths(d)
 	if err != nil {
 		return nil, err
 	}
-
-	loader := &clientcmd.ClientConfigLoadingRules{
-		ExplicitPath: path,
+	var path string
+	if len(paths) > 0 {
+		loader.Precedence = paths
+		path = strings.Join(paths, string(filepath.ListSeparator))
+	} else {
+		path, err = homedir.Expand(d.Get("config_path").(string))
+		if err != nil {
+			return nil, err
+		}
+		loader.ExplicitPath = path
 	}
 
 	overrides := &clientcmd.ConfigOverrides{}
@@ -301,6 +569,10 @@ func tryLoadingConfigFile(d *schema.ResourceData) (*restclient.Config, error) {
 			log.Printf("[INFO] Unable to load config file as it doesn't exist at %q", path)
 			return nil, nil
 		}
+		if len(paths) > 0 && clientcmd.IsEmptyConfig(err) {
+			log.Printf("[INFO] Unable to load config as none of the files exist at %q", path)
+			return nil, nil
+		}
 		return nil, fmt.Errorf("Failed to load config (%s%s): %s", path, ctxSuffix, err)
 	}
 
@@ -308,3 +580,19 @@ func tryLoadingConfigFile(d *schema.ResourceData) (*restclient.Config, error) {
 	return cfg, nil
 }
 
+func configPaths(d *schema.ResourceData) ([]string, error) {
+	var paths []string
+	if v, ok := d.GetOk("config_paths"); ok {
+		paths = expandStringSlice(v.([]interface{}))
+	} else if v := os.Getenv("KUBE_CONFIG_PATHS"); v != "" {
+		paths = filepath.SplitList(v)
+	}
+	for i, p := range paths {
+		path, err := homedir.Expand(p)
+		if err != nil {
+			return nil, err
+		}
+		paths[i] = path
+	}
+	return paths, nil
+}
@@ -2,7 +2,9 @@ package prometheus_operator
 
 import (
 	"errors"
+	"net/http"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -13,6 +15,7 @@ import (
 	"github.com/terraform-providers/terraform-provider-google/google"
 	api "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	restclient "k8s.io/client-go/rest"
 )
 
 var testAccProviders map[string]terraform.ResourceProvider
@@ -57,6 +60,120 @@ func TestProvider_configure(t *testing.T) {
 	}
 }
 
+func TestProvider_configureConfigPaths(t *testing.T) {
+	if os.Getenv("TF_ACC") != "" {
+		t.Skip("The environment variable TF_ACC is set, and this test prevents acceptance tests" +
+			" from running as it alters environment variables - skipping")
+	}
+
+	resetEnv := unsetEnv(t)
+	defer resetEnv()
+
+	paths := []interface{}{
+		"test-fixtures/kube-config-staging.yaml",
+		"test-fixtures/kube-config-production.yaml",
+		"test-fixtures/kube-config-missing.yaml",
+	}
+	testCases := []struct {
+		Context      string
+		ExpectedHost string
+	}{
+		{"", "https://staging.example.com:6443"},
+		{"production", "https://production.example.com:6443"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.ExpectedHost, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"config_paths": paths,
+			}
+			if tc.Context != "" {
+				raw["config_context"] = tc.Context
+			}
+			d := schema.TestResourceDataRaw(t, Provider().(*schema.Provider).Schema, raw)
+			cfg, err := providerRestConfig(d, "0.12.0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.Host != tc.ExpectedHost {
+				t.Fatalf("Expected host %q, got %q", tc.ExpectedHost, cfg.Host)
+			}
+		})
+	}
+}
+
+func TestProvider_configureAuthOptions(t *testing.T) {
+	if os.Getenv("TF_ACC") != "" {
+		t.Skip("The environment variable TF_ACC is set, and this test prevents acceptance tests" +
+			" from running as it alters environment variables - skipping")
+	}
+
+	resetEnv := unsetEnv(t)
+	defer resetEnv()
+
+	os.Setenv("KUBECONFIG", "test-fixtures/kube-config.yaml")
+
+	d := schema.TestResourceDataRaw(t, Provider().(*schema.Provider).Schema, map[string]interface{}{
+		"token_file":      "test-fixtures/token",
+		"proxy_url":       "http://proxy.example.com:3128",
+		"tls_server_name": "kubernetes.default.svc",
+		"qps":             50,
+		"burst":           100,
+		"impersonate": []interface{}{
+			map[string]interface{}{
+				"user":   "system:serviceaccount:ci:terraform",
+				"groups": []interface{}{"system:serviceaccounts", "ci"},
+				"extra": []interface{}{
+					map[string]interface{}{
+						"name":   "scopes",
+						"values": []interface{}{"monitoring", "alerting"},
+					},
+				},
+			},
+		},
+	})
+	cfg, err := providerRestConfig(d, "0.12.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Host != "https://35.196.0.10" {
+		t.Errorf("Expected host from kube config, got %q", cfg.Host)
+	}
+	if cfg.BearerTokenFile != "test-fixtures/token" {
+		t.Errorf("Expected token file to be set, got %q", cfg.BearerTokenFile)
+	}
+	if cfg.ServerName != "kubernetes.default.svc" {
+		t.Errorf("Expected TLS server name to be set, got %q", cfg.ServerName)
+	}
+	if cfg.QPS != 50 || cfg.Burst != 100 {
+		t.Errorf("Expected QPS 50 and burst 100, got %v and %d", cfg.QPS, cfg.Burst)
+	}
+	expectedImpersonation := restclient.ImpersonationConfig{
+		UserName: "system:serviceaccount:ci:terraform",
+		Groups:   []string{"system:serviceaccounts", "ci"},
+		Extra:    map[string][]string{"scopes": {"monitoring", "alerting"}},
+	}
+	if !reflect.DeepEqual(cfg.Impersonate, expectedImpersonation) {
+		t.Errorf("Expected impersonation %#v, got %#v", expectedImpersonation, cfg.Impersonate)
+	}
+
+	if cfg.WrapTransport == nil {
+		t.Fatal("Expected transport to be wrapped for proxy")
+	}
+	rt, ok := cfg.WrapTransport(&http.Transport{}).(*http.Transport)
+	if !ok {
+		t.Fatal("Expected wrapped transport to be an *http.Transport")
+	}
+	req, _ := http.NewRequest("GET", cfg.Host, nil)
+	proxy, err := rt.Proxy(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if proxy == nil || proxy.String() != "http://proxy.example.com:3128" {
+		t.Errorf("Expected requests to go through proxy, got %v", proxy)
+	}
+}
+
 func unsetEnv(t *testing.T) func() {
 	e := getEnv()
 
@@ -93,6 +210,18 @@ func unsetEnv(t *testing.T) func() {
 	if err := os.Unsetenv("KUBE_CLUSTER_CA_CERT_DATA"); err != nil {
 		t.Fatalf("Error unsetting env var KUBE_CLUSTER_CA_CERT_DATA: %s", err)
 	}
+	if err := os.Unsetenv("KUBE_CONFIG_PATHS"); err != nil {
+		t.Fatalf("Error unsetting env var KUBE_CONFIG_PATHS: %s", err)
+	}
+	if err := os.Unsetenv("KUBE_TOKEN_FILE"); err != nil {
+		t.Fatalf("Error unsetting env var KUBE_TOKEN_FILE: %s", err)
+	}
+	if err := os.Unsetenv("KUBE_PROXY_URL"); err != nil {
+		t.Fatalf("Error unsetting env var KUBE_PROXY_URL: %s", err)
+	}
+	if err := os.Unsetenv("KUBE_TLS_SERVER_NAME"); err != nil {
+		t.Fatalf("Error unsetting env var KUBE_TLS_SERVER_NAME: %s", err)
+	}
 
 	return func() {
 		if err := os.Setenv("KUBE_CONFIG", e.Config); err != nil {
@@ -128,6 +257,18 @@ func unsetEnv(t *testing.T) func() {
 		if err := os.Setenv("KUBE_CLUSTER_CA_CERT_DATA", e.ClusterCACertData); err != nil {
 			t.Fatalf("Error resetting env var KUBE_CLUSTER_CA_CERT_DATA: %s", err)
 		}
+		if err := os.Setenv("KUBE_CONFIG_PATHS", e.ConfigPaths); err != nil {
+			t.Fatalf("Error resetting env var KUBE_CONFIG_PATHS: %s", err)
+		}
+		if err := os.Setenv("KUBE_TOKEN_FILE", e.TokenFile); err != nil {
+			t.Fatalf("Error resetting env var KUBE_TOKEN_FILE: %s", err)
+		}
+		if err := os.Setenv("KUBE_PROXY_URL", e.ProxyURL); err != nil {
+			t.Fatalf("Error resetting env var KUBE_PROXY_URL: %s", err)
+		}
+		if err := os.Setenv("KUBE_TLS_SERVER_NAME", e.TLSServerName); err != nil {
+			t.Fatalf("Error resetting env var KUBE_TLS_SERVER_NAME: %s", err)
+		}
 	}
 }
 
@@ -142,6 +283,10 @@ func getEnv() *currentEnv {
 		ClientCertData:    os.Getenv("KUBE_CLIENT_CERT_DATA"),
 		ClientKeyData:     os.Getenv("KUBE_CLIENT_KEY_DATA"),
 		ClusterCACertData: os.Getenv("KUBE_CLUSTER_CA_CERT_DATA"),
+		ConfigPaths:       os.Getenv("KUBE_CONFIG_PATHS"),
+		TokenFile:         os.Getenv("KUBE_TOKEN_FILE"),
+		ProxyURL:          os.Getenv("KUBE_PROXY_URL"),
+		TLSServerName:     os.Getenv("KUBE_TLS_SERVER_NAME"),
 	}
 	if cfg := os.Getenv("KUBE_CONFIG"); cfg != "" {
 		e.Config = cfg
@@ -312,5 +457,9 @@ type currentEnv struct {
 	ClientCertData    string
 	ClientKeyData     string
 	ClusterCACertData string
+	ConfigPaths       string
+	TokenFile         string
+	ProxyURL          string
+	TLSServerName     string
 }
 
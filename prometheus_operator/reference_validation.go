@@ -0,0 +1,225 @@
+package prometheus_operator
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	po_types "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetes "k8s.io/client-go/kubernetes"
+)
+
+// objectReference is a by-name reference from a custom resource to another
+// Kubernetes object, along with the attribute path it was configured at.
+type objectReference struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Key       string
+	Path      string
+}
+
+func (r objectReference) String() string {
+	if r.Namespace == "" {
+		return fmt.Sprintf("%s %q", r.Kind, r.Name)
+	}
+	return fmt.Sprintf("%s %q", r.Kind, r.Namespace+"/"+r.Name)
+}
+
+type referenceCollector func(d *schema.ResourceDiff) ([]objectReference, error)
+
+// validateReferencesDiff checks during plan that all objects referenced by
+// the resource exist in the cluster, if enabled in the provider configuration.
+func validateReferencesDiff(collect referenceCollector) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		clients, ok := meta.(*KubeClientsets)
+		if !ok || !clients.ValidateReferences {
+			return nil
+		}
+		refs, err := collect(d)
+		if err != nil {
+			return err
+		}
+		return checkReferences(clients.MainClientset, refs)
+	}
+}
+
+func checkReferences(conn kubernetes.Interface, refs []objectReference) error {
+	objects := make(map[string]map[string]bool)
+	var problems []string
+	for _, ref := range refs {
+		if ref.Name == "" {
+			continue
+		}
+		id := ref.Kind + "/" + ref.Namespace + "/" + ref.Name
+		keys, ok := objects[id]
+		if !ok {
+			log.Printf("[DEBUG] Checking reference to %s", ref)
+			var err error
+			keys, err = lookupReference(conn, ref)
+			if err != nil {
+				return fmt.Errorf("Failed to validate reference to %s: %s", ref, err)
+			}
+			objects[id] = keys
+		}
+		switch {
+		case keys == nil:
+			problems = append(problems, fmt.Sprintf("%s: %s not found", ref.Path, ref))
+		case ref.Key != "" && !keys[ref.Key]:
+			problems = append(problems, fmt.Sprintf("%s: key %q not found in %s", ref.Path, ref.Key, ref))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("Unresolved references:\n\t%s", strings.Join(problems, "\n\t"))
+	}
+	return nil
+}
+
+// lookupReference returns the set of data keys of the referenced object,
+// or nil if the object doesn't exist.
+func lookupReference(conn kubernetes.Interface, ref objectReference) (map[string]bool, error) {
+	keys := make(map[string]bool)
+	var err error
+	switch ref.Kind {
+	case "Secret":
+		var s *api.Secret
+		s, err = conn.CoreV1().Secrets(ref.Namespace).Get(ref.Name, metav1.GetOptions{})
+		if err == nil {
+			for k := range s.Data {
+				keys[k] = true
+			}
+			for k := range s.StringData {
+				keys[k] = true
+			}
+		}
+	case "ConfigMap":
+		var cm *api.ConfigMap
+		cm, err = conn.CoreV1().ConfigMaps(ref.Namespace).Get(ref.Name, metav1.GetOptions{})
+		if err == nil {
+			for k := range cm.Data {
+				keys[k] = true
+			}
+			for k := range cm.BinaryData {
+				keys[k] = true
+			}
+		}
+	case "ServiceAccount":
+		_, err = conn.CoreV1().ServiceAccounts(ref.Namespace).Get(ref.Name, metav1.GetOptions{})
+	case "Endpoints":
+		_, err = conn.CoreV1().Endpoints(ref.Namespace).Get(ref.Name, metav1.GetOptions{})
+	case "PriorityClass":
+		_, err = conn.SchedulingV1().PriorityClasses().Get(ref.Name, metav1.GetOptions{})
+	default:
+		return nil, fmt.Errorf("unsupported kind %q", ref.Kind)
+	}
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return keys, nil
+}
+
+func prometheusReferences(d *schema.ResourceDiff) ([]objectReference, error) {
+	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	spec, err := expandPrometheusSpec(d.Get("spec").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	ns := metadata.Namespace
+	refs := podReferences(ns, spec.Secrets, spec.ConfigMaps, spec.ServiceAccountName, spec.PriorityClassName)
+	if spec.Alerting != nil {
+		for i, am := range spec.Alerting.Alertmanagers {
+			path := fmt.Sprintf("spec.0.alerting.0.alertmanagers.%d", i)
+			refs = append(refs, objectReference{Kind: "Endpoints", Namespace: am.Namespace, Name: am.Name, Path: path + ".name"})
+			refs = append(refs, tlsConfigReferences(ns, path+".tls_config.0", am.TLSConfig)...)
+		}
+	}
+	return refs, nil
+}
+
+func alertmanagerReferences(d *schema.ResourceDiff) ([]objectReference, error) {
+	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	spec, err := expandAlertmanagerSpec(d.Get("spec").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	return podReferences(metadata.Namespace, spec.Secrets, spec.ConfigMaps, spec.ServiceAccountName, spec.PriorityClassName), nil
+}
+
+func serviceMonitorReferences(d *schema.ResourceDiff) ([]objectReference, error) {
+	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	spec, err := expandServiceMonitorSpec(d.Get("spec").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	ns := metadata.Namespace
+	var refs []objectReference
+	for i, e := range spec.Endpoints {
+		path := fmt.Sprintf("spec.0.endpoints.%d", i)
+		refs = append(refs, secretKeyReference(ns, path+".bearer_token_secret.0", &e.BearerTokenSecret)...)
+		refs = append(refs, tlsConfigReferences(ns, path+".tls_config.0", e.TLSConfig)...)
+		if e.BasicAuth != nil {
+			refs = append(refs, secretKeyReference(ns, path+".basic_auth.0.username.0", &e.BasicAuth.Username)...)
+			refs = append(refs, secretKeyReference(ns, path+".basic_auth.0.password.0", &e.BasicAuth.Password)...)
+		}
+	}
+	return refs, nil
+}
+
+func thanosRulerReferences(d *schema.ResourceDiff) ([]objectReference, error) {
+	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	spec, err := expandThanosRulerSpec(d.Get("spec").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	ns := metadata.Namespace
+	refs := podReferences(ns, nil, nil, spec.ServiceAccountName, spec.PriorityClassName)
+	refs = append(refs, secretKeyReference(ns, "spec.0.object_storage_config.0", spec.ObjectStorageConfig)...)
+	return refs, nil
+}
+
+func podReferences(ns string, secrets, configMaps []string, serviceAccount, priorityClass string) []objectReference {
+	var refs []objectReference
+	for i, s := range secrets {
+		refs = append(refs, objectReference{Kind: "Secret", Namespace: ns, Name: s, Path: fmt.Sprintf("spec.0.secrets.%d", i)})
+	}
+	for i, cm := range configMaps {
+		refs = append(refs, objectReference{Kind: "ConfigMap", Namespace: ns, Name: cm, Path: fmt.Sprintf("spec.0.config_maps.%d", i)})
+	}
+	refs = append(refs, objectReference{Kind: "ServiceAccount", Namespace: ns, Name: serviceAccount, Path: "spec.0.service_account_name"})
+	refs = append(refs, objectReference{Kind: "PriorityClass", Name: priorityClass, Path: "spec.0.priority_class_name"})
+	return refs
+}
+
+func tlsConfigReferences(ns, path string, in *po_types.TLSConfig) []objectReference {
+	if in == nil {
+		return nil
+	}
+	var refs []objectReference
+	refs = append(refs, secretOrConfigMapReference(ns, path+".ca.0", &in.CA)...)
+	refs = append(refs, secretOrConfigMapReference(ns, path+".cert.0", &in.Cert)...)
+	refs = append(refs, secretKeyReference(ns, path+".key_secret.0", in.KeySecret)...)
+	return refs
+}
+
+func secretOrConfigMapReference(ns, path string, in *po_types.SecretOrConfigMap) []objectReference {
+	var refs []objectReference
+	refs = append(refs, secretKeyReference(ns, path+".secret.0", in.Secret)...)
+	if in.ConfigMap != nil && in.ConfigMap.Name != "" {
+		refs = append(refs, objectReference{Kind: "ConfigMap", Namespace: ns, Name: in.ConfigMap.Name, Key: in.ConfigMap.Key, Path: path + ".config_map.0.name"})
+	}
+	return refs
+}
+
+func secretKeyReference(ns, path string, in *api.SecretKeySelector) []objectReference {
+	if in == nil || in.Name == "" {
+		return nil
+	}
+	return []objectReference{{Kind: "Secret", Namespace: ns, Name: in.Name, Key: in.Key, Path: path + ".name"}}
+}
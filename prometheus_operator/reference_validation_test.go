@@ -0,0 +1,53 @@
+package prometheus_operator
+
+import (
+	"strings"
+	"testing"
+
+	po_types "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCheckReferences(t *testing.T) {
+	conn := fake.NewSimpleClientset(
+		&api.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "etcd-certs", Namespace: "monitoring"},
+			Data:       map[string][]byte{"ca.crt": []byte("ca")},
+		},
+		&api.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "extra-rules", Namespace: "monitoring"},
+		},
+		&api.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{Name: "prometheus-k8s", Namespace: "monitoring"},
+		},
+	)
+
+	refs := podReferences("monitoring", []string{"etcd-certs", "etcd-cert"}, []string{"extra-rules"}, "prometheus-k8s", "")
+	refs = append(refs, tlsConfigReferences("monitoring", "spec.0.endpoints.0.tls_config.0", &po_types.TLSConfig{
+		CA: po_types.SecretOrConfigMap{
+			Secret: &api.SecretKeySelector{
+				LocalObjectReference: api.LocalObjectReference{Name: "etcd-certs"},
+				Key:                  "ca.pem",
+			},
+		},
+	})...)
+
+	err := checkReferences(conn, refs)
+	if err == nil {
+		t.Fatal("Expected unresolved references, got none")
+	}
+	expected := []string{
+		`spec.0.secrets.1: Secret "monitoring/etcd-cert" not found`,
+		`spec.0.endpoints.0.tls_config.0.ca.0.secret.0.name: key "ca.pem" not found in Secret "monitoring/etcd-certs"`,
+	}
+	for _, e := range expected {
+		if !strings.Contains(err.Error(), e) {
+			t.Errorf("Expected error to contain %q, got %q", e, err)
+		}
+	}
+	if strings.Contains(err.Error(), "spec.0.secrets.0") || strings.Contains(err.Error(), "service_account_name") {
+		t.Errorf("Expected existing objects to resolve, got %q", err)
+	}
+}
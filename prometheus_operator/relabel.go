@@ -0,0 +1,126 @@
+package prometheus_operator
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+
+	po_types "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/prometheus/prometheus/pkg/relabel"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// relabelConfigKeys are the JSON keys of lists of relabel configs in the
+// operator objects.
+var relabelConfigKeys = map[string]bool{
+	"relabelings":         true,
+	"metricRelabelings":   true,
+	"writeRelabelConfigs": true,
+	"relabelingConfigs":   true,
+}
+
+// prometheusRelabelConfig returns the relabel config Prometheus generates
+// from the operator one, validated the way Prometheus loads its
+// configuration. Unset fields take the defaults of Prometheus.
+func prometheusRelabelConfig(in po_types.RelabelConfig) (*relabel.Config, error) {
+	fields := yaml.MapSlice{}
+	if len(in.SourceLabels) > 0 {
+		fields = append(fields, yaml.MapItem{Key: "source_labels", Value: in.SourceLabels})
+	}
+	for _, f := range []struct {
+		key, value string
+	}{
+		{"separator", in.Separator},
+		{"target_label", in.TargetLabel},
+		{"regex", in.Regex},
+		{"replacement", in.Replacement},
+		{"action", in.Action},
+	} {
+		if f.value != "" {
+			fields = append(fields, yaml.MapItem{Key: f.key, Value: f.value})
+		}
+	}
+	if in.Modulus != 0 {
+		fields = append(fields, yaml.MapItem{Key: "modulus", Value: in.Modulus})
+	}
+	data, err := yaml.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	out := &relabel.Config{}
+	if err := yaml.UnmarshalStrict(data, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// validateRelabelConfigs validates every list of relabel configs in the
+// JSON object, and returns the errors with the JSON path of the config.
+func validateRelabelConfigs(path string, obj interface{}) []error {
+	errs := []error{}
+	switch v := obj.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			l, ok := v[k].([]interface{})
+			if !relabelConfigKeys[k] || !ok {
+				errs = append(errs, validateRelabelConfigs(path+"."+k, v[k])...)
+				continue
+			}
+			for i, c := range l {
+				data, err := json.Marshal(c)
+				if err != nil {
+					return append(errs, err)
+				}
+				config := po_types.RelabelConfig{}
+				if err := json.Unmarshal(data, &config); err != nil {
+					errs = append(errs, fmt.Errorf("%s.%s[%d]: %s", path, k, i, err))
+					continue
+				}
+				if _, err := prometheusRelabelConfig(config); err != nil {
+					errs = append(errs, fmt.Errorf("%s.%s[%d]: %s", path, k, i, err))
+				}
+			}
+		}
+	case []interface{}:
+		for i, e := range v {
+			errs = append(errs, validateRelabelConfigs(fmt.Sprintf("%s[%d]", path, i), e)...)
+		}
+	}
+	return errs
+}
+
+// validateRelabelConfigsDiff fails the plan if a relabel config of the
+// object would be rejected by the operator or by Prometheus, e.g. because of
+// an invalid regex, an unknown action or a missing target_label.
+func validateRelabelConfigsDiff(build objectBuilder) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		if k, ok := unknownValue(d); ok {
+			log.Printf("[DEBUG] Skipping validation of relabel configs of %q, %s is known only after apply", d.Id(), k)
+			return nil
+		}
+		body, err := build(d)
+		if err != nil {
+			return err
+		}
+		obj := map[string]interface{}{}
+		if err := json.Unmarshal(body, &obj); err != nil {
+			return err
+		}
+		errs := validateRelabelConfigs("", obj["spec"])
+		if len(errs) == 0 {
+			return nil
+		}
+		msg := "Invalid relabel configs:"
+		for _, e := range errs {
+			msg += "\n  spec" + e.Error()
+		}
+		return fmt.Errorf("%s", msg)
+	}
+}
@@ -0,0 +1,119 @@
+package prometheus_operator
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	po_types "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestValidateRelabelConfigs(t *testing.T) {
+	spec := po_types.PrometheusSpec{
+		RemoteWrite: []po_types.RemoteWriteSpec{{
+			URL: "http://remote/write",
+			WriteRelabelConfigs: []po_types.RelabelConfig{
+				{SourceLabels: []string{"__name__"}, Regex: "go_.*", Action: "drop"},
+				{SourceLabels: []string{"instance"}, Action: "hashmod", TargetLabel: "shard"},
+			},
+		}},
+	}
+	monitor := po_types.ServiceMonitorSpec{
+		Endpoints: []po_types.Endpoint{{
+			RelabelConfigs: []*po_types.RelabelConfig{
+				{Action: "labeldrop", Regex: "pod_template_hash"},
+				{SourceLabels: []string{"__meta_kubernetes_pod_name"}, Regex: "(.*"},
+				{Action: "label_drop", Regex: "pod_template_hash"},
+			},
+			MetricRelabelConfigs: []*po_types.RelabelConfig{
+				{SourceLabels: []string{"pod"}, Action: "replace"},
+			},
+		}},
+	}
+
+	cases := []struct {
+		spec     interface{}
+		expected []string
+	}{
+		{spec, []string{
+			".remoteWrite[0].writeRelabelConfigs[1]: relabel configuration for hashmod requires non-zero modulus",
+		}},
+		{monitor, []string{
+			".endpoints[0].metricRelabelings[0]: relabel configuration for replace action requires 'target_label' value",
+			".endpoints[0].relabelings[1]: error parsing regexp: missing closing ): `^(?:(.*)$`",
+			`.endpoints[0].relabelings[2]: unknown relabel action "label_drop"`,
+		}},
+	}
+	for i, c := range cases {
+		data, err := json.Marshal(c.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var obj interface{}
+		if err := json.Unmarshal(data, &obj); err != nil {
+			t.Fatal(err)
+		}
+		errs := []string{}
+		for _, e := range validateRelabelConfigs("", obj) {
+			errs = append(errs, e.Error())
+		}
+		if got, expected := strings.Join(errs, "\n"), strings.Join(c.expected, "\n"); got != expected {
+			t.Errorf("Case %d: expected errors\n%s\ngot\n%s", i, expected, got)
+		}
+	}
+}
+
+func TestDataSourcePORelabelPreview(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dataSourcePORelabelPreview().Schema, map[string]interface{}{
+		"relabel_configs": []interface{}{
+			map[string]interface{}{
+				"source_labels": []interface{}{"__meta_kubernetes_namespace"},
+				"regex":         "kube-.*",
+				"action":        "drop",
+			},
+			map[string]interface{}{
+				"source_labels": []interface{}{"__meta_kubernetes_namespace", "__meta_kubernetes_pod_name"},
+				"separator":     "/",
+				"target_label":  "instance",
+			},
+			map[string]interface{}{
+				"regex":  "__meta_kubernetes_.*",
+				"action": "labeldrop",
+			},
+		},
+		"samples": []interface{}{
+			map[string]interface{}{"labels": map[string]interface{}{
+				"__address__":                 "10.0.0.1:8080",
+				"__meta_kubernetes_namespace": "default",
+				"__meta_kubernetes_pod_name":  "api-0",
+			}},
+			map[string]interface{}{"labels": map[string]interface{}{
+				"__address__":                 "10.0.0.2:8080",
+				"__meta_kubernetes_namespace": "kube-system",
+			}},
+		},
+	})
+	if err := dataSourcePORelabelPreviewRead(d, nil); err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range map[string]string{
+		"results.#":                    "2",
+		"results.0.dropped":            "false",
+		"results.0.labels.%":           "2",
+		"results.0.labels.__address__": "10.0.0.1:8080",
+		"results.0.labels.instance":    "default/api-0",
+		"results.1.dropped":            "true",
+		"results.1.labels.%":           "0",
+	} {
+		if got := fmt.Sprint(d.Get(k)); got != v {
+			t.Errorf("Expected %s to be %q, got %q", k, v, got)
+		}
+	}
+
+	d.Set("relabel_configs", []interface{}{map[string]interface{}{"action": "hashmod", "target_label": "shard"}})
+	if err := dataSourcePORelabelPreviewRead(d, nil); err == nil || !strings.Contains(err.Error(), "requires non-zero modulus") {
+		t.Errorf("Expected invalid hashmod, got %v", err)
+	}
+}
@@ -0,0 +1,354 @@
+package prometheus_operator
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+func resourcePOAlertmanagerSilence() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePOAlertmanagerSilenceCreate,
+		Read:   resourcePOAlertmanagerSilenceRead,
+		Update: resourcePOAlertmanagerSilenceUpdate,
+		Delete: resourcePOAlertmanagerSilenceDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: silenceEndDiff,
+
+		// There is no spec_override_json or spec_override_yaml, silences are
+		// objects of the Alertmanager API, not custom resources with a spec.
+		Schema: map[string]*schema.Schema{
+			"alertmanager": {
+				Type:        schema.TypeString,
+				Description: "ID of the po_alertmanager, as namespace/name. Its API is reached through the proxy of its first pod, which shares silences with the other replicas.",
+				Required:    true,
+				ForceNew:    true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, es []error) {
+					if _, _, err := idParts(v.(string)); err != nil {
+						es = append(es, fmt.Errorf("%s: %s", k, err))
+					}
+					return
+				},
+			},
+			"matchers": {
+				Type:        schema.TypeList,
+				Description: "Matchers of the labels of silenced alerts.",
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "Name of the label.",
+							Required:    true,
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Description: "Value of the label, or regular expression matching it.",
+							Required:    true,
+						},
+						"is_regex": {
+							Type:        schema.TypeBool,
+							Description: "Whether value is a regular expression.",
+							Optional:    true,
+						},
+						"is_equal": {
+							Type:        schema.TypeBool,
+							Description: "Whether the label has to match, or to not match value.",
+							Optional:    true,
+							Default:     true,
+						},
+					},
+				},
+			},
+			"starts_at": {
+				Type:             schema.TypeString,
+				Description:      "Start of the silence in RFC 3339 format. Defaults to the time of creation.",
+				Optional:         true,
+				Computed:         true,
+				ValidateFunc:     validation.ValidateRFC3339TimeString,
+				DiffSuppressFunc: suppressEquivalentTime,
+			},
+			"ends_at": {
+				Type:             schema.TypeString,
+				Description:      "End of the silence in RFC 3339 format.",
+				Optional:         true,
+				Computed:         true,
+				ExactlyOneOf:     []string{"ends_at", "duration"},
+				ValidateFunc:     validation.ValidateRFC3339TimeString,
+				DiffSuppressFunc: suppressEquivalentTime,
+			},
+			"duration": {
+				Type:        schema.TypeString,
+				Description: "Duration of the silence from starts_at, e.g. 2h30m.",
+				Optional:    true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, es []error) {
+					if d, err := time.ParseDuration(v.(string)); err != nil {
+						es = append(es, fmt.Errorf("%s: %s", k, err))
+					} else if d <= 0 {
+						es = append(es, fmt.Errorf("%s: duration must be positive, got %s", k, d))
+					}
+					return
+				},
+			},
+			"created_by": {
+				Type:        schema.TypeString,
+				Description: "Author of the silence.",
+				Required:    true,
+			},
+			"comment": {
+				Type:        schema.TypeString,
+				Description: "Reason of the silence.",
+				Required:    true,
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Description: "State of the silence, pending, active or expired. Expired silences are kept, a later ends_at or a longer duration creates them again. Silences expired outside of Terraform before their end are created again.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func buildSilenceId(alertmanager, silence string) string {
+	return alertmanager + "/" + silence
+}
+
+func silenceIdParts(id string) (string, string, string, error) {
+	parts := strings.SplitN(id, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf("Unexpected ID format (%q), expected %q.", id, "namespace/alertmanager/silence")
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+func resourcePOAlertmanagerSilenceCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*KubeClientsets).MainClientset.CoreV1().RESTClient()
+	namespace, name, err := idParts(d.Get("alertmanager").(string))
+	if err != nil {
+		return err
+	}
+
+	silence, err := expandSilence(d, time.Now())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Creating silence: %#v", silence)
+	id, err := postSilence(alertmanagerPodProxy(conn, namespace, name), silence)
+	if err != nil {
+		return fmt.Errorf("Failed to create silence: %s", err)
+	}
+	log.Printf("[INFO] Submitted new silence: %s", id)
+
+	d.SetId(buildSilenceId(d.Get("alertmanager").(string), id))
+
+	return resourcePOAlertmanagerSilenceRead(d, meta)
+}
+
+func resourcePOAlertmanagerSilenceRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*KubeClientsets).MainClientset.CoreV1().RESTClient()
+	namespace, name, id, err := silenceIdParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading silence %s", id)
+	silence, err := getSilence(alertmanagerPodProxy(conn, namespace, name), id)
+	if err != nil {
+		switch {
+		case errors.IsNotFound(err):
+			log.Printf("[DEBUG] Silence %q was not found in Alertmanager %s/%s - removing from state!", id, namespace, name)
+			d.SetId("")
+			return nil
+		default:
+			log.Printf("[DEBUG] Error reading silence: %#v", err)
+			return err
+		}
+	}
+	log.Printf("[INFO] Received silence: %#v", silence)
+
+	d.Set("alertmanager", namespace+"/"+name)
+	if err := d.Set("matchers", flattenSilenceMatchers(silence.Matchers)); err != nil {
+		return fmt.Errorf("Error setting `matchers`: %+v", err)
+	}
+	d.Set("starts_at", silence.StartsAt.UTC().Format(time.RFC3339))
+	d.Set("ends_at", silence.EndsAt.UTC().Format(time.RFC3339))
+	d.Set("created_by", silence.CreatedBy)
+	d.Set("comment", silence.Comment)
+	d.Set("state", silence.Status.State)
+	return nil
+}
+
+func resourcePOAlertmanagerSilenceUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*KubeClientsets).MainClientset.CoreV1().RESTClient()
+	namespace, name, id, err := silenceIdParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	silence, err := expandSilence(d, time.Now())
+	if err != nil {
+		return err
+	}
+	silence.ID = id
+
+	log.Printf("[INFO] Updating silence %q: %#v", id, silence)
+	newId, err := postSilence(alertmanagerPodProxy(conn, namespace, name), silence)
+	if err != nil {
+		return fmt.Errorf("Failed to update silence: %s", err)
+	}
+	if newId != id {
+		log.Printf("[INFO] Silence %q was replaced by %q", id, newId)
+	}
+	d.SetId(buildSilenceId(namespace+"/"+name, newId))
+
+	return resourcePOAlertmanagerSilenceRead(d, meta)
+}
+
+func resourcePOAlertmanagerSilenceDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*KubeClientsets).MainClientset.CoreV1().RESTClient()
+	namespace, name, id, err := silenceIdParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Expiring silence: %q", id)
+	err = expireSilence(alertmanagerPodProxy(conn, namespace, name), id)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("Failed to expire silence: %s", err)
+	}
+
+	log.Printf("[INFO] Silence %s expired", id)
+
+	d.SetId("")
+
+	return nil
+}
+
+// silenceEndDiff rejects silences ending in the past, which Alertmanager
+// refuses to create. Silences which expired since they were created don't
+// show changes, while silences expired outside of Terraform before their
+// configured end are planned to be created again.
+func silenceEndDiff(d *schema.ResourceDiff, meta interface{}) error {
+	changed := d.Id() == ""
+	for _, k := range []string{"matchers", "starts_at", "ends_at", "duration", "created_by", "comment"} {
+		changed = changed || d.HasChange(k)
+	}
+	if !d.NewValueKnown("duration") || d.Get("duration").(string) == "" && !d.NewValueKnown("ends_at") {
+		return nil
+	}
+	if !changed && d.Get("state").(string) != "expired" {
+		return nil
+	}
+	now := time.Now()
+	silence, err := expandSilence(d, now)
+	if err != nil {
+		return err
+	}
+	if changed {
+		return validateSilenceEnd(silence, now)
+	}
+	if !silence.EndsAt.After(now) {
+		return nil
+	}
+	log.Printf("[DEBUG] Silence %q expired before %s, creating it again", d.Id(), silence.EndsAt.UTC().Format(time.RFC3339))
+	if d.Get("duration").(string) != "" {
+		if err := d.SetNewComputed("ends_at"); err != nil {
+			return err
+		}
+	}
+	return d.SetNewComputed("state")
+}
+
+func validateSilenceEnd(s *postableSilence, now time.Time) error {
+	if !s.EndsAt.After(now) {
+		return fmt.Errorf("Silence ends at %s, which is in the past. Set ends_at or duration to a later time.", s.EndsAt.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+// expandSilence returns the silence, starting at now unless starts_at is
+// set.
+func expandSilence(d resourceGetter, now time.Time) (*postableSilence, error) {
+	obj := &postableSilence{
+		Matchers:  expandSilenceMatchers(d.Get("matchers").([]interface{})),
+		StartsAt:  now.UTC(),
+		CreatedBy: d.Get("created_by").(string),
+		Comment:   d.Get("comment").(string),
+	}
+	if v, ok := d.Get("starts_at").(string); ok && v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, err
+		}
+		obj.StartsAt = t
+	}
+	if v, ok := d.Get("duration").(string); ok && v != "" {
+		duration, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, err
+		}
+		obj.EndsAt = obj.StartsAt.Add(duration)
+		return obj, nil
+	}
+	t, err := time.Parse(time.RFC3339, d.Get("ends_at").(string))
+	if err != nil {
+		return nil, err
+	}
+	obj.EndsAt = t
+	return obj, nil
+}
+
+func expandSilenceMatchers(l []interface{}) []silenceMatcher {
+	obj := make([]silenceMatcher, len(l))
+	for i, v := range l {
+		in := v.(map[string]interface{})
+		isEqual := in["is_equal"].(bool)
+		obj[i] = silenceMatcher{
+			Name:    in["name"].(string),
+			Value:   in["value"].(string),
+			IsRegex: in["is_regex"].(bool),
+			IsEqual: &isEqual,
+		}
+	}
+	return obj
+}
+
+func flattenSilenceMatchers(in []silenceMatcher) []interface{} {
+	att := make([]interface{}, len(in))
+	for i, m := range in {
+		isEqual := true
+		if m.IsEqual != nil {
+			isEqual = *m.IsEqual
+		}
+		att[i] = map[string]interface{}{
+			"name":     m.Name,
+			"value":    m.Value,
+			"is_regex": m.IsRegex,
+			"is_equal": isEqual,
+		}
+	}
+	return att
+}
+
+// suppressEquivalentTime suppresses differences between the formats of the
+// same instant, e.g. time zones.
+func suppressEquivalentTime(k, old, new string, d *schema.ResourceData) bool {
+	o, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+	n, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+	return o.Equal(n)
+}
@@ -0,0 +1,239 @@
+package prometheus_operator
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+)
+
+const testAlertmanagerProxy = "/api/v1/namespaces/monitoring/pods/alertmanager-main-0:9093/proxy/api/v2/"
+
+// testAlertmanager is an Alertmanager stand-in keeping silences in memory,
+// served behind the service proxy path of the API server.
+type testAlertmanager struct {
+	sync.Mutex
+	silences map[string]*gettableSilence
+	next     int
+}
+
+func (a *testAlertmanager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.Lock()
+	defer a.Unlock()
+	path := strings.TrimPrefix(r.URL.Path, testAlertmanagerProxy)
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case path == "silences" && r.Method == http.MethodPost:
+		in := &postableSilence{}
+		if err := json.NewDecoder(r.Body).Decode(in); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if in.ID != "" {
+			if _, ok := a.silences[in.ID]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+		} else {
+			a.next++
+			in.ID = fmt.Sprintf("silence-%d", a.next)
+		}
+		out := &gettableSilence{postableSilence: *in}
+		out.Status.State = "active"
+		a.silences[in.ID] = out
+		json.NewEncoder(w).Encode(map[string]string{"silenceID": in.ID})
+	case strings.HasPrefix(path, "silence/"):
+		s, ok := a.silences[strings.TrimPrefix(path, "silence/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(s)
+		case http.MethodDelete:
+			s.Status.State = "expired"
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// testProxyClientsets returns clientsets talking to the stand-in API server.
+func testProxyClientsets(t *testing.T, server *httptest.Server) *KubeClientsets {
+	k, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &KubeClientsets{MainClientset: k}
+}
+
+func TestResourcePOAlertmanagerSilence(t *testing.T) {
+	am := &testAlertmanager{silences: map[string]*gettableSilence{}}
+	server := httptest.NewServer(am)
+	defer server.Close()
+	meta := testProxyClientsets(t, server)
+
+	d := schema.TestResourceDataRaw(t, resourcePOAlertmanagerSilence().Schema, map[string]interface{}{
+		"alertmanager": "monitoring/main",
+		"matchers": []interface{}{
+			map[string]interface{}{"name": "alertname", "value": "Watchdog"},
+			map[string]interface{}{"name": "severity", "value": "info|none", "is_regex": true, "is_equal": false},
+		},
+		"starts_at":  "2020-01-02T03:04:05+01:00",
+		"duration":   "2h",
+		"created_by": "terraform",
+		"comment":    "maintenance",
+	})
+	if err := resourcePOAlertmanagerSilenceCreate(d, meta); err != nil {
+		t.Fatal(err)
+	}
+	if d.Id() != "monitoring/main/silence-1" {
+		t.Errorf("Expected ID monitoring/main/silence-1, got %q", d.Id())
+	}
+	for k, v := range map[string]string{
+		"starts_at":           "2020-01-02T02:04:05Z",
+		"ends_at":             "2020-01-02T04:04:05Z",
+		"state":               "active",
+		"matchers.0.is_equal": "true",
+		"matchers.1.is_regex": "true",
+		"matchers.1.is_equal": "false",
+	} {
+		if got := fmt.Sprint(d.Get(k)); got != v {
+			t.Errorf("Expected %s to be %q, got %q", k, v, got)
+		}
+	}
+
+	am.silences["silence-1"].Status.State = "expired"
+	if err := resourcePOAlertmanagerSilenceRead(d, meta); err != nil {
+		t.Fatal(err)
+	}
+	if d.Id() != "monitoring/main/silence-1" || d.Get("state") != "expired" {
+		t.Errorf("Expected expired silence to be kept in state, got ID %q, state %q", d.Id(), d.Get("state"))
+	}
+
+	am.silences["silence-1"].Status.State = "active"
+	if err := resourcePOAlertmanagerSilenceDelete(d, meta); err != nil {
+		t.Fatal(err)
+	}
+	if s := am.silences["silence-1"].Status.State; s != "expired" {
+		t.Errorf("Expected destroy to expire the silence, got state %q", s)
+	}
+
+	d.SetId("monitoring/main/silence-2")
+	if err := resourcePOAlertmanagerSilenceRead(d, meta); err != nil {
+		t.Fatal(err)
+	}
+	if d.Id() != "" {
+		t.Errorf("Expected missing silence to be removed from state, got ID %q", d.Id())
+	}
+}
+
+func TestExpandSilence_defaultsToNow(t *testing.T) {
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	d := schema.TestResourceDataRaw(t, resourcePOAlertmanagerSilence().Schema, map[string]interface{}{
+		"alertmanager": "monitoring/main",
+		"matchers":     []interface{}{map[string]interface{}{"name": "alertname", "value": "Watchdog"}},
+		"ends_at":      "2020-01-03T00:00:00Z",
+		"created_by":   "terraform",
+		"comment":      "maintenance",
+	})
+	s, err := expandSilence(d, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.StartsAt.Equal(now) || !s.EndsAt.Equal(time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected time range %s - %s", s.StartsAt, s.EndsAt)
+	}
+}
+
+func TestValidateSilenceEnd(t *testing.T) {
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	d := schema.TestResourceDataRaw(t, resourcePOAlertmanagerSilence().Schema, map[string]interface{}{
+		"alertmanager": "monitoring/main",
+		"matchers":     []interface{}{map[string]interface{}{"name": "alertname", "value": "Watchdog"}},
+		"starts_at":    "2020-01-01T00:00:00Z",
+		"duration":     "2h",
+		"created_by":   "terraform",
+		"comment":      "maintenance",
+	})
+	s, err := expandSilence(d, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := validateSilenceEnd(s, now); err == nil || !strings.Contains(err.Error(), "2020-01-01T02:00:00Z, which is in the past") {
+		t.Errorf("Expected error about the end in the past, got %v", err)
+	}
+
+	d.Set("duration", "48h")
+	if s, err = expandSilence(d, now); err != nil {
+		t.Fatal(err)
+	}
+	if err := validateSilenceEnd(s, now); err != nil {
+		t.Errorf("Expected silence ending in the future to be valid, got %s", err)
+	}
+}
+
+func TestSilenceEndDiff_expiredEarly(t *testing.T) {
+	start := time.Now().Add(-time.Hour).UTC()
+	state := &terraform.InstanceState{
+		ID: "monitoring/main/silence-1",
+		Attributes: map[string]string{
+			"id":                  "monitoring/main/silence-1",
+			"alertmanager":        "monitoring/main",
+			"matchers.#":          "1",
+			"matchers.0.name":     "alertname",
+			"matchers.0.value":    "Watchdog",
+			"matchers.0.is_regex": "false",
+			"matchers.0.is_equal": "true",
+			"starts_at":           start.Format(time.RFC3339),
+			"ends_at":             start.Add(time.Minute).Format(time.RFC3339),
+			"duration":            "2h",
+			"created_by":          "terraform",
+			"comment":             "maintenance",
+			"state":               "expired",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"alertmanager": "monitoring/main",
+		"matchers":     []interface{}{map[string]interface{}{"name": "alertname", "value": "Watchdog"}},
+		"duration":     "2h",
+		"created_by":   "terraform",
+		"comment":      "maintenance",
+	})
+
+	diff, err := resourcePOAlertmanagerSilence().Diff(state, config, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == nil || diff.Attributes["state"] == nil || !diff.Attributes["state"].NewComputed {
+		t.Errorf("Expected silence expired before its end to be created again, got %v", diff)
+	}
+
+	// Silences which expired at their configured end don't show changes.
+	state.Attributes["duration"] = "30m"
+	config = terraform.NewResourceConfigRaw(map[string]interface{}{
+		"alertmanager": "monitoring/main",
+		"matchers":     []interface{}{map[string]interface{}{"name": "alertname", "value": "Watchdog"}},
+		"duration":     "30m",
+		"created_by":   "terraform",
+		"comment":      "maintenance",
+	})
+	if diff, err = resourcePOAlertmanagerSilence().Diff(state, config, nil); err != nil {
+		t.Fatal(err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("Expected no changes, got %v", diff)
+	}
+}
@@ -0,0 +1,279 @@
+package prometheus_operator
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+)
+
+func resourcePOManifest() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePOManifestCreate,
+		Read:   resourcePOManifestRead,
+		Exists: resourcePOManifestExists,
+		Update: resourcePOManifestUpdate,
+		Delete: resourcePOManifestDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: manifestDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		// There is no spec_override_json or spec_override_yaml, the manifest
+		// already holds the whole object.
+		Schema: map[string]*schema.Schema{
+			"manifest_json": manifestSchema("json"),
+			"manifest_yaml": manifestSchema("yaml"),
+			"wait_for": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Wait for a status condition of the object after it's created or updated.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"condition": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Type of the condition in status.conditions, e.g. Available or Reconciled.",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "True",
+							Description: "Status the condition must have.",
+						},
+					},
+				},
+			},
+			"api_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "APIVersion of the object.",
+			},
+			"kind": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Kind of the object.",
+			},
+			"namespace": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Namespace of the object.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the object.",
+			},
+			"uid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "UID of the object.",
+			},
+		},
+	}
+}
+
+func resourcePOManifestCreate(d *schema.ResourceData, meta interface{}) error {
+	_, m, err := manifest(d)
+	if err != nil {
+		return err
+	}
+	obj := manifestObject(m)
+	client, err := manifestClient(meta, obj.GroupVersionKind(), obj.GetNamespace())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Creating %s %s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+	out, err := client.Create(obj, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("Failed to create %s: %s", obj.GetKind(), err)
+	}
+	log.Printf("[INFO] Submitted new %s: %#v", out.GetKind(), out.Object)
+
+	d.SetId(buildManifestId(out))
+
+	if err := waitForManifest(d, out, d.Timeout(schema.TimeoutCreate), meta); err != nil {
+		return err
+	}
+	return resourcePOManifestRead(d, meta)
+}
+
+func resourcePOManifestExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	gvk, namespace, name, err := manifestIdParts(d.Id())
+	if err != nil {
+		return false, err
+	}
+	client, err := manifestClient(meta, gvk, namespace)
+	if err != nil {
+		return false, err
+	}
+
+	log.Printf("[INFO] Checking %s %s", gvk.Kind, name)
+	_, err = client.Get(name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+	}
+	return true, err
+}
+
+func resourcePOManifestRead(d *schema.ResourceData, meta interface{}) error {
+	gvk, namespace, name, err := manifestIdParts(d.Id())
+	if err != nil {
+		return err
+	}
+	client, err := manifestClient(meta, gvk, namespace)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading %s %s", gvk.Kind, name)
+	out, err := client.Get(name, metav1.GetOptions{})
+	if err != nil {
+		switch {
+		case errors.IsNotFound(err):
+			log.Printf("[DEBUG] %s %q was not found in Namespace %q - removing from state!", gvk.Kind, name, namespace)
+			d.SetId("")
+			return nil
+		default:
+			log.Printf("[DEBUG] Error reading %s: %#v", gvk.Kind, err)
+			return err
+		}
+	}
+	log.Printf("[INFO] Received %s: %#v", gvk.Kind, out.Object)
+
+	k, m, err := manifest(d)
+	if err != nil {
+		return err
+	}
+	var live map[string]interface{}
+	if k == "" {
+		// Imported objects are only identified.
+		k = "manifest_yaml"
+		live = importedManifest(out)
+	} else {
+		live = projectFields(out.Object, m).(map[string]interface{})
+	}
+	v, err := formatObject(k, live)
+	if err != nil {
+		return err
+	}
+	if err := d.Set(k, v); err != nil {
+		return fmt.Errorf("Failed to set %s: %s", k, err)
+	}
+
+	d.Set("api_version", out.GetAPIVersion())
+	d.Set("kind", out.GetKind())
+	d.Set("namespace", out.GetNamespace())
+	d.Set("name", out.GetName())
+	d.Set("uid", string(out.GetUID()))
+	return nil
+}
+
+func resourcePOManifestUpdate(d *schema.ResourceData, meta interface{}) error {
+	gvk, namespace, name, err := manifestIdParts(d.Id())
+	if err != nil {
+		return err
+	}
+	client, err := manifestClient(meta, gvk, namespace)
+	if err != nil {
+		return err
+	}
+
+	old, err := oldManifest(d)
+	if err != nil {
+		return err
+	}
+	_, m, err := manifest(d)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(mergePatch(old, m))
+	if err != nil {
+		return fmt.Errorf("Failed to marshal update of %s: %s", gvk.Kind, err)
+	}
+
+	log.Printf("[INFO] Updating %s %q: %s", gvk.Kind, name, data)
+	out, err := client.Patch(name, pkgApi.MergePatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("Failed to update %s: %s", gvk.Kind, err)
+	}
+	log.Printf("[INFO] Submitted updated %s: %#v", gvk.Kind, out.Object)
+
+	if err := waitForManifest(d, out, d.Timeout(schema.TimeoutUpdate), meta); err != nil {
+		return err
+	}
+	return resourcePOManifestRead(d, meta)
+}
+
+func resourcePOManifestDelete(d *schema.ResourceData, meta interface{}) error {
+	gvk, namespace, name, err := manifestIdParts(d.Id())
+	if err != nil {
+		return err
+	}
+	client, err := manifestClient(meta, gvk, namespace)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting %s: %q", gvk.Kind, name)
+	err = client.Delete(name, &metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	log.Printf("[INFO] %s %s deleted", gvk.Kind, name)
+
+	d.SetId("")
+
+	return nil
+}
+
+// manifestDiff replaces the object if the manifest identifies another one.
+func manifestDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	k, m, err := manifest(d)
+	if err != nil || k == "" {
+		// Unknown until apply, or reported by validation.
+		return nil
+	}
+	if id := buildManifestId(manifestObject(m)); id != d.Id() {
+		log.Printf("[DEBUG] Manifest identifies %s instead of %s, replacing", id, d.Id())
+		return d.ForceNew(k)
+	}
+	return nil
+}
+
+func waitForManifest(d *schema.ResourceData, obj *unstructured.Unstructured, timeout time.Duration, meta interface{}) error {
+	w, ok := d.Get("wait_for").([]interface{})
+	if !ok || len(w) == 0 || w[0] == nil {
+		return nil
+	}
+	in := w[0].(map[string]interface{})
+	condition := in["condition"].(string)
+	status := in["status"].(string)
+
+	client, err := manifestClient(meta, obj.GroupVersionKind(), obj.GetNamespace())
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Waiting for condition %s of %s %q to be %s", condition, obj.GetKind(), obj.GetName(), status)
+	return waitForCondition(client, obj.GetName(), condition, status, timeout)
+}
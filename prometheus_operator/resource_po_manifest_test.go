@@ -0,0 +1,135 @@
+package prometheus_operator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAccPrometheusOperatorManifest_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	namespace := "monitoring"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccPrometheusOperatorManifestDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPrometheusOperatorManifestConfig_basic(name, namespace, "30s"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccPrometheusOperatorManifestExists("po_manifest.test"),
+					resource.TestCheckResourceAttr("po_manifest.test", "api_version", "monitoring.coreos.com/v1"),
+					resource.TestCheckResourceAttr("po_manifest.test", "kind", "ServiceMonitor"),
+					resource.TestCheckResourceAttr("po_manifest.test", "namespace", namespace),
+					resource.TestCheckResourceAttr("po_manifest.test", "name", name),
+					resource.TestCheckResourceAttrSet("po_manifest.test", "uid"),
+				),
+			},
+			{
+				Config: testAccPrometheusOperatorManifestConfig_basic(name, namespace, "60s"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccPrometheusOperatorManifestExists("po_manifest.test"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPrometheusOperatorManifest_importBasic(t *testing.T) {
+	resourceName := "po_manifest.test"
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	namespace := "monitoring"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccPrometheusOperatorManifestDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPrometheusOperatorManifestConfig_basic(name, namespace, "30s"),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"manifest_json", "manifest_yaml"},
+			},
+		},
+	})
+}
+
+func testAccPrometheusOperatorManifestConfig_basic(name, namespace, interval string) string {
+	return fmt.Sprintf(`
+resource "po_manifest" "test" {
+  manifest_json = jsonencode({
+    apiVersion = "monitoring.coreos.com/v1"
+    kind       = "ServiceMonitor"
+    metadata = {
+      name      = "%[1]s"
+      namespace = "%[2]s"
+      labels = {
+        "k8s-app" = "%[1]s"
+      }
+    }
+    spec = {
+      endpoints = [{
+        port     = "http-metrics"
+        interval = "%[3]s"
+      }]
+      selector = {
+        matchLabels = {
+          "k8s-app" = "%[1]s"
+        }
+      }
+    }
+  })
+}`, name, namespace, interval)
+}
+
+func testAccPrometheusOperatorManifestExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		gvk, namespace, name, err := manifestIdParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		client, err := manifestClient(testAccProvider.Meta(), gvk, namespace)
+		if err != nil {
+			return err
+		}
+
+		_, err = client.Get(name, meta_v1.GetOptions{})
+		return err
+	}
+}
+
+func testAccPrometheusOperatorManifestDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "po_manifest" {
+			continue
+		}
+
+		gvk, namespace, name, err := manifestIdParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		client, err := manifestClient(testAccProvider.Meta(), gvk, namespace)
+		if err != nil {
+			return err
+		}
+
+		_, err = client.Get(name, meta_v1.GetOptions{})
+		if err == nil {
+			return fmt.Errorf("%s still exists: %s", gvk.Kind, rs.Primary.ID)
+		}
+	}
+	return nil
+}
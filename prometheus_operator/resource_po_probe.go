@@ -0,0 +1,494 @@
+package prometheus_operator
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+)
+
+func resourcePOProbe() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePOProbeCreate,
+		Read:   resourcePOProbeRead,
+		Exists: resourcePOProbeExists,
+		Update: resourcePOProbeUpdate,
+		Delete: resourcePOProbeDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		// Operator v0.34.0 ships no Probe CRD, so there is no schema to validate against.
+		CustomizeDiff: customdiff.All(
+			specOverrideDiff(probeObject),
+			validateProberDiff,
+			validateRelabelConfigsDiff(probeObject),
+			dryRunDiff("probes", probeObject, patchProbe),
+		),
+
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("probe", true),
+			"spec": {
+				Type:        schema.TypeList,
+				Description: "Specification of desired Ingress selection for target discovery by Prometheus.",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"job_name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The job name assigned to scraped metrics by default.",
+						},
+						"prober": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MaxItems:    1,
+							Description: "Specification for the prober to use for probing targets.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"url": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "Mandatory URL of the prober as host:port, e.g. blackbox-exporter.monitoring:9115. The scheme and the path are set with scheme and path.",
+									},
+									"scheme": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										Description:  "HTTP scheme to use for scraping. Defaults to http.",
+										ValidateFunc: validation.StringInSlice([]string{"http", "https"}, false),
+									},
+									"path": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "Path to collect metrics from. Defaults to /probe.",
+									},
+								},
+							},
+						},
+						"module": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The module to use for probing specifying how to probe the target, e.g. http_2xx.",
+						},
+						"targets": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: "Targets defines a set of static or dynamically discovered targets to be probed.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"static_config": {
+										Type:          schema.TypeList,
+										Optional:      true,
+										MaxItems:      1,
+										ConflictsWith: []string{"spec.0.targets.0.ingress"},
+										Description:   "StaticConfig defines static targets which are considered for probing.",
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"static": {
+													Type:        schema.TypeList,
+													Optional:    true,
+													Elem:        &schema.Schema{Type: schema.TypeString},
+													Description: "Targets is a list of URLs to probe using the configured prober.",
+												},
+												"labels": {
+													Type:        schema.TypeMap,
+													Optional:    true,
+													Elem:        &schema.Schema{Type: schema.TypeString},
+													Description: "Labels assigned to all metrics scraped from the targets.",
+												},
+												"relabeling_configs": {
+													Type:        schema.TypeList,
+													Optional:    true,
+													Description: "RelabelConfigs to apply to samples before ingestion.",
+													Elem: &schema.Resource{
+														Schema: genRelabelConfigSchema(),
+													},
+												},
+											},
+										},
+									},
+									"ingress": {
+										Type:          schema.TypeList,
+										Optional:      true,
+										MaxItems:      1,
+										ConflictsWith: []string{"spec.0.targets.0.static_config"},
+										Description:   "Ingress defines the set of dynamically discovered ingress objects which hosts are considered for probing.",
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"selector": {
+													Type:        schema.TypeList,
+													Optional:    true,
+													MaxItems:    1,
+													Description: "Select Ingress objects by labels.",
+													Elem: &schema.Resource{
+														Schema: labelSelectorFields(true),
+													},
+												},
+												"namespace_selector": {
+													Type:        schema.TypeList,
+													Optional:    true,
+													MaxItems:    1,
+													Description: "Select Ingress objects by namespace.",
+													Elem: &schema.Resource{
+														Schema: genNamespaceSelectorSchema(),
+													},
+												},
+												"relabeling_configs": {
+													Type:        schema.TypeList,
+													Optional:    true,
+													Description: "RelabelConfigs to apply to samples before ingestion.",
+													Elem: &schema.Resource{
+														Schema: genRelabelConfigSchema(),
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						"interval": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Interval at which targets are probed using the configured prober. If not specified Prometheus' global scrape interval is used.",
+						},
+						"scrape_timeout": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Timeout for scraping metrics from the Prometheus exporter.",
+						},
+					},
+				},
+			},
+			"spec_override_json": specOverrideSchema("json"),
+			"spec_override_yaml": specOverrideSchema("yaml"),
+		},
+	}
+}
+
+func resourcePOProbeCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*KubeClientsets).DynamicClient
+
+	body, err := probeObject(d)
+	if err != nil {
+		return err
+	}
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(body); err != nil {
+		return fmt.Errorf("Failed to decode Probe: %s", err)
+	}
+
+	log.Printf("[INFO] Creating Probe custom resource: %s", body)
+	out, err := conn.Resource(probeGVR).Namespace(obj.GetNamespace()).Create(obj, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("Failed to create Probe: %s", err)
+	}
+
+	log.Printf("[INFO] Submitted new Probe custom resource: %#v", out.Object)
+
+	d.SetId(buildId(metav1.ObjectMeta{Namespace: out.GetNamespace(), Name: out.GetName()}))
+
+	return resourcePOProbeRead(d, meta)
+}
+
+func resourcePOProbeExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	conn := meta.(*KubeClientsets).DynamicClient
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	log.Printf("[INFO] Checking Probe custom resource %s", name)
+	_, err = conn.Resource(probeGVR).Namespace(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+	}
+	return true, err
+}
+
+func resourcePOProbeRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*KubeClientsets).DynamicClient
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading Probe custom resource %s", name)
+	out, err := conn.Resource(probeGVR).Namespace(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		switch {
+		case errors.IsNotFound(err):
+			log.Printf("[DEBUG] Probe %q was not found in Namespace %q - removing from state!", name, namespace)
+			d.SetId("")
+			return nil
+		default:
+			log.Printf("[DEBUG] Error reading Probe: %#v", err)
+			return err
+		}
+	}
+	data, err := out.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	p := &Probe{}
+	if err := decodeObject(d, data, p); err != nil {
+		return fmt.Errorf("Failed to decode Probe: %s", err)
+	}
+	log.Printf("[INFO] Received Probe: %#v", p)
+
+	if err := d.Set("metadata", flattenMetadata(p.ObjectMeta, d)); err != nil {
+		return fmt.Errorf("Error setting `metadata`: %+v", err)
+	}
+	spec, err := flattenProbeSpec(p.Spec)
+	if err != nil {
+		return fmt.Errorf("Failed to flatten Probe spec: %s", err)
+	}
+	if err := d.Set("spec", spec); err != nil {
+		return fmt.Errorf("Failed to set Probe spec: %s", err)
+	}
+	return nil
+}
+
+func resourcePOProbeUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*KubeClientsets).DynamicClient
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	data, err := patchProbe(d)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Updating Probe %q: %v", name, string(data))
+	out, err := conn.Resource(probeGVR).Namespace(namespace).Patch(name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("Failed to update Probe: %s", err)
+	}
+	log.Printf("[INFO] Submitted updated Probe: %#v", out.Object)
+
+	return resourcePOProbeRead(d, meta)
+}
+
+func resourcePOProbeDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*KubeClientsets).DynamicClient
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting Probe: %q", name)
+	err = conn.Resource(probeGVR).Namespace(namespace).Delete(name, &metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	log.Printf("[INFO] Probe %s deleted", name)
+
+	d.SetId("")
+
+	return nil
+}
+
+func buildProbe(d resourceGetter) (*Probe, error) {
+	spec, err := expandProbeSpec(d.Get("spec").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Probe{
+		TypeMeta:   metav1.TypeMeta{Kind: "Probe", APIVersion: probeGVR.GroupVersion().String()},
+		ObjectMeta: expandMetadata(d.Get("metadata").([]interface{})),
+		Spec:       *spec,
+	}, nil
+}
+
+func probeObject(d resourceGetter) ([]byte, error) {
+	obj, err := buildProbe(d)
+	if err != nil {
+		return nil, err
+	}
+	return objectWithSpecOverride(d, obj)
+}
+
+func patchProbe(d resourceGetter) ([]byte, error) {
+	ops := patchMetadata("metadata.0.", "/metadata/", d)
+
+	if d.HasChange("spec") || hasSpecOverrideChange(d) {
+		log.Println("[TRACE] Probe.Spec has changes")
+		spec, err := expandProbeSpec(d.Get("spec").([]interface{}))
+		if err != nil {
+			return nil, err
+		}
+		merged, err := mergedSpec(d, spec)
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, replace(merged))
+	}
+
+	data, err := ops.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to marshal update operations for Probe: %s", err)
+	}
+	return data, nil
+}
+
+// validateProberDiff reports prober URLs which Prometheus can't scrape during
+// plan, unless the URL is known only after apply.
+func validateProberDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("spec.0.prober.0.url") {
+		log.Printf("[DEBUG] Skipping validation of the prober of %q, its URL is known only after apply", d.Id())
+		return nil
+	}
+	prober, _ := d.Get("spec.0.prober").([]interface{})
+	if len(prober) == 0 || prober[0] == nil {
+		return nil
+	}
+	return validateProber(prober[0].(map[string]interface{}))
+}
+
+// validateProber checks that the URL of the prober is the address of the
+// prober only, as the operator uses it as the address of the targets and
+// takes the scheme and the path from their attributes.
+func validateProber(in map[string]interface{}) error {
+	u, _ := in["url"].(string)
+	if strings.Contains(u, "://") {
+		return fmt.Errorf("spec.0.prober.0.url: %q must not have a scheme, set it with scheme", u)
+	}
+	if strings.ContainsAny(u, "/?#") {
+		return fmt.Errorf("spec.0.prober.0.url: %q must be host:port only, set the path with path", u)
+	}
+	return nil
+}
+
+func expandProbeSpec(l []interface{}) (*ProbeSpec, error) {
+	obj := &ProbeSpec{}
+	if len(l) == 0 || l[0] == nil {
+		return obj, nil
+	}
+	in := l[0].(map[string]interface{})
+
+	obj.JobName = in["job_name"].(string)
+	obj.Module = in["module"].(string)
+	obj.Interval = in["interval"].(string)
+	obj.ScrapeTimeout = in["scrape_timeout"].(string)
+	if v, ok := in["prober"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		p := v[0].(map[string]interface{})
+		if err := validateProber(p); err != nil {
+			return obj, err
+		}
+		obj.ProberSpec = ProberSpec{
+			URL:    p["url"].(string),
+			Scheme: p["scheme"].(string),
+			Path:   p["path"].(string),
+		}
+	}
+	if v, ok := in["targets"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		targets, err := expandProbeTargets(v[0].(map[string]interface{}))
+		if err != nil {
+			return obj, err
+		}
+		obj.Targets = *targets
+	}
+	return obj, nil
+}
+
+func expandProbeTargets(in map[string]interface{}) (*ProbeTargets, error) {
+	obj := &ProbeTargets{}
+	if v, ok := in["static_config"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		sc := v[0].(map[string]interface{})
+		obj.StaticConfig = &ProbeTargetStaticConfig{}
+		if s, ok := sc["static"].([]interface{}); ok {
+			obj.StaticConfig.Targets = expandStringSlice(s)
+		}
+		if l, ok := sc["labels"].(map[string]interface{}); ok && len(l) > 0 {
+			obj.StaticConfig.Labels = expandStringMap(l)
+		}
+		if r, ok := sc["relabeling_configs"].([]interface{}); ok && len(r) > 0 {
+			c, err := expandRelabelConfig(r)
+			if err != nil {
+				return obj, err
+			}
+			obj.StaticConfig.RelabelConfigs = c
+		}
+	}
+	if v, ok := in["ingress"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		ing := v[0].(map[string]interface{})
+		obj.Ingress = &ProbeTargetIngress{}
+		if s, ok := ing["selector"].([]interface{}); ok && len(s) > 0 {
+			obj.Ingress.Selector = *expandLabelSelector(s)
+		}
+		if ns, ok := ing["namespace_selector"].([]interface{}); ok && len(ns) > 0 {
+			selector, err := expandNamespaceSelector(ns)
+			if err != nil {
+				return obj, err
+			}
+			obj.Ingress.NamespaceSelector = *selector
+		}
+		if r, ok := ing["relabeling_configs"].([]interface{}); ok && len(r) > 0 {
+			c, err := expandRelabelConfig(r)
+			if err != nil {
+				return obj, err
+			}
+			obj.Ingress.RelabelConfigs = c
+		}
+	}
+	return obj, nil
+}
+
+func flattenProbeSpec(spec ProbeSpec) ([]interface{}, error) {
+	att := make(map[string]interface{})
+	att["job_name"] = spec.JobName
+	att["module"] = spec.Module
+	att["interval"] = spec.Interval
+	att["scrape_timeout"] = spec.ScrapeTimeout
+	att["prober"] = []interface{}{map[string]interface{}{
+		"url":    spec.ProberSpec.URL,
+		"scheme": spec.ProberSpec.Scheme,
+		"path":   spec.ProberSpec.Path,
+	}}
+
+	targets := make(map[string]interface{})
+	if sc := spec.Targets.StaticConfig; sc != nil {
+		relabelings, err := flattenRelabelConfig(sc.RelabelConfigs)
+		if err != nil {
+			return nil, err
+		}
+		targets["static_config"] = []interface{}{map[string]interface{}{
+			"static":             sc.Targets,
+			"labels":             sc.Labels,
+			"relabeling_configs": relabelings,
+		}}
+	}
+	if ing := spec.Targets.Ingress; ing != nil {
+		namespaces, err := flattenNamespaceSelector(&ing.NamespaceSelector)
+		if err != nil {
+			return nil, err
+		}
+		relabelings, err := flattenRelabelConfig(ing.RelabelConfigs)
+		if err != nil {
+			return nil, err
+		}
+		targets["ingress"] = []interface{}{map[string]interface{}{
+			"selector":           flattenLabelSelector(&ing.Selector),
+			"namespace_selector": namespaces,
+			"relabeling_configs": relabelings,
+		}}
+	}
+	if len(targets) > 0 {
+		att["targets"] = []interface{}{targets}
+	}
+	return []interface{}{att}, nil
+}
@@ -0,0 +1,252 @@
+package prometheus_operator
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestAccPrometheusOperatorProbe_basic(t *testing.T) {
+	var p Probe
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	namespace := "monitoring"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "po_probe.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccPrometheusOperatorProbeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPrometheusOperatorProbeConfig_basic(name, namespace),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccPrometheusOperatorProbeExists("po_probe.test", &p),
+					resource.TestCheckResourceAttr("po_probe.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttr("po_probe.test", "metadata.0.namespace", namespace),
+					resource.TestCheckResourceAttrSet("po_probe.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttr("po_probe.test", "spec.0.job_name", "blackbox"),
+					resource.TestCheckResourceAttr("po_probe.test", "spec.0.module", "http_2xx"),
+					resource.TestCheckResourceAttr("po_probe.test", "spec.0.interval", "30s"),
+					resource.TestCheckResourceAttr("po_probe.test", "spec.0.prober.0.url", "blackbox-exporter.monitoring:9115"),
+					resource.TestCheckResourceAttr("po_probe.test", "spec.0.targets.0.static_config.0.static.#", "2"),
+					resource.TestCheckResourceAttr("po_probe.test", "spec.0.targets.0.static_config.0.labels.env", "test"),
+					resource.TestCheckResourceAttr("po_probe.test", "spec.0.targets.0.static_config.0.relabeling_configs.0.target_label", "instance"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPrometheusOperatorProbe_importBasic(t *testing.T) {
+	resourceName := "po_probe.test"
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	namespace := "monitoring"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccPrometheusOperatorProbeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPrometheusOperatorProbeConfig_basic(name, namespace),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version"},
+			},
+		},
+	})
+}
+
+func TestExpandFlattenProbeSpec(t *testing.T) {
+	raw := map[string]interface{}{
+		"spec": []interface{}{map[string]interface{}{
+			"job_name": "blackbox",
+			"module":   "http_2xx",
+			"prober": []interface{}{map[string]interface{}{
+				"url":  "blackbox-exporter:9115",
+				"path": "/probe",
+			}},
+			"targets": []interface{}{map[string]interface{}{
+				"ingress": []interface{}{map[string]interface{}{
+					"selector": []interface{}{map[string]interface{}{
+						"match_labels": map[string]interface{}{"app": "web"},
+					}},
+					"namespace_selector": []interface{}{map[string]interface{}{
+						"match_names": []interface{}{"default"},
+					}},
+					"relabeling_configs": []interface{}{map[string]interface{}{
+						"source_labels": []interface{}{"__address__"},
+						"target_label":  "instance",
+					}},
+				}},
+			}},
+		}},
+	}
+	d := schema.TestResourceDataRaw(t, resourcePOProbe().Schema, raw)
+
+	spec, err := expandProbeSpec(d.Get("spec").([]interface{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.ProberSpec.URL != "blackbox-exporter:9115" || spec.Targets.StaticConfig != nil {
+		t.Fatalf("Unexpected spec %#v", spec)
+	}
+	ing := spec.Targets.Ingress
+	if ing == nil || ing.Selector.MatchLabels["app"] != "web" || len(ing.NamespaceSelector.MatchNames) != 1 || len(ing.RelabelConfigs) != 1 {
+		t.Fatalf("Unexpected ingress targets %#v", ing)
+	}
+
+	// The object must survive the conversion used when reading it back.
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&Probe{Spec: *spec})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &Probe{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u, p); err != nil {
+		t.Fatal(err)
+	}
+	flattened, err := flattenProbeSpec(p.Spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Set("spec", flattened); err != nil {
+		t.Fatal(err)
+	}
+	out, err := expandProbeSpec(d.Get("spec").([]interface{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(out, spec) {
+		t.Errorf("Expected %#v after round trip, got %#v", spec, out)
+	}
+}
+
+func TestProbeObject_specOverride(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourcePOProbe().Schema, map[string]interface{}{
+		"metadata": []interface{}{map[string]interface{}{"name": "web", "namespace": "monitoring"}},
+		"spec": []interface{}{map[string]interface{}{
+			"prober": []interface{}{map[string]interface{}{"url": "blackbox-exporter:9115"}},
+		}},
+		"spec_override_yaml": "prober:\n  proxyUrl: http://proxy:3128\n",
+	})
+	body, err := probeObject(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(body, &obj); err != nil {
+		t.Fatal(err)
+	}
+	prober := obj["spec"].(map[string]interface{})["prober"].(map[string]interface{})
+	if prober["url"] != "blackbox-exporter:9115" || prober["proxyUrl"] != "http://proxy:3128" {
+		t.Errorf("Expected override merged into the prober, got %v", prober)
+	}
+}
+
+func testAccPrometheusOperatorProbeConfig_basic(name, namespace string) string {
+	return fmt.Sprintf(`
+resource "po_probe" "test" {
+  metadata {
+    name = "%[1]s"
+    namespace = "%[2]s"
+  }
+  spec {
+    job_name = "blackbox"
+    module = "http_2xx"
+    interval = "30s"
+    prober {
+      url = "blackbox-exporter.monitoring:9115"
+    }
+    targets {
+      static_config {
+        static = ["https://example.com", "https://example.org"]
+        labels = {
+          env = "test"
+        }
+        relabeling_configs {
+          source_labels = ["__param_target"]
+          target_label = "instance"
+        }
+      }
+    }
+  }
+}`, name, namespace)
+}
+
+func testAccPrometheusOperatorProbeExists(n string, obj *Probe) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*KubeClientsets).DynamicClient
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		out, err := conn.Resource(probeGVR).Namespace(namespace).Get(name, meta_v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		return runtime.DefaultUnstructuredConverter.FromUnstructured(out.UnstructuredContent(), obj)
+	}
+}
+
+func testAccPrometheusOperatorProbeDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*KubeClientsets).DynamicClient
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "po_probe" {
+			continue
+		}
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = conn.Resource(probeGVR).Namespace(namespace).Get(name, meta_v1.GetOptions{})
+		if err == nil {
+			return fmt.Errorf("Probe still exists: %s", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func TestValidateProberDiff(t *testing.T) {
+	cases := map[string]string{
+		"blackbox-exporter.monitoring:9115":        "",
+		"http://blackbox-exporter.monitoring:9115": "must not have a scheme",
+		"blackbox-exporter.monitoring:9115/probe":  "set the path with path",
+	}
+	for url, expected := range cases {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"metadata": []interface{}{map[string]interface{}{"name": "web", "namespace": "monitoring"}},
+			"spec": []interface{}{map[string]interface{}{
+				"prober": []interface{}{map[string]interface{}{"url": url}},
+			}},
+		})
+		_, err := resourcePOProbe().Diff(nil, config, &KubeClientsets{})
+		if expected == "" && err != nil {
+			t.Errorf("%s: expected no error, got %s", url, err)
+		}
+		if expected != "" && (err == nil || !strings.Contains(err.Error(), expected)) {
+			t.Errorf("%s: expected error %q during plan, got %v", url, expected, err)
+		}
+	}
+}
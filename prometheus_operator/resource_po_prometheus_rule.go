@@ -3,12 +3,14 @@ package prometheus_operator
 import (
 	"fmt"
 	po_types "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
-	po_v1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgApi "k8s.io/apimachinery/pkg/types"
 	"log"
+	"time"
 )
 
 func resourcePOPrometheusRule() *schema.Resource {
@@ -21,8 +23,24 @@ func resourcePOPrometheusRule() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: customdiff.All(
+			ruleShardsDiff,
+			specOverrideDiff(prometheusRuleObject),
+			validateCRDSchemaDiff("PrometheusRule", prometheusRuleObject),
+			enforceNamespaceLabelDiff,
+			fanOutDiff(prometheusRuleObject),
+			customdiff.If(hasRuleShards, dryRunRuleShardsDiff),
+			customdiff.If(func(d *schema.ResourceDiff, meta interface{}) bool {
+				return !hasRuleShards(d, meta)
+			}, dryRunDiff("prometheusrules", prometheusRuleObject, patchPrometheusRule)),
+		),
 
-		Schema: map[string]*schema.Schema{
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: withFanOut(map[string]*schema.Schema{
 			"metadata": namespacedMetadataSchema("prometheus rule", true),
 			"spec": {
 				Type:        schema.TypeList,
@@ -42,26 +60,76 @@ func resourcePOPrometheusRule() *schema.Resource {
 					},
 				},
 			},
-		},
+			"spec_override_json": specOverrideSchema("json"),
+			"spec_override_yaml": specOverrideSchema("yaml"),
+			"max_object_bytes": {
+				Type:          schema.TypeInt,
+				Description:   "Maximum size in bytes of a PrometheusRule object. If set, groups are split across several PrometheusRule objects named <name>-0, <name>-1, ..., sharing the same labels, so none exceeds the size limits of etcd and of the ConfigMaps generated by the operator.",
+				Optional:      true,
+				ConflictsWith: fanOutKeys,
+				ValidateFunc:  validation.IntAtLeast(1024),
+			},
+			"shards": {
+				Type:        schema.TypeList,
+				Description: "Names of the PrometheusRule objects holding the groups, if max_object_bytes is set.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"verify_loaded": {
+				Type:        schema.TypeBool,
+				Description: "Wait after create or update until the groups are in the rule ConfigMaps generated by the operator for every selecting Prometheus, and are loaded by every replica of it, with the same rules and expressions. Replicas are queried through the proxy of their pod.",
+				Optional:    true,
+			},
+			"enforce_namespace_label": {
+				Type:          schema.TypeString,
+				Description:   "Restrict the expressions of the rules to series of the namespace of the object, like prom-label-proxy. With inject, the matcher namespace=\"<metadata.namespace>\" is added to every selector missing it in the object, while the configured expressions are kept in the state. With verify, rules with such selectors are rejected during plan, or during apply if the expressions depend on other resources. Defaults to verify if enforce_namespace_label is set in the provider. Can't be combined with copies in other namespaces, which would read series of the namespace of the object.",
+				Optional:      true,
+				ConflictsWith: fanOutKeys,
+				ValidateFunc:  validation.StringInSlice([]string{enforceInject, enforceVerify}, false),
+			},
+		}, "max_object_bytes", "enforce_namespace_label"),
 	}
 }
 
 func resourcePOPrometheusRuleCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*KubeClientsets).MonitoringClient
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
 
-	spec, err := expandPrometheusRuleSpec(d.Get("spec").([]interface{}))
-	if err != nil {
+	// The plan skips the check if values were unknown.
+	if err := verifyNamespaceLabel(d, meta); err != nil {
 		return err
 	}
 
-	monitor := po_types.PrometheusRule{
-		ObjectMeta: metadata,
-		Spec:       *spec,
+	if isShardedRule(d) {
+		shards, err := prometheusRuleShards(d)
+		if err != nil {
+			return err
+		}
+		metadata := expandMetadata(d.Get("metadata").([]interface{}))
+		log.Printf("[INFO] Creating PrometheusRule %q in %d shards", metadata.Name, len(shards))
+		// Shards created before an error are stored, so the next apply
+		// replaces them instead of failing to create them again.
+		names, err := applyRuleShards(conn.RESTClient(), metadata.Namespace, shards, nil)
+		if len(names) > 0 {
+			d.SetId(buildId(metadata))
+			d.Set("shards", names)
+		}
+		if err != nil {
+			return err
+		}
+		if err := verifyRulesLoaded(d, meta, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
+		return resourcePOPrometheusRuleRead(d, meta)
 	}
 
-	log.Printf("[INFO] Creating PrometheusRule custom resource: %#v", monitor)
-	out, err := conn.PrometheusRules(metadata.Namespace).Create(&monitor)
+	body, err := prometheusRuleObject(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Creating PrometheusRule custom resource: %s", body)
+	out := &po_types.PrometheusRule{}
+	err = createObject(conn.RESTClient(), "prometheusrules", d.Get("metadata.0.namespace").(string), body, out)
 	if err != nil {
 		return fmt.Errorf("Failed to create PrometheusRule: %s", err)
 	}
@@ -70,25 +138,39 @@ func resourcePOPrometheusRuleCreate(d *schema.ResourceData, meta interface{}) er
 
 	d.SetId(buildId(out.ObjectMeta))
 
+	if err := applyFanOut(d, meta, "prometheusrules", prometheusRuleObject); err != nil {
+		return err
+	}
+	if err := verifyRulesLoaded(d, meta, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return err
+	}
 	return resourcePOPrometheusRuleRead(d, meta)
 }
 
 func resourcePOPrometheusRuleExists(d *schema.ResourceData, meta interface{}) (bool, error) {
 	conn := meta.(*KubeClientsets).MonitoringClient
-	namespace, name, err := idParts(d.Id())
+	namespace, _, err := idParts(d.Id())
 	if err != nil {
 		return false, err
 	}
-
-	log.Printf("[INFO] Checking PrometheusRule custom resource %s", name)
-	_, err = conn.PrometheusRules(namespace).Get(name, metav1.GetOptions{})
+	names, err := ruleObjectNames(d, d.Get("shards").([]interface{}))
 	if err != nil {
-		if errors.IsNotFound(err) {
-			return false, nil
+		return false, err
+	}
+
+	// The resource exists as long as one of its shards does.
+	for _, n := range names {
+		log.Printf("[INFO] Checking PrometheusRule custom resource %s", n)
+		_, err = conn.PrometheusRules(namespace).Get(n, metav1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			log.Printf("[DEBUG] Received error: %#v", err)
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		return true, err
 	}
-	return true, err
+	return false, nil
 }
 
 func resourcePOPrometheusRuleRead(d *schema.ResourceData, meta interface{}) error {
@@ -98,8 +180,13 @@ func resourcePOPrometheusRuleRead(d *schema.ResourceData, meta interface{}) erro
 		return err
 	}
 
+	names, err := ruleObjectNames(d, d.Get("shards").([]interface{}))
+	if err != nil {
+		return err
+	}
+
 	log.Printf("[INFO] Reading PrometheusRule custom resource %s", name)
-	am, err := conn.PrometheusRules(namespace).Get(name, metav1.GetOptions{})
+	am, err := readRuleShards(d, conn.RESTClient(), namespace, name, names)
 	if err != nil {
 		switch {
 		case errors.IsNotFound(err):
@@ -122,7 +209,7 @@ func resourcePOPrometheusRuleRead(d *schema.ResourceData, meta interface{}) erro
 	if err != nil {
 		return fmt.Errorf("Failed to set PrometheusRule spec: %s", err)
 	}
-	return nil
+	return readFanOut(d, meta, "prometheusrules")
 }
 
 func resourcePOPrometheusRuleUpdate(d *schema.ResourceData, meta interface{}) error {
@@ -131,20 +218,42 @@ func resourcePOPrometheusRuleUpdate(d *schema.ResourceData, meta interface{}) er
 	if err != nil {
 		return err
 	}
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	if err := verifyNamespaceLabel(d, meta); err != nil {
+		return err
+	}
 
-	if d.HasChange("spec") {
-		log.Println("[TRACE] PrometheusRule.Spec has changes")
-		spec, err := expandPrometheusRuleSpec(d.Get("spec").([]interface{}))
+	if o, _ := d.GetChange("shards"); isShardedRule(d) || len(o.([]interface{})) > 0 {
+		previous, err := ruleObjectNames(d, o.([]interface{}))
+		if err != nil {
+			return err
+		}
+		shards, err := prometheusRuleShards(d)
+		if err != nil {
+			return err
+		}
+		log.Printf("[INFO] Updating PrometheusRule %q from %v to %v", name, previous, ruleShardNames(shards))
+		names, err := applyRuleShards(conn.RESTClient(), namespace, shards, previous)
 		if err != nil {
+			d.Set("shards", names)
 			return err
 		}
-		ops = append(ops, replace(spec))
+		if isShardedRule(d) {
+			d.Set("shards", names)
+		} else {
+			d.Set("shards", []string{})
+		}
+		if err := applyFanOut(d, meta, "prometheusrules", prometheusRuleObject); err != nil {
+			return err
+		}
+		if err := verifyRulesLoaded(d, meta, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+		return resourcePOPrometheusRuleRead(d, meta)
 	}
 
-	data, err := ops.MarshalJSON()
+	data, err := patchPrometheusRule(d)
 	if err != nil {
-		return fmt.Errorf("Failed to marshal update operations for PrometheusRule: %s", err)
+		return err
 	}
 	log.Printf("[INFO] Updating PrometheusRule %q: %v", name, string(data))
 	out, err := conn.PrometheusRules(namespace).Patch(name, pkgApi.JSONPatchType, data)
@@ -153,6 +262,12 @@ func resourcePOPrometheusRuleUpdate(d *schema.ResourceData, meta interface{}) er
 	}
 	log.Printf("[INFO] Submitted updated PrometheusRule: %#v", out)
 
+	if err := applyFanOut(d, meta, "prometheusrules", prometheusRuleObject); err != nil {
+		return err
+	}
+	if err := verifyRulesLoaded(d, meta, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return err
+	}
 	return resourcePOPrometheusRuleRead(d, meta)
 }
 
@@ -163,12 +278,29 @@ func resourcePOPrometheusRuleDelete(d *schema.ResourceData, meta interface{}) er
 		return err
 	}
 
-	log.Printf("[INFO] Deleting PrometheusRule: %q", name)
-	err = conn.PrometheusRules(namespace).Delete(name, &metav1.DeleteOptions{})
+	if err := deleteFanOut(d, meta, "prometheusrules"); err != nil {
+		return err
+	}
+
+	names, err := ruleObjectNames(d, d.Get("shards").([]interface{}))
 	if err != nil {
 		return err
 	}
 
+	if len(names) > 1 || names[0] != name {
+		for _, n := range names {
+			if err := deleteObject(conn.RESTClient(), "prometheusrules", namespace, n); err != nil {
+				return err
+			}
+		}
+	} else {
+		log.Printf("[INFO] Deleting PrometheusRule: %q", name)
+		err = conn.PrometheusRules(namespace).Delete(name, &metav1.DeleteOptions{})
+		if err != nil {
+			return err
+		}
+	}
+
 	log.Printf("[INFO] PrometheusRule %s deleted", name)
 
 	d.SetId("")
@@ -176,8 +308,58 @@ func resourcePOPrometheusRuleDelete(d *schema.ResourceData, meta interface{}) er
 	return nil
 }
 
-func expandPrometheusRuleSpec(groups []interface{}) (*po_types.PrometheusRuleSpec, error) {
-	obj := &po_types.PrometheusRuleSpec{}
+func buildPrometheusRule(d resourceGetter) (*PrometheusRule, error) {
+	spec, err := expandPrometheusRuleSpec(d.Get("spec").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	if err := injectNamespaceLabel(d, spec.Groups); err != nil {
+		return nil, err
+	}
+
+	return &PrometheusRule{
+		TypeMeta:   metav1.TypeMeta{Kind: "PrometheusRule", APIVersion: po_types.SchemeGroupVersion.String()},
+		ObjectMeta: expandMetadata(d.Get("metadata").([]interface{})),
+		Spec:       *spec,
+	}, nil
+}
+
+func prometheusRuleObject(d resourceGetter) ([]byte, error) {
+	obj, err := buildPrometheusRule(d)
+	if err != nil {
+		return nil, err
+	}
+	return objectWithSpecOverride(d, obj)
+}
+
+func patchPrometheusRule(d resourceGetter) ([]byte, error) {
+	ops := patchMetadata("metadata.0.", "/metadata/", d)
+
+	if d.HasChange("spec") || hasSpecOverrideChange(d) {
+		log.Println("[TRACE] PrometheusRule.Spec has changes")
+		spec, err := expandPrometheusRuleSpec(d.Get("spec").([]interface{}))
+		if err != nil {
+			return nil, err
+		}
+		if err := injectNamespaceLabel(d, spec.Groups); err != nil {
+			return nil, err
+		}
+		merged, err := mergedSpec(d, spec)
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, replace(merged))
+	}
+
+	data, err := ops.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to marshal update operations for PrometheusRule: %s", err)
+	}
+	return data, nil
+}
+
+func expandPrometheusRuleSpec(groups []interface{}) (*PrometheusRuleSpec, error) {
+	obj := &PrometheusRuleSpec{}
 	if len(groups) == 0 || groups[0] == nil {
 		return obj, nil
 	}
@@ -194,13 +376,14 @@ func expandPrometheusRuleSpec(groups []interface{}) (*po_types.PrometheusRuleSpe
 	return obj, nil
 }
 
-func flattenPrometheusRuleSpec(spec po_v1.PrometheusRuleSpec, d *schema.ResourceData) ([]interface{}, error) {
+func flattenPrometheusRuleSpec(spec PrometheusRuleSpec, d *schema.ResourceData) ([]interface{}, error) {
 	att := make(map[string]interface{})
 
 	groups, err := flattenRuleGroup(spec.Groups)
 	if err != nil {
 		return nil, err
 	}
+	configuredNamespacedExprs(d, groups)
 	att["groups"] = groups
 
 	return []interface{}{att}, nil
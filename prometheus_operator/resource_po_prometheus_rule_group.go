@@ -0,0 +1,352 @@
+package prometheus_operator
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	po_types "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	k8s "github.com/terraform-providers/terraform-provider-kubernetes"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+	restclient "k8s.io/client-go/rest"
+)
+
+// ruleGroupPatchAttempts is how many times a patch of a group is retried
+// when other groups are added or removed concurrently.
+const ruleGroupPatchAttempts = 5
+
+func resourcePOPrometheusRuleGroup() *schema.Resource {
+	s := RuleGroupSchema()
+	s["name"].ForceNew = true
+	s["name"].Description = "Name of the rule group, unique in the PrometheusRule."
+	s["namespace"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "Namespace of the PrometheusRule.",
+		Required:    true,
+		ForceNew:    true,
+	}
+	s["prometheus_rule"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "Name of an existing PrometheusRule, whose other groups are left alone. Groups added this way are removed by updates of a po_prometheus_rule managing the same object, unless it ignores changes of spec.",
+		Required:    true,
+		ForceNew:    true,
+	}
+
+	return &schema.Resource{
+		Create: resourcePOPrometheusRuleGroupCreate,
+		Read:   resourcePOPrometheusRuleGroupRead,
+		Exists: resourcePOPrometheusRuleGroupExists,
+		Update: resourcePOPrometheusRuleGroupUpdate,
+		Delete: resourcePOPrometheusRuleGroupDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: customdiff.All(
+			validateCRDSchemaDiff("PrometheusRule", prometheusRuleGroupObject),
+			ruleGroupNamespaceLabelDiff,
+		),
+
+		// There is no spec_override_json or spec_override_yaml, the group is
+		// one of the groups of a PrometheusRule whose spec is managed
+		// elsewhere.
+		Schema: s,
+	}
+}
+
+func buildRuleGroupId(namespace, rule, group string) string {
+	return namespace + "/" + rule + "/" + group
+}
+
+func ruleGroupIdParts(id string) (string, string, string, error) {
+	parts := strings.SplitN(id, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf("Unexpected ID format (%q), expected %q.", id, "namespace/prometheus_rule/group")
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+func resourcePOPrometheusRuleGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*KubeClientsets).MonitoringClient
+	namespace := d.Get("namespace").(string)
+	rule := d.Get("prometheus_rule").(string)
+
+	group, err := expandPrometheusRuleGroup(d)
+	if err != nil {
+		return err
+	}
+	if err := verifyRuleGroupNamespaceLabel(d, meta); err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Adding group %q to PrometheusRule %s/%s", group.Name, namespace, rule)
+	err = patchRuleGroups(conn.RESTClient(), namespace, rule, func(groups []RuleGroup) (k8s.PatchOperations, error) {
+		if i := ruleGroupIndex(groups, group.Name); i >= 0 {
+			return nil, fmt.Errorf("Group %q already exists in PrometheusRule %s/%s, import it to manage it", group.Name, namespace, rule)
+		}
+		return appendRuleGroupOperations(groups, group), nil
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to add group to PrometheusRule: %s", err)
+	}
+
+	d.SetId(buildRuleGroupId(namespace, rule, group.Name))
+
+	return resourcePOPrometheusRuleGroupRead(d, meta)
+}
+
+func resourcePOPrometheusRuleGroupExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	conn := meta.(*KubeClientsets).MonitoringClient
+	namespace, rule, name, err := ruleGroupIdParts(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	log.Printf("[INFO] Checking group %q of PrometheusRule %s/%s", name, namespace, rule)
+	obj, err := getPrometheusRule(conn.RESTClient(), namespace, rule)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return true, err
+	}
+	return ruleGroupIndex(obj.Spec.Groups, name) >= 0, nil
+}
+
+func resourcePOPrometheusRuleGroupRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*KubeClientsets).MonitoringClient
+	namespace, rule, name, err := ruleGroupIdParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading group %q of PrometheusRule %s/%s", name, namespace, rule)
+	obj, err := getPrometheusRule(conn.RESTClient(), namespace, rule)
+	if err != nil {
+		switch {
+		case errors.IsNotFound(err):
+			log.Printf("[DEBUG] PrometheusRule %q was not found in Namespace %q - removing group from state!", rule, namespace)
+			d.SetId("")
+			return nil
+		default:
+			log.Printf("[DEBUG] Error reading PrometheusRule: %#v", err)
+			return err
+		}
+	}
+	i := ruleGroupIndex(obj.Spec.Groups, name)
+	if i < 0 {
+		log.Printf("[DEBUG] Group %q was not found in PrometheusRule %s/%s - removing from state!", name, namespace, rule)
+		d.SetId("")
+		return nil
+	}
+	log.Printf("[INFO] Received group: %#v", obj.Spec.Groups[i])
+
+	groups, err := flattenRuleGroup(obj.Spec.Groups[i : i+1])
+	if err != nil {
+		return err
+	}
+	d.Set("namespace", namespace)
+	d.Set("prometheus_rule", rule)
+	for k, v := range groups[0].(map[string]interface{}) {
+		if err := d.Set(k, v); err != nil {
+			return fmt.Errorf("Error setting `%s`: %+v", k, err)
+		}
+	}
+	return nil
+}
+
+func resourcePOPrometheusRuleGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*KubeClientsets).MonitoringClient
+	namespace, rule, name, err := ruleGroupIdParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	group, err := expandPrometheusRuleGroup(d)
+	if err != nil {
+		return err
+	}
+	if err := verifyRuleGroupNamespaceLabel(d, meta); err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Updating group %q of PrometheusRule %s/%s", name, namespace, rule)
+	err = patchRuleGroups(conn.RESTClient(), namespace, rule, func(groups []RuleGroup) (k8s.PatchOperations, error) {
+		path, err := ruleGroupPath(groups, name)
+		if err != nil {
+			return nil, err
+		}
+		return k8s.PatchOperations{
+			&k8s.TestOperation{Path: path + "/name", Value: name},
+			&k8s.ReplaceOperation{Path: path, Value: group},
+		}, nil
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to update group of PrometheusRule: %s", err)
+	}
+
+	return resourcePOPrometheusRuleGroupRead(d, meta)
+}
+
+func resourcePOPrometheusRuleGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*KubeClientsets).MonitoringClient
+	namespace, rule, name, err := ruleGroupIdParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Removing group %q from PrometheusRule %s/%s", name, namespace, rule)
+	err = patchRuleGroups(conn.RESTClient(), namespace, rule, func(groups []RuleGroup) (k8s.PatchOperations, error) {
+		if ruleGroupIndex(groups, name) < 0 {
+			return nil, nil
+		}
+		path, err := ruleGroupPath(groups, name)
+		if err != nil {
+			return nil, err
+		}
+		return k8s.PatchOperations{
+			&k8s.TestOperation{Path: path + "/name", Value: name},
+			&k8s.RemoveOperation{Path: path},
+		}, nil
+	})
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("Failed to remove group from PrometheusRule: %s", err)
+	}
+
+	log.Printf("[INFO] Group %q removed from PrometheusRule %s/%s", name, namespace, rule)
+
+	d.SetId("")
+
+	return nil
+}
+
+func expandPrometheusRuleGroup(d resourceGetter) (*RuleGroup, error) {
+	groups, err := expandRuleGroup([]interface{}{map[string]interface{}{
+		"name":                      d.Get("name"),
+		"interval":                  d.Get("interval"),
+		"partial_response_strategy": d.Get("partial_response_strategy"),
+		"rules":                     d.Get("rules"),
+	}})
+	if err != nil {
+		return nil, err
+	}
+	return &groups[0], nil
+}
+
+// prometheusRuleGroupObject returns a PrometheusRule holding only the group,
+// which is validated against the CRD schema.
+func prometheusRuleGroupObject(d resourceGetter) ([]byte, error) {
+	group, err := expandPrometheusRuleGroup(d)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&PrometheusRule{
+		TypeMeta: metav1.TypeMeta{Kind: "PrometheusRule", APIVersion: po_types.SchemeGroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      d.Get("prometheus_rule").(string),
+			Namespace: d.Get("namespace").(string),
+		},
+		Spec: PrometheusRuleSpec{Groups: []RuleGroup{*group}},
+	})
+}
+
+func getPrometheusRule(client restclient.Interface, namespace, name string) (*PrometheusRule, error) {
+	data, err := client.Get().
+		Namespace(namespace).
+		Resource("prometheusrules").
+		Name(name).
+		Do().
+		Raw()
+	if err != nil {
+		return nil, err
+	}
+	out := &PrometheusRule{}
+	if err := json.Unmarshal(data, out); err != nil {
+		return nil, fmt.Errorf("Failed to decode PrometheusRule: %s", err)
+	}
+	return out, nil
+}
+
+func ruleGroupIndex(groups []RuleGroup, name string) int {
+	for i, g := range groups {
+		if g.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func ruleGroupPath(groups []RuleGroup, name string) (string, error) {
+	i := ruleGroupIndex(groups, name)
+	if i < 0 {
+		return "", fmt.Errorf("Group %q was not found", name)
+	}
+	return fmt.Sprintf("/spec/groups/%d", i), nil
+}
+
+// appendRuleGroupOperations returns the operations appending group to the
+// groups read. They test the last group first, or the absence of groups, so
+// the patch fails if a group was added or removed in between and is rebuilt,
+// checking duplicates again.
+func appendRuleGroupOperations(groups []RuleGroup, group *RuleGroup) k8s.PatchOperations {
+	if groups == nil {
+		return k8s.PatchOperations{
+			&k8s.TestOperation{Path: "/spec/groups", Value: nil},
+			&k8s.AddOperation{Path: "/spec/groups", Value: []RuleGroup{*group}},
+		}
+	}
+	if len(groups) == 0 {
+		return k8s.PatchOperations{
+			&k8s.TestOperation{Path: "/spec/groups", Value: []RuleGroup{}},
+			&k8s.AddOperation{Path: "/spec/groups/-", Value: group},
+		}
+	}
+	last := len(groups) - 1
+	return k8s.PatchOperations{
+		&k8s.TestOperation{Path: fmt.Sprintf("/spec/groups/%d/name", last), Value: groups[last].Name},
+		&k8s.AddOperation{Path: "/spec/groups/-", Value: group},
+	}
+}
+
+// patchRuleGroups reads the groups of the PrometheusRule and applies the
+// operations built from them. Operations addressing a group by index test
+// its name first, so the patch is rebuilt from the current groups if
+// another group was added or removed in between.
+func patchRuleGroups(client restclient.Interface, namespace, name string, build func([]RuleGroup) (k8s.PatchOperations, error)) error {
+	var err error
+	for attempt := 0; attempt < ruleGroupPatchAttempts; attempt++ {
+		obj, getErr := getPrometheusRule(client, namespace, name)
+		if getErr != nil {
+			return getErr
+		}
+		ops, buildErr := build(obj.Spec.Groups)
+		if buildErr != nil {
+			return buildErr
+		}
+		if len(ops) == 0 {
+			return nil
+		}
+		data, marshalErr := ops.MarshalJSON()
+		if marshalErr != nil {
+			return fmt.Errorf("Failed to marshal update operations for PrometheusRule: %s", marshalErr)
+		}
+		log.Printf("[INFO] Patching PrometheusRule %s/%s: %s", namespace, name, data)
+		err = client.Patch(pkgApi.JSONPatchType).
+			Namespace(namespace).
+			Resource("prometheusrules").
+			Name(name).
+			Body(data).
+			Do().
+			Error()
+		if err == nil || !(errors.IsInvalid(err) || errors.IsConflict(err)) {
+			return err
+		}
+		log.Printf("[DEBUG] Patch of PrometheusRule %s/%s failed, groups changed concurrently: %s", namespace, name, err)
+	}
+	return err
+}
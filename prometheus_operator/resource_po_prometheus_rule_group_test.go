@@ -0,0 +1,251 @@
+package prometheus_operator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	k8s "github.com/terraform-providers/terraform-provider-kubernetes"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+func TestAccPrometheusOperatorPrometheusRuleGroup_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	namespace := "monitoring"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccPrometheusOperatorPrometheusRuleGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPrometheusOperatorPrometheusRuleGroupConfig_basic(name, namespace, "none"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccPrometheusOperatorPrometheusRuleGroupExists("po_prometheus_rule_group.test"),
+					resource.TestCheckResourceAttr("po_prometheus_rule_group.test", "name", "team-a"),
+					resource.TestCheckResourceAttr("po_prometheus_rule_group.test", "rules.0.labels.severity", "none"),
+					testAccPrometheusOperatorPrometheusRuleGroupExists("po_prometheus_rule_group.other"),
+				),
+			},
+			{
+				Config: testAccPrometheusOperatorPrometheusRuleGroupConfig_basic(name, namespace, "warning"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("po_prometheus_rule_group.test", "rules.0.labels.severity", "warning"),
+					testAccPrometheusOperatorPrometheusRuleGroupExists("po_prometheus_rule_group.other"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPrometheusOperatorPrometheusRuleGroup_importBasic(t *testing.T) {
+	resourceName := "po_prometheus_rule_group.test"
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccPrometheusOperatorPrometheusRuleGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPrometheusOperatorPrometheusRuleGroupConfig_basic(name, "monitoring", "none"),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestPatchRuleGroups_retriesConcurrentChange(t *testing.T) {
+	gets, patches := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apis/monitoring.coreos.com/v1/namespaces/monitoring/prometheusrules/shared" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			gets++
+			groups := `[{"name":"team-a","rules":[]},{"name":"team-b","rules":[]}]`
+			if gets > 1 {
+				// team-a was removed concurrently.
+				groups = `[{"name":"team-b","rules":[]}]`
+			}
+			w.Write([]byte(`{"kind":"PrometheusRule","apiVersion":"monitoring.coreos.com/v1","metadata":{"name":"shared","namespace":"monitoring"},"spec":{"groups":` + groups + `}}`))
+		case http.MethodPatch:
+			patches++
+			body, _ := ioutil.ReadAll(r.Body)
+			ops := []map[string]interface{}{}
+			if err := json.Unmarshal(body, &ops); err != nil {
+				t.Error(err)
+			}
+			if patches == 1 {
+				if ops[0]["op"] != "test" || ops[0]["path"] != "/spec/groups/1/name" {
+					t.Errorf("Expected test of the group name, got %s", body)
+				}
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","message":"testing value /spec/groups/1/name failed","reason":"Invalid","code":422}`))
+				return
+			}
+			if ops[0]["path"] != "/spec/groups/0/name" || ops[1]["op"] != "remove" || ops[1]["path"] != "/spec/groups/0" {
+				t.Errorf("Expected patch rebuilt from current groups, got %s", body)
+			}
+			w.Write([]byte(`{"kind":"PrometheusRule","apiVersion":"monitoring.coreos.com/v1","metadata":{"name":"shared","namespace":"monitoring"}}`))
+		}
+	}))
+	defer server.Close()
+
+	m := testMonitoringClient(t, server, 0)
+	err := patchRuleGroups(m.RESTClient(), "monitoring", "shared", func(groups []RuleGroup) (k8s.PatchOperations, error) {
+		path, err := ruleGroupPath(groups, "team-b")
+		if err != nil {
+			return nil, err
+		}
+		return k8s.PatchOperations{
+			&k8s.TestOperation{Path: path + "/name", Value: "team-b"},
+			&k8s.RemoveOperation{Path: path},
+		}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gets != 2 || patches != 2 {
+		t.Errorf("Expected 2 reads and 2 patches, got %d and %d", gets, patches)
+	}
+}
+
+func TestAppendRuleGroupOperations(t *testing.T) {
+	group := &RuleGroup{Name: "team-c"}
+	cases := []struct {
+		groups   []RuleGroup
+		expected string
+	}{
+		{nil, `[{"path":"/spec/groups","value":null,"op":"test"},{"path":"/spec/groups","value":[{"name":"team-c","rules":null}],"op":"add"}]`},
+		{[]RuleGroup{}, `[{"path":"/spec/groups","value":[],"op":"test"},{"path":"/spec/groups/-","value":{"name":"team-c","rules":null},"op":"add"}]`},
+		{[]RuleGroup{{Name: "team-a"}, {Name: "team-b"}}, `[{"path":"/spec/groups/1/name","value":"team-b","op":"test"},{"path":"/spec/groups/-","value":{"name":"team-c","rules":null},"op":"add"}]`},
+	}
+	for _, c := range cases {
+		data, err := appendRuleGroupOperations(c.groups, group).MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != c.expected {
+			t.Errorf("Expected %s, got %s", c.expected, data)
+		}
+	}
+}
+
+func TestRuleGroupIdParts(t *testing.T) {
+	namespace, rule, group, err := ruleGroupIdParts(buildRuleGroupId("monitoring", "shared", "team/a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if namespace != "monitoring" || rule != "shared" || group != "team/a" {
+		t.Errorf("Unexpected ID parts %q, %q, %q", namespace, rule, group)
+	}
+	if _, _, _, err := ruleGroupIdParts("monitoring/shared"); err == nil {
+		t.Error("Expected error for ID without group")
+	}
+}
+
+func testAccPrometheusOperatorPrometheusRuleGroupConfig_basic(name, namespace, severity string) string {
+	return fmt.Sprintf(`
+resource "po_prometheus_rule" "shared" {
+  metadata {
+    name = "%[1]s"
+    namespace = "%[2]s"
+    labels = {
+      role = "alert-rules"
+    }
+  }
+  spec {}
+
+  lifecycle {
+    ignore_changes = [spec]
+  }
+}
+
+resource "po_prometheus_rule_group" "test" {
+  namespace = po_prometheus_rule.shared.metadata.0.namespace
+  prometheus_rule = po_prometheus_rule.shared.metadata.0.name
+  name = "team-a"
+  rules {
+    alert = "Watchdog"
+    expr = "vector(1)"
+    labels = {
+      severity = "%[3]s"
+    }
+  }
+}
+
+resource "po_prometheus_rule_group" "other" {
+  namespace = po_prometheus_rule.shared.metadata.0.namespace
+  prometheus_rule = po_prometheus_rule.shared.metadata.0.name
+  name = "team-b"
+  rules {
+    record = "job:up:sum"
+    expr = "sum by (job) (up)"
+  }
+}`, name, namespace, severity)
+}
+
+func testAccPrometheusOperatorPrometheusRuleGroupExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*KubeClientsets).MonitoringClient
+
+		namespace, rule, group, err := ruleGroupIdParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		obj, err := getPrometheusRule(conn.RESTClient(), namespace, rule)
+		if err != nil {
+			return err
+		}
+		if ruleGroupIndex(obj.Spec.Groups, group) < 0 {
+			return fmt.Errorf("Group %q not found in PrometheusRule %s/%s", group, namespace, rule)
+		}
+		return nil
+	}
+}
+
+func testAccPrometheusOperatorPrometheusRuleGroupDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*KubeClientsets).MonitoringClient
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "po_prometheus_rule_group" {
+			continue
+		}
+
+		namespace, rule, group, err := ruleGroupIdParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		obj, err := getPrometheusRule(conn.RESTClient(), namespace, rule)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if ruleGroupIndex(obj.Spec.Groups, group) >= 0 {
+			return fmt.Errorf("Group %q still exists in PrometheusRule %s/%s", group, namespace, rule)
+		}
+	}
+	return nil
+}
@@ -71,6 +71,37 @@ func TestAccPrometheusOperatorPrometheusRule_importBasic(t *testing.T) {
 }
 
 
+func TestAccPrometheusOperatorPrometheusRule_sharded(t *testing.T) {
+	var pr po_types.PrometheusRule
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	namespace := "monitoring"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccPrometheusOperatorPrometheusRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPrometheusOperatorPrometheusRuleConfig_sharded(name, namespace, 20),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("po_prometheus_rule.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttr("po_prometheus_rule.test", "spec.0.groups.#", "20"),
+					resource.TestCheckResourceAttr("po_prometheus_rule.test", "shards.0", name+"-0"),
+					resource.TestCheckResourceAttr("po_prometheus_rule.test", "shards.1", name+"-1"),
+					testAccPrometheusOperatorPrometheusRuleExists("po_prometheus_rule.test", &pr),
+				),
+			},
+			{
+				Config: testAccPrometheusOperatorPrometheusRuleConfig_sharded(name, namespace, 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("po_prometheus_rule.test", "spec.0.groups.#", "2"),
+					resource.TestCheckResourceAttr("po_prometheus_rule.test", "shards.#", "1"),
+				),
+			},
+		},
+	})
+}
+
 func testAccPrometheusOperatorPrometheusRuleConfig_basic(name, namespace string) string {
 	return fmt.Sprintf(`
 resource "po_prometheus_rule" "test" {
@@ -98,6 +129,32 @@ resource "po_prometheus_rule" "test" {
   }`, name, namespace)
 }
 
+func testAccPrometheusOperatorPrometheusRuleConfig_sharded(name, namespace string, groups int) string {
+	return fmt.Sprintf(`
+resource "po_prometheus_rule" "test" {
+  metadata {
+    name = "%s"
+    namespace = "%s"
+    labels = {
+      role = "alert-rules"
+    }
+  }
+  max_object_bytes = 2048
+  spec {
+    dynamic "groups" {
+      for_each = range(%d)
+      content {
+        name = "group-${groups.value}"
+        rules {
+          record = "job:up:sum${groups.value}"
+          expr = "sum by (job) (up)"
+        }
+      }
+    }
+  }
+}`, name, namespace, groups)
+}
+
 func testAccPrometheusOperatorPrometheusRuleExists(n string, obj *po_types.PrometheusRule) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -111,6 +168,9 @@ func testAccPrometheusOperatorPrometheusRuleExists(n string, obj *po_types.Prome
 		if err != nil {
 			return err
 		}
+		if shard, ok := rs.Primary.Attributes["shards.0"]; ok {
+			name = shard
+		}
 
 		out, err := conn.PrometheusRules(namespace).Get(name, meta_v1.GetOptions{})
 		if err != nil {
@@ -3,12 +3,14 @@ package prometheus_operator
 import (
 	"fmt"
 	po_types "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
-	po_v1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgApi "k8s.io/apimachinery/pkg/types"
 	"log"
+	"time"
 )
 
 func resourcePOServiceMonitor() *schema.Resource {
@@ -21,87 +23,65 @@ func resourcePOServiceMonitor() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: customdiff.All(
+			specOverrideDiff(serviceMonitorObject),
+			validateEndpointsDiff,
+			validateCRDSchemaDiff("ServiceMonitor", serviceMonitorObject),
+			validateRelabelConfigsDiff(serviceMonitorObject),
+			fanOutDiff(serviceMonitorObject),
+			validateReferencesDiff(serviceMonitorReferences),
+			dryRunDiff("servicemonitors", serviceMonitorObject, patchServiceMonitor),
+		),
 
-		Schema: map[string]*schema.Schema{
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: withFanOut(map[string]*schema.Schema{
 			"metadata": namespacedMetadataSchema("service monitor", true),
 			"spec": {
 				Type:        schema.TypeList,
 				Description: "Spec defines the specification of the desired behavior of the deployment. More info: https://github.com/coreos/prometheus-operator/blob/master/Documentation/api.md#servicemonitorspec",
 				Required:    true,
 				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: genServiceMonitorSpecSchema(),
+				},
+			},
+			"spec_override_json": specOverrideSchema("json"),
+			"spec_override_yaml": specOverrideSchema("yaml"),
+			"wait_for_targets": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Wait for the Prometheuses selecting the ServiceMonitor to scrape its targets after it's created or updated. Every replica of the Prometheuses is queried through the proxy of its pod.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
-						"job_label": {
-							Type:        schema.TypeString,
-							Description: "The label to use to retrieve the job name from. More info: https://github.com/coreos/prometheus-operator/blob/master/Documentation/api.md#servicemonitorspec",
-							Optional:    true,
-						},
-						"target_labels": {
-							Type:        schema.TypeList,
-							Optional:    true,
-							Elem:        &schema.Schema{Type: schema.TypeString},
-							Description: "TargetLabels transfers labels on the Kubernetes Service onto the target. More info: https://github.com/coreos/prometheus-operator/blob/master/Documentation/api.md#servicemonitorspec",
-						},
-						"pod_target_labels": {
-							Type:        schema.TypeList,
-							Optional:    true,
-							Elem:        &schema.Schema{Type: schema.TypeString},
-							Description: "PodTargetLabels transfers labels on the Kubernetes Pod onto the target. More info: https://github.com/coreos/prometheus-operator/blob/master/Documentation/api.md#servicemonitorspec",
-						},
-						"endpoints": {
-							Type:        schema.TypeList,
-							Optional:    true,
-							Description: "A list of endpoints allowed as part of this ServiceMonitor. More info: https://github.com/coreos/prometheus-operator/blob/master/Documentation/api.md#servicemonitorspec",
-							Elem: &schema.Resource{
-								Schema: EndpointSchema(),
-							},
-						},
-						"selector": {
-							Type:        schema.TypeList,
-							Optional:    true,
-							Description: "Selector to select Endpoints objects.",
-							MaxItems:    1,
-							Elem: &schema.Resource{
-								Schema: labelSelectorFields(true),
-							},
-						},
-						"namespace_selector": {
-							Type:        schema.TypeList,
-							Optional:    true,
-							Description: "Selector to select which namespaces the Endpoints objects are discovered from.",
-							MaxItems:    1,
-							Elem: &schema.Resource{
-								Schema: NamespaceSelectorSchema(),
-							},
-						},
-						"sample_limit": {
-							Type:        schema.TypeInt,
-							Description: "SampleLimit defines per-scrape limit on number of scraped samples that will be accepted. More info: https://github.com/coreos/prometheus-operator/blob/master/Documentation/api.md#servicemonitorspec",
-							Optional:    true,
+						"min_up": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							Description:  "Number of targets of the jobs of the ServiceMonitor which must be up in every selecting Prometheus.",
+							ValidateFunc: validation.IntAtLeast(1),
 						},
 					},
 				},
 			},
-		},
+		}),
 	}
 }
 
 func resourcePOServiceMonitorCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*KubeClientsets).MonitoringClient
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
 
-	spec, err := expandServiceMonitorSpec(d.Get("spec").([]interface{}))
+	body, err := serviceMonitorObject(d)
 	if err != nil {
 		return err
 	}
 
-	monitor := po_types.ServiceMonitor{
-		ObjectMeta: metadata,
-		Spec:       *spec,
-	}
-
-	log.Printf("[INFO] Creating ServiceMonitor custom resource: %#v", monitor)
-	out, err := conn.ServiceMonitors(metadata.Namespace).Create(&monitor)
+	log.Printf("[INFO] Creating ServiceMonitor custom resource: %s", body)
+	out := &po_types.ServiceMonitor{}
+	err = createObject(conn.RESTClient(), "servicemonitors", d.Get("metadata.0.namespace").(string), body, out)
 	if err != nil {
 		return fmt.Errorf("Failed to create ServiceMonitor: %s", err)
 	}
@@ -110,6 +90,12 @@ func resourcePOServiceMonitorCreate(d *schema.ResourceData, meta interface{}) er
 
 	d.SetId(buildId(out.ObjectMeta))
 
+	if err := applyFanOut(d, meta, "servicemonitors", serviceMonitorObject); err != nil {
+		return err
+	}
+	if err := waitForTargets(d, meta, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return err
+	}
 	return resourcePOServiceMonitorRead(d, meta)
 }
 
@@ -139,7 +125,8 @@ func resourcePOServiceMonitorRead(d *schema.ResourceData, meta interface{}) erro
 	}
 
 	log.Printf("[INFO] Reading ServiceMonitor custom resource %s", name)
-	am, err := conn.ServiceMonitors(namespace).Get(name, metav1.GetOptions{})
+	am := &po_types.ServiceMonitor{}
+	err = readObject(d, conn.RESTClient(), "servicemonitors", namespace, name, am)
 	if err != nil {
 		switch {
 		case errors.IsNotFound(err):
@@ -156,13 +143,13 @@ func resourcePOServiceMonitorRead(d *schema.ResourceData, meta interface{}) erro
 	if d.Set("metadata", flattenMetadata(am.ObjectMeta, d)) != nil {
 		return fmt.Errorf("Error setting `metadata`: %+v", err)
 	}
-	spec, err := flattenServiceMonitorSpec(am.Spec, d)
+	spec, err := genFlattenServiceMonitorSpec(am.Spec)
 
 	d.Set("spec", spec)
 	if err != nil {
 		return fmt.Errorf("Failed to set ServiceMonitor spec: %s", err)
 	}
-	return nil
+	return readFanOut(d, meta, "servicemonitors")
 }
 
 func resourcePOServiceMonitorUpdate(d *schema.ResourceData, meta interface{}) error {
@@ -171,20 +158,10 @@ func resourcePOServiceMonitorUpdate(d *schema.ResourceData, meta interface{}) er
 	if err != nil {
 		return err
 	}
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
 
-	if d.HasChange("spec") {
-		log.Println("[TRACE] ServiceMonitor.Spec has changes")
-		spec, err := expandServiceMonitorSpec(d.Get("spec").([]interface{}))
-		if err != nil {
-			return err
-		}
-		ops = append(ops, replace(spec))
-	}
-
-	data, err := ops.MarshalJSON()
+	data, err := patchServiceMonitor(d)
 	if err != nil {
-		return fmt.Errorf("Failed to marshal update operations for ServiceMonitor: %s", err)
+		return err
 	}
 	log.Printf("[INFO] Updating ServiceMonitor %q: %v", name, string(data))
 	out, err := conn.ServiceMonitors(namespace).Patch(name, pkgApi.JSONPatchType, data)
@@ -193,6 +170,12 @@ func resourcePOServiceMonitorUpdate(d *schema.ResourceData, meta interface{}) er
 	}
 	log.Printf("[INFO] Submitted updated ServiceMonitor: %#v", out)
 
+	if err := applyFanOut(d, meta, "servicemonitors", serviceMonitorObject); err != nil {
+		return err
+	}
+	if err := waitForTargets(d, meta, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return err
+	}
 	return resourcePOServiceMonitorRead(d, meta)
 }
 
@@ -203,6 +186,10 @@ func resourcePOServiceMonitorDelete(d *schema.ResourceData, meta interface{}) er
 		return err
 	}
 
+	if err := deleteFanOut(d, meta, "servicemonitors"); err != nil {
+		return err
+	}
+
 	log.Printf("[INFO] Deleting ServiceMonitor: %q", name)
 	err = conn.ServiceMonitors(namespace).Delete(name, &metav1.DeleteOptions{})
 	if err != nil {
@@ -216,63 +203,56 @@ func resourcePOServiceMonitorDelete(d *schema.ResourceData, meta interface{}) er
 	return nil
 }
 
-func expandServiceMonitorSpec(sm []interface{}) (*po_types.ServiceMonitorSpec, error) {
-	obj := &po_types.ServiceMonitorSpec{}
-	if len(sm) == 0 || sm[0] == nil {
-		return obj, nil
+func buildServiceMonitor(d resourceGetter) (*po_types.ServiceMonitor, error) {
+	spec, err := expandServiceMonitorSpec(d.Get("spec").([]interface{}))
+	if err != nil {
+		return nil, err
 	}
-	in := sm[0].(map[string]interface{})
 
-	obj.JobLabel = in["job_label"].(string)
-	obj.SampleLimit = uint64(in["sample_limit"].(int))
-	if tl, ok := in["target_labels"].([]interface{}); ok {
-		obj.TargetLabels = expandStringSlice(tl)
-	}
-	if ptl, ok := in["pod_target_labels"].([]interface{}); ok {
-		obj.PodTargetLabels = expandStringSlice(ptl)
+	return &po_types.ServiceMonitor{
+		TypeMeta:   metav1.TypeMeta{Kind: "ServiceMonitor", APIVersion: po_types.SchemeGroupVersion.String()},
+		ObjectMeta: expandMetadata(d.Get("metadata").([]interface{})),
+		Spec:       *spec,
+	}, nil
+}
+
+func serviceMonitorObject(d resourceGetter) ([]byte, error) {
+	obj, err := buildServiceMonitor(d)
+	if err != nil {
+		return nil, err
 	}
-	if v, ok := in["endpoints"].([]interface{}); ok && len(v) > 0 {
-		endpoints, err := expandEndpoints(v)
+	return objectWithSpecOverride(d, obj)
+}
+
+func patchServiceMonitor(d resourceGetter) ([]byte, error) {
+	ops := patchMetadata("metadata.0.", "/metadata/", d)
+
+	if d.HasChange("spec") || hasSpecOverrideChange(d) {
+		log.Println("[TRACE] ServiceMonitor.Spec has changes")
+		spec, err := expandServiceMonitorSpec(d.Get("spec").([]interface{}))
 		if err != nil {
-			return obj, err
+			return nil, err
 		}
-		obj.Endpoints = endpoints
-	}
-	if s, ok := in["selector"].([]interface{}); ok && len(s) > 0 {
-		selector := expandLabelSelector(s)
-		obj.Selector = *selector
-	}
-	if ns, ok := in["namespace_selector"].([]interface{}); ok && len(ns) > 0 {
-		selector, err := expandNamespaceSelector(ns)
+		merged, err := mergedSpec(d, spec)
 		if err != nil {
-			return obj, err
+			return nil, err
 		}
-		obj.NamespaceSelector = *selector
+		ops = append(ops, replace(merged))
 	}
-	return obj, nil
-}
-
-func flattenServiceMonitorSpec(spec po_v1.ServiceMonitorSpec, d *schema.ResourceData) ([]interface{}, error) {
-	att := make(map[string]interface{})
 
-	if spec.JobLabel != "" {
-		att["job_label"] = spec.JobLabel
-	}
-	if len(spec.TargetLabels) > 0 {
-		att["target_labels"] = spec.TargetLabels
-	}
-	if len(spec.PodTargetLabels) > 0 {
-		att["pod_target_labels"] = spec.PodTargetLabels
-	}
-	att["sample_limit"] = int(spec.SampleLimit)
-
-	endpoints, err := flattenEndpoints(spec.Endpoints)
+	data, err := ops.MarshalJSON()
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("Failed to marshal update operations for ServiceMonitor: %s", err)
 	}
-	att["endpoints"] = endpoints
-	att["selector"] = flattenLabelSelector(&spec.Selector)
-	att["namespace_selector"] = flattenNamespaceSelector(&spec.NamespaceSelector)
+	return data, nil
+}
 
-	return []interface{}{att}, nil
+func expandServiceMonitorSpec(sm []interface{}) (*po_types.ServiceMonitorSpec, error) {
+	if len(sm) > 0 && sm[0] != nil {
+		endpoints, _ := sm[0].(map[string]interface{})["endpoints"].([]interface{})
+		if err := validateEndpoints(endpoints); err != nil {
+			return &po_types.ServiceMonitorSpec{}, err
+		}
+	}
+	return genExpandServiceMonitorSpec(sm)
 }
@@ -69,6 +69,33 @@ func TestAccPrometheusOperatorServiceMonitor_importBasic(t *testing.T) {
 }
 
 
+func TestAccPrometheusOperatorServiceMonitor_fanOut(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccPrometheusOperatorServiceMonitorFanOutDestroy(name, "default", "kube-public"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPrometheusOperatorServiceMonitorConfig_fanOut(name, `["default", "kube-public"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("po_service_monitor.test", "target_namespace_status.%", "2"),
+					resource.TestCheckResourceAttrSet("po_service_monitor.test", "target_namespace_status.default"),
+					resource.TestCheckResourceAttrSet("po_service_monitor.test", "target_namespace_status.kube-public"),
+				),
+			},
+			{
+				Config: testAccPrometheusOperatorServiceMonitorConfig_fanOut(name, `["default"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("po_service_monitor.test", "target_namespace_status.%", "1"),
+					testAccPrometheusOperatorServiceMonitorFanOutDestroy(name, "kube-public"),
+				),
+			},
+		},
+	})
+}
+
 func testAccPrometheusOperatorServiceMonitorConfig_basic(name, namespace string) string {
 	return fmt.Sprintf(`
 resource "po_service_monitor" "test" {
@@ -97,6 +124,41 @@ resource "po_service_monitor" "test" {
 }`, name, namespace)
 }
 
+func testAccPrometheusOperatorServiceMonitorConfig_fanOut(name, namespaces string) string {
+	return fmt.Sprintf(`
+resource "po_service_monitor" "test" {
+  metadata {
+    name = "%s"
+    namespace = "monitoring"
+  }
+  target_namespaces = %s
+  spec {
+    endpoints {
+      port = "http-metrics"
+    }
+    selector {
+      match_labels = {
+        "k8s-app" = "tenant-app"
+      }
+    }
+  }
+}`, name, namespaces)
+}
+
+func testAccPrometheusOperatorServiceMonitorFanOutDestroy(name string, namespaces ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*KubeClientsets).MonitoringClient
+
+		for _, ns := range namespaces {
+			_, err := conn.ServiceMonitors(ns).Get(name, meta_v1.GetOptions{})
+			if err == nil {
+				return fmt.Errorf("Copy of ServiceMonitor still exists in namespace %s", ns)
+			}
+		}
+		return nil
+	}
+}
+
 func testAccPrometheusOperatorServiceMonitorExists(n string, obj *po_types.ServiceMonitor) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -0,0 +1,338 @@
+package prometheus_operator
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	po_types "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+)
+
+func resourcePOSLO() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePOSLOCreate,
+		Read:   resourcePOSLORead,
+		Exists: resourcePOSLOExists,
+		Update: resourcePOSLOUpdate,
+		Delete: resourcePOSLODelete,
+		// The spec isn't stored in the PrometheusRule, imported SLOs take it
+		// from the configuration and update the rules on the next apply if
+		// they differ.
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: customdiff.All(
+			sloRulesDiff,
+			validateCRDSchemaDiff("PrometheusRule", sloObject),
+			dryRunDiff("prometheusrules", sloObject, patchSLO),
+		),
+
+		// There is no spec_override_json or spec_override_yaml, the spec of the
+		// generated PrometheusRule only holds the generated groups.
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("prometheus rule", false),
+			"spec": {
+				Type:        schema.TypeList,
+				Description: "Service level objective, from which recording rules and multi-window, multi-burn-rate alerts of the SRE workbook are generated into a PrometheusRule.",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"objective": {
+							Type:         schema.TypeFloat,
+							Description:  "Percentage of good events in the time window, e.g. 99.9.",
+							Required:     true,
+							ValidateFunc: validateSLOObjective,
+						},
+						"time_window": {
+							Type:         schema.TypeString,
+							Description:  "Time window of the objective, e.g. 30d or 4w.",
+							Optional:     true,
+							Default:      "30d",
+							ValidateFunc: validateSLOTimeWindow,
+						},
+						"sli": {
+							Type:        schema.TypeList,
+							Description: "Service level indicator, as PromQL queries using {{.window}} as the range of their range vectors.",
+							Required:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"error_query": {
+										Type:         schema.TypeString,
+										Description:  "Query of the rate of bad events, e.g. sum(rate(http_requests_total{code=~\"5..\"}[{{.window}}])).",
+										Optional:     true,
+										ExactlyOneOf: []string{"spec.0.sli.0.error_query", "spec.0.sli.0.good_query"},
+										ValidateFunc: validateSLIQuery,
+									},
+									"good_query": {
+										Type:         schema.TypeString,
+										Description:  "Query of the rate of good events.",
+										Optional:     true,
+										ValidateFunc: validateSLIQuery,
+									},
+									"total_query": {
+										Type:         schema.TypeString,
+										Description:  "Query of the rate of all events.",
+										Required:     true,
+										ValidateFunc: validateSLIQuery,
+									},
+								},
+							},
+						},
+						"alerting": {
+							Type:        schema.TypeList,
+							Description: "Page and ticket alerts on the error budget burn rate. No alerts are generated if not set.",
+							Optional:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Description: "Name of the alerts.",
+										Required:    true,
+									},
+									"labels": {
+										Type:         schema.TypeMap,
+										Description:  "Labels added to the alerts.",
+										Optional:     true,
+										Elem:         &schema.Schema{Type: schema.TypeString},
+										ValidateFunc: validateLabels,
+									},
+									"annotations": {
+										Type:        schema.TypeMap,
+										Description: "Annotations added to the alerts.",
+										Optional:    true,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+									},
+									"page_severity": {
+										Type:        schema.TypeString,
+										Description: "Value of the severity label of the alert on fast burn rates.",
+										Optional:    true,
+										Default:     "page",
+									},
+									"ticket_severity": {
+										Type:        schema.TypeString,
+										Description: "Value of the severity label of the alert on slow burn rates.",
+										Optional:    true,
+										Default:     "ticket",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"rules_yaml": {
+				Type:        schema.TypeString,
+				Description: "Rule groups of the generated PrometheusRule.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourcePOSLOCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*KubeClientsets).MonitoringClient
+
+	body, err := sloObject(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Creating PrometheusRule of SLO: %s", body)
+	out := &po_types.PrometheusRule{}
+	err = createObject(conn.RESTClient(), "prometheusrules", d.Get("metadata.0.namespace").(string), body, out)
+	if err != nil {
+		return fmt.Errorf("Failed to create PrometheusRule of SLO: %s", err)
+	}
+
+	log.Printf("[INFO] Submitted new PrometheusRule of SLO: %#v", out)
+
+	d.SetId(buildId(out.ObjectMeta))
+
+	return resourcePOSLORead(d, meta)
+}
+
+func resourcePOSLOExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	conn := meta.(*KubeClientsets).MonitoringClient
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	log.Printf("[INFO] Checking PrometheusRule of SLO %s", name)
+	_, err = conn.PrometheusRules(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+	}
+	return true, err
+}
+
+func resourcePOSLORead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*KubeClientsets).MonitoringClient
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading PrometheusRule of SLO %s", name)
+	data, err := conn.RESTClient().Get().
+		Namespace(namespace).
+		Resource("prometheusrules").
+		Name(name).
+		Do().
+		Raw()
+	if err != nil {
+		switch {
+		case errors.IsNotFound(err):
+			log.Printf("[DEBUG] PrometheusRule %q was not found in Namespace %q - removing from state!", name, namespace)
+			d.SetId("")
+			return nil
+		default:
+			log.Printf("[DEBUG] Error reading PrometheusRule: %#v", err)
+			return err
+		}
+	}
+	rule := &PrometheusRule{}
+	if err := json.Unmarshal(data, rule); err != nil {
+		return fmt.Errorf("Failed to decode PrometheusRule of SLO: %s", err)
+	}
+	log.Printf("[INFO] Received PrometheusRule of SLO: %#v", rule)
+
+	if err := d.Set("metadata", flattenMetadata(rule.ObjectMeta, d)); err != nil {
+		return fmt.Errorf("Error setting `metadata`: %+v", err)
+	}
+	rules, err := sloRulesYAML(rule.Spec)
+	if err != nil {
+		return err
+	}
+	d.Set("rules_yaml", rules)
+	return nil
+}
+
+func resourcePOSLOUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*KubeClientsets).MonitoringClient
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	data, err := patchSLO(d)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Updating PrometheusRule of SLO %q: %v", name, string(data))
+	out, err := conn.PrometheusRules(namespace).Patch(name, pkgApi.JSONPatchType, data)
+	if err != nil {
+		return fmt.Errorf("Failed to update PrometheusRule of SLO: %s", err)
+	}
+	log.Printf("[INFO] Submitted updated PrometheusRule of SLO: %#v", out)
+
+	return resourcePOSLORead(d, meta)
+}
+
+func resourcePOSLODelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*KubeClientsets).MonitoringClient
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting PrometheusRule of SLO: %q", name)
+	err = conn.PrometheusRules(namespace).Delete(name, &metav1.DeleteOptions{})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] PrometheusRule %s deleted", name)
+
+	d.SetId("")
+
+	return nil
+}
+
+// sloRulesDiff plans the generated rules, so changes made to the
+// PrometheusRule outside of Terraform show up as a diff of rules_yaml.
+func sloRulesDiff(d *schema.ResourceDiff, meta interface{}) error {
+	for _, k := range append(d.GetChangedKeysPrefix("spec"), "metadata.0.name") {
+		if !d.NewValueKnown(k) {
+			return d.SetNewComputed("rules_yaml")
+		}
+	}
+	rule, err := buildSLORule(d)
+	if err != nil {
+		return err
+	}
+	rules, err := sloRulesYAML(rule.Spec)
+	if err != nil {
+		return err
+	}
+	if d.Get("rules_yaml").(string) != rules {
+		return d.SetNew("rules_yaml", rules)
+	}
+	return nil
+}
+
+func sloRulesYAML(spec PrometheusRuleSpec) (string, error) {
+	data, err := yaml.Marshal(spec.Groups)
+	if err != nil {
+		return "", fmt.Errorf("Failed to marshal rule groups of SLO: %s", err)
+	}
+	return string(data), nil
+}
+
+func buildSLORule(d resourceGetter) (*PrometheusRule, error) {
+	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	slo := expandSLOSpec(metadata.Name, d.Get("spec").([]interface{}))
+	groups, err := slo.ruleGroups()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to generate rules of SLO %q: %s", metadata.Name, err)
+	}
+	g, err := expandRuleGroup(groups)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PrometheusRule{
+		TypeMeta:   metav1.TypeMeta{Kind: "PrometheusRule", APIVersion: po_types.SchemeGroupVersion.String()},
+		ObjectMeta: metadata,
+		Spec:       PrometheusRuleSpec{Groups: g},
+	}, nil
+}
+
+func sloObject(d resourceGetter) ([]byte, error) {
+	obj, err := buildSLORule(d)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(obj)
+}
+
+func patchSLO(d resourceGetter) ([]byte, error) {
+	ops := patchMetadata("metadata.0.", "/metadata/", d)
+
+	if d.HasChange("spec") || d.HasChange("rules_yaml") {
+		log.Println("[TRACE] SLO rules have changes")
+		rule, err := buildSLORule(d)
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, replace(rule.Spec))
+	}
+
+	data, err := ops.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to marshal update operations for SLO: %s", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,213 @@
+package prometheus_operator
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	po_types "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAccPrometheusOperatorSLO_basic(t *testing.T) {
+	var pr po_types.PrometheusRule
+	name := fmt.Sprintf("tf-acc-test-%s", strings.ToLower(acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)))
+	namespace := "monitoring"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccPrometheusOperatorSLODestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPrometheusOperatorSLOConfig_basic(name, namespace),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccPrometheusOperatorPrometheusRuleExists("po_slo.test", &pr),
+					resource.TestCheckResourceAttr("po_slo.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttr("po_slo.test", "metadata.0.namespace", namespace),
+					resource.TestCheckResourceAttrSet("po_slo.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttr("po_slo.test", "spec.0.time_window", "30d"),
+					resource.TestCheckResourceAttrSet("po_slo.test", "rules_yaml"),
+					func(*terraform.State) error {
+						if len(pr.Spec.Groups) != 2 || len(pr.Spec.Groups[0].Rules) != len(sloWindows)+3 {
+							return fmt.Errorf("Unexpected rule groups %#v", pr.Spec.Groups)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:            "po_slo.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"spec"},
+			},
+		},
+	})
+}
+
+func TestBuildSLORule(t *testing.T) {
+	raw := map[string]interface{}{
+		"metadata": []interface{}{map[string]interface{}{
+			"name":      "api",
+			"namespace": "monitoring",
+		}},
+		"spec": []interface{}{map[string]interface{}{
+			"objective": 99.9,
+			"sli": []interface{}{map[string]interface{}{
+				"error_query": `sum(rate(http_requests_total{code=~"5.."}[{{.window}}]))`,
+				"total_query": `sum(rate(http_requests_total[{{.window}}]))`,
+			}},
+			"alerting": []interface{}{map[string]interface{}{
+				"name":        "APIErrorBudgetBurn",
+				"labels":      map[string]interface{}{"team": "api"},
+				"annotations": map[string]interface{}{"summary": "API is burning its error budget"},
+			}},
+		}},
+	}
+	d := schema.TestResourceDataRaw(t, resourcePOSLO().Schema, raw)
+
+	rule, err := buildSLORule(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rule.Spec.Groups) != 2 {
+		t.Fatalf("Expected recordings and alerts groups, got %#v", rule.Spec.Groups)
+	}
+	recordings := rule.Spec.Groups[0].Rules
+	records := make([]string, len(recordings))
+	for i, r := range recordings {
+		records[i] = r.Record
+		if r.Labels["slo"] != "api" {
+			t.Errorf("Expected slo label on %s, got %v", r.Record, r.Labels)
+		}
+	}
+	expected := []string{
+		"slo:sli_error:ratio_rate5m", "slo:sli_error:ratio_rate30m", "slo:sli_error:ratio_rate1h",
+		"slo:sli_error:ratio_rate2h", "slo:sli_error:ratio_rate6h", "slo:sli_error:ratio_rate1d",
+		"slo:sli_error:ratio_rate3d", "slo:sli_error:ratio_rate30d",
+		"slo:objective:ratio", "slo:error_budget:ratio",
+	}
+	if strings.Join(records, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected records %v, got %v", expected, records)
+	}
+	if e := recordings[0].Expr.String(); !strings.Contains(e, `[5m]`) || strings.Contains(e, sloWindowPlaceholder) {
+		t.Errorf("Expected window to be substituted, got %s", e)
+	}
+	if e := recordings[9].Expr.String(); e != "0.001" {
+		t.Errorf("Expected error budget 0.001, got %s", e)
+	}
+
+	raw["spec"].([]interface{})[0].(map[string]interface{})["time_window"] = "28d"
+	weeks, err := buildSLORule(schema.TestResourceDataRaw(t, resourcePOSLO().Schema, raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := weeks.Spec.Groups[0].Rules[7]; r.Record != "slo:sli_error:ratio_rate28d" || !strings.Contains(r.Expr.String(), "[28d]") {
+		t.Errorf("Expected the time window as configured, got %s: %s", r.Record, r.Expr.String())
+	}
+
+	alerts := rule.Spec.Groups[1].Rules
+	if len(alerts) != 2 {
+		t.Fatalf("Expected page and ticket alerts, got %#v", alerts)
+	}
+	for i, c := range []struct {
+		severity   string
+		burnRates  []string
+		windowPair []string
+	}{
+		{"page", []string{"(14.4 * 0.001)", "(6 * 0.001)"}, []string{"ratio_rate5m", "ratio_rate1h", "ratio_rate30m", "ratio_rate6h"}},
+		{"ticket", []string{"(3 * 0.001)", "(1 * 0.001)"}, []string{"ratio_rate2h", "ratio_rate1d", "ratio_rate6h", "ratio_rate3d"}},
+	} {
+		a := alerts[i]
+		if a.Alert != "APIErrorBudgetBurn" || a.Labels["severity"] != c.severity || a.Labels["team"] != "api" {
+			t.Errorf("Unexpected alert %#v", a)
+		}
+		expr := a.Expr.String()
+		for _, s := range append(c.burnRates, c.windowPair...) {
+			if !strings.Contains(expr, s) {
+				t.Errorf("Expected %s alert to contain %q, got %s", c.severity, s, expr)
+			}
+		}
+	}
+}
+
+func TestValidateSLIQuery(t *testing.T) {
+	cases := []struct {
+		query string
+		valid bool
+	}{
+		{`sum(rate(http_requests_total[{{.window}}]))`, true},
+		{`sum(rate(http_requests_total[5m]))`, false},
+		{`sum(rate(http_requests_total[{{.window}}])`, false},
+		{`http_requests_total[{{.window}}]`, false},
+	}
+	for _, c := range cases {
+		_, es := validateSLIQuery(c.query, "error_query")
+		if c.valid && len(es) > 0 {
+			t.Errorf("Expected %q to be valid, got %v", c.query, es)
+		}
+		if !c.valid && len(es) == 0 {
+			t.Errorf("Expected %q to be invalid", c.query)
+		}
+	}
+
+	if _, es := validateSLOTimeWindow("1d", "time_window"); len(es) == 0 {
+		t.Error("Expected time window shorter than 7d to be invalid")
+	}
+	if _, es := validateSLOObjective(100.0, "objective"); len(es) == 0 {
+		t.Error("Expected objective of 100 to be invalid")
+	}
+}
+
+func testAccPrometheusOperatorSLOConfig_basic(name, namespace string) string {
+	return fmt.Sprintf(`
+resource "po_slo" "test" {
+  metadata {
+    name = "%[1]s"
+    namespace = "%[2]s"
+    labels = {
+      prometheus = "k8s"
+      role = "alert-rules"
+    }
+  }
+  spec {
+    objective = 99.9
+    sli {
+      error_query = "sum(rate(prometheus_http_requests_total{code=~\"5..\"}[{{.window}}]))"
+      total_query = "sum(rate(prometheus_http_requests_total[{{.window}}]))"
+    }
+    alerting {
+      name = "PrometheusErrorBudgetBurn"
+      annotations = {
+        summary = "Prometheus HTTP API is burning its error budget"
+      }
+    }
+  }
+}`, name, namespace)
+}
+
+func testAccPrometheusOperatorSLODestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*KubeClientsets).MonitoringClient
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "po_slo" {
+			continue
+		}
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = conn.PrometheusRules(namespace).Get(name, meta_v1.GetOptions{})
+		if err == nil {
+			return fmt.Errorf("PrometheusRule of SLO still exists: %s", rs.Primary.ID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,500 @@
+package prometheus_operator
+
+import (
+	"fmt"
+	"log"
+
+	po_types "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+)
+
+func resourcePOThanosRuler() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePOThanosRulerCreate,
+		Read:   resourcePOThanosRulerRead,
+		Exists: resourcePOThanosRulerExists,
+		Update: resourcePOThanosRulerUpdate,
+		Delete: resourcePOThanosRulerDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		// Operator v0.34.0 ships no ThanosRuler CRD, so there is no schema to validate against.
+		CustomizeDiff: customdiff.All(
+			specOverrideDiff(thanosRulerObject),
+			validateReferencesDiff(thanosRulerReferences),
+			dryRunDiff("thanosrulers", thanosRulerObject, patchThanosRuler),
+		),
+
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("thanos ruler", true),
+			"spec": {
+				Type:        schema.TypeList,
+				Description: "Specification of the desired behavior of the Thanos Ruler.",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: withPodTemplate("Thanos Ruler", map[string]*schema.Schema{
+						"image": {
+							Type:        schema.TypeString,
+							Description: "Thanos container image URL.",
+							Optional:    true,
+						},
+						"replicas": {
+							Type:         schema.TypeInt,
+							Description:  "Number of Thanos Ruler instances to deploy.",
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"service_account_name": {
+							Type:        schema.TypeString,
+							Description: "ServiceAccountName is the name of the ServiceAccount to use to run the Thanos Ruler Pods.",
+							Optional:    true,
+						},
+						"priority_class_name": {
+							Type:        schema.TypeString,
+							Description: "Priority class assigned to the Thanos Ruler Pods.",
+							Optional:    true,
+						},
+						"listen_local": {
+							Type:        schema.TypeBool,
+							Description: "ListenLocal makes the Thanos Ruler listen on loopback, so that it does not bind against the Pod IP.",
+							Optional:    true,
+							Default:     false,
+						},
+						"port_name": {
+							Type:        schema.TypeString,
+							Description: "Port name used for the pods and governing service. Defaults to web.",
+							Optional:    true,
+						},
+						"log_level": {
+							Type:         schema.TypeString,
+							Description:  "Log level for Thanos Ruler to be configured with.",
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"debug", "info", "warn", "error"}, false),
+						},
+						"log_format": {
+							Type:         schema.TypeString,
+							Description:  "Log format for Thanos Ruler to be configured with.",
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"logfmt", "json"}, false),
+						},
+						"evaluation_interval": {
+							Type:        schema.TypeString,
+							Description: "Interval between consecutive evaluations. Defaults to 15s.",
+							Optional:    true,
+						},
+						"retention": {
+							Type:        schema.TypeString,
+							Description: "Time duration Thanos Ruler shall retain data for. Default is '24h', and must match the regular expression [0-9]+(ms|s|m|h|d|w|y) (milliseconds seconds minutes hours days weeks years).",
+							Optional:    true,
+						},
+						"query_endpoints": {
+							Type:        schema.TypeList,
+							Description: "Thanos Query endpoints from which to query metrics, e.g. dnssrv+_http._tcp.thanos-querier.monitoring.svc.cluster.local.",
+							Required:    true,
+							MinItems:    1,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"alertmanagers_url": {
+							Type:        schema.TypeList,
+							Description: "URLs of Alertmanagers to send alerts to, e.g. dnssrv+http://alertmanager-operated.monitoring.svc.cluster.local:9093.",
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"object_storage_config": {
+							Type:        schema.TypeList,
+							Description: "Secret key holding the Thanos object storage configuration, used to upload rule evaluation results.",
+							Optional:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: SecretKeySelectorSchema(),
+							},
+						},
+						"rule_selector": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "A selector to select which PrometheusRules to mount for alerting and recording.",
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: labelSelectorFields(true),
+							},
+						},
+						"rule_namespace_selector": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Namespaces to be selected for PrometheusRules discovery. If unspecified, only the same namespace as the ThanosRuler object is in is used.",
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: labelSelectorFields(true),
+							},
+						},
+						"labels": {
+							Type:        schema.TypeMap,
+							Description: "External labels added to alerts and series produced by Thanos Ruler.",
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"storage": {
+							Type:        schema.TypeList,
+							Description: "Storage of rule evaluation results. If neither empty_dir nor volume_claim_template is set, an emptyDir is used.",
+							Optional:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: storageSchema(),
+							},
+						},
+					}),
+				},
+			},
+			"spec_override_json": specOverrideSchema("json"),
+			"spec_override_yaml": specOverrideSchema("yaml"),
+		},
+	}
+}
+
+func storageSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"empty_dir": {
+			Type:          schema.TypeList,
+			Description:   "EmptyDir volume, used in place of volume_claim_template if set.",
+			Optional:      true,
+			MaxItems:      1,
+			ConflictsWith: []string{"spec.0.storage.0.volume_claim_template"},
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"medium": {
+						Type:         schema.TypeString,
+						Description:  `What type of storage medium should back this directory. The default is "" which means to use the node's default medium. Must be an empty string (default) or Memory.`,
+						Optional:     true,
+						Default:      "",
+						ValidateFunc: validation.StringInSlice([]string{"", "Memory"}, false),
+					},
+				},
+			},
+		},
+		"volume_claim_template": {
+			Type:          schema.TypeList,
+			Description:   "Spec of the PersistentVolumeClaims created for every Pod. More info: http://kubernetes.io/docs/user-guide/persistent-volumes#persistentvolumeclaims",
+			Optional:      true,
+			MaxItems:      1,
+			ConflictsWith: []string{"spec.0.storage.0.empty_dir"},
+			Elem: &schema.Resource{
+				Schema: persistentVolumeClaimSpecFields(),
+			},
+		},
+	}
+}
+
+func resourcePOThanosRulerCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*KubeClientsets).DynamicClient
+
+	body, err := thanosRulerObject(d)
+	if err != nil {
+		return err
+	}
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(body); err != nil {
+		return fmt.Errorf("Failed to decode ThanosRuler: %s", err)
+	}
+
+	log.Printf("[INFO] Creating ThanosRuler custom resource: %s", body)
+	out, err := conn.Resource(thanosRulerGVR).Namespace(obj.GetNamespace()).Create(obj, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("Failed to create ThanosRuler: %s", err)
+	}
+
+	log.Printf("[INFO] Submitted new ThanosRuler custom resource: %#v", out.Object)
+
+	d.SetId(buildId(metav1.ObjectMeta{Namespace: out.GetNamespace(), Name: out.GetName()}))
+
+	return resourcePOThanosRulerRead(d, meta)
+}
+
+func resourcePOThanosRulerExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	conn := meta.(*KubeClientsets).DynamicClient
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	log.Printf("[INFO] Checking ThanosRuler custom resource %s", name)
+	_, err = conn.Resource(thanosRulerGVR).Namespace(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+	}
+	return true, err
+}
+
+func resourcePOThanosRulerRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*KubeClientsets).DynamicClient
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading ThanosRuler custom resource %s", name)
+	out, err := conn.Resource(thanosRulerGVR).Namespace(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		switch {
+		case errors.IsNotFound(err):
+			log.Printf("[DEBUG] ThanosRuler %q was not found in Namespace %q - removing from state!", name, namespace)
+			d.SetId("")
+			return nil
+		default:
+			log.Printf("[DEBUG] Error reading ThanosRuler: %#v", err)
+			return err
+		}
+	}
+	data, err := out.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	tr := &ThanosRuler{}
+	if err := decodeObject(d, data, tr); err != nil {
+		return fmt.Errorf("Failed to decode ThanosRuler: %s", err)
+	}
+	log.Printf("[INFO] Received ThanosRuler: %#v", tr)
+
+	if err := d.Set("metadata", flattenMetadata(tr.ObjectMeta, d)); err != nil {
+		return fmt.Errorf("Error setting `metadata`: %+v", err)
+	}
+	spec, err := flattenThanosRulerSpec(tr.Spec)
+	if err != nil {
+		return fmt.Errorf("Failed to set ThanosRuler spec: %s", err)
+	}
+	if err := d.Set("spec", spec); err != nil {
+		return fmt.Errorf("Failed to set ThanosRuler spec: %s", err)
+	}
+	return nil
+}
+
+func resourcePOThanosRulerUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*KubeClientsets).DynamicClient
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	data, err := patchThanosRuler(d)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Updating ThanosRuler %q: %v", name, string(data))
+	out, err := conn.Resource(thanosRulerGVR).Namespace(namespace).Patch(name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("Failed to update ThanosRuler: %s", err)
+	}
+	log.Printf("[INFO] Submitted updated ThanosRuler: %#v", out.Object)
+
+	return resourcePOThanosRulerRead(d, meta)
+}
+
+func resourcePOThanosRulerDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*KubeClientsets).DynamicClient
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting ThanosRuler: %q", name)
+	err = conn.Resource(thanosRulerGVR).Namespace(namespace).Delete(name, &metav1.DeleteOptions{})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] ThanosRuler %s deleted", name)
+
+	d.SetId("")
+
+	return nil
+}
+
+func buildThanosRuler(d resourceGetter) (*ThanosRuler, error) {
+	spec, err := expandThanosRulerSpec(d.Get("spec").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ThanosRuler{
+		TypeMeta:   metav1.TypeMeta{Kind: "ThanosRuler", APIVersion: thanosRulerGVR.GroupVersion().String()},
+		ObjectMeta: expandMetadata(d.Get("metadata").([]interface{})),
+		Spec:       *spec,
+	}, nil
+}
+
+func thanosRulerObject(d resourceGetter) ([]byte, error) {
+	obj, err := buildThanosRuler(d)
+	if err != nil {
+		return nil, err
+	}
+	return objectWithSpecOverride(d, obj)
+}
+
+func patchThanosRuler(d resourceGetter) ([]byte, error) {
+	ops := patchMetadata("metadata.0.", "/metadata/", d)
+
+	if d.HasChange("spec") || hasSpecOverrideChange(d) {
+		log.Println("[TRACE] ThanosRuler.Spec has changes")
+		spec, err := expandThanosRulerSpec(d.Get("spec").([]interface{}))
+		if err != nil {
+			return nil, err
+		}
+		merged, err := mergedSpec(d, spec)
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, replace(merged))
+	}
+
+	data, err := ops.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to marshal update operations for ThanosRuler: %s", err)
+	}
+	return data, nil
+}
+
+func expandThanosRulerSpec(l []interface{}) (*ThanosRulerSpec, error) {
+	obj := &ThanosRulerSpec{}
+	if len(l) == 0 || l[0] == nil {
+		return obj, nil
+	}
+	in := l[0].(map[string]interface{})
+
+	obj.Image = in["image"].(string)
+	obj.Replicas = ptrToInt32(int32(in["replicas"].(int)))
+	obj.ServiceAccountName = in["service_account_name"].(string)
+	obj.PriorityClassName = in["priority_class_name"].(string)
+	obj.ListenLocal = in["listen_local"].(bool)
+	obj.PortName = in["port_name"].(string)
+	obj.LogLevel = in["log_level"].(string)
+	obj.LogFormat = in["log_format"].(string)
+	obj.EvaluationInterval = in["evaluation_interval"].(string)
+	obj.Retention = in["retention"].(string)
+	if v, ok := in["query_endpoints"].([]interface{}); ok {
+		obj.QueryEndpoints = expandStringSlice(v)
+	}
+	if v, ok := in["alertmanagers_url"].([]interface{}); ok && len(v) > 0 {
+		obj.AlertManagersURL = expandStringSlice(v)
+	}
+	if v, ok := in["object_storage_config"].([]interface{}); ok && len(v) > 0 {
+		s, err := expandSecretKeyRef(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.ObjectStorageConfig = s
+	}
+	if v, ok := in["rule_selector"].([]interface{}); ok && len(v) > 0 {
+		obj.RuleSelector = expandLabelSelector(v)
+	}
+	if v, ok := in["rule_namespace_selector"].([]interface{}); ok && len(v) > 0 {
+		obj.RuleNamespaceSelector = expandLabelSelector(v)
+	}
+	if v, ok := in["labels"].(map[string]interface{}); ok && len(v) > 0 {
+		obj.Labels = expandStringMap(v)
+	}
+	if v, ok := in["storage"].([]interface{}); ok && len(v) > 0 {
+		s, err := expandStorageSpec(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.Storage = s
+	}
+
+	pod, err := expandPodTemplate(in)
+	if err != nil {
+		return obj, err
+	}
+	obj.Containers = pod.Containers
+	obj.InitContainers = pod.InitContainers
+	obj.NodeSelector = pod.NodeSelector
+	obj.Resources = pod.Resources
+	obj.SecurityContext = pod.SecurityContext
+	obj.Tolerations = pod.Tolerations
+	obj.Volumes = pod.Volumes
+
+	return obj, nil
+}
+
+func flattenThanosRulerSpec(spec ThanosRulerSpec) ([]interface{}, error) {
+	att := make(map[string]interface{})
+
+	att["image"] = spec.Image
+	if spec.Replicas != nil {
+		att["replicas"] = *spec.Replicas
+	}
+	att["service_account_name"] = spec.ServiceAccountName
+	att["priority_class_name"] = spec.PriorityClassName
+	att["listen_local"] = spec.ListenLocal
+	att["port_name"] = spec.PortName
+	att["log_level"] = spec.LogLevel
+	att["log_format"] = spec.LogFormat
+	att["evaluation_interval"] = spec.EvaluationInterval
+	att["retention"] = spec.Retention
+	att["query_endpoints"] = spec.QueryEndpoints
+	att["alertmanagers_url"] = spec.AlertManagersURL
+	if spec.ObjectStorageConfig != nil {
+		att["object_storage_config"] = flattenSecretKeyRef(spec.ObjectStorageConfig)
+	}
+	if spec.RuleSelector != nil {
+		att["rule_selector"] = flattenLabelSelector(spec.RuleSelector)
+	}
+	if spec.RuleNamespaceSelector != nil {
+		att["rule_namespace_selector"] = flattenLabelSelector(spec.RuleNamespaceSelector)
+	}
+	att["labels"] = spec.Labels
+	if spec.Storage != nil {
+		att["storage"] = flattenStorageSpec(spec.Storage)
+	}
+
+	err := flattenPodTemplate(podTemplate{
+		Containers:      spec.Containers,
+		InitContainers:  spec.InitContainers,
+		SecurityContext: spec.SecurityContext,
+	}, att)
+	if err != nil {
+		return nil, err
+	}
+
+	return []interface{}{att}, nil
+}
+
+func expandStorageSpec(l []interface{}) (*po_types.StorageSpec, error) {
+	obj := &po_types.StorageSpec{}
+	if len(l) == 0 || l[0] == nil {
+		return obj, nil
+	}
+	in := l[0].(map[string]interface{})
+
+	if v, ok := in["empty_dir"].([]interface{}); ok && len(v) > 0 {
+		obj.EmptyDir = expandEmptyDirVolumeSource(v)
+	}
+	if v, ok := in["volume_claim_template"].([]interface{}); ok && len(v) > 0 {
+		spec, err := expandPersistentVolumeClaimSpec(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.VolumeClaimTemplate.Spec = *spec
+	}
+	return obj, nil
+}
+
+func flattenStorageSpec(in *po_types.StorageSpec) []interface{} {
+	att := make(map[string]interface{})
+	if in.EmptyDir != nil {
+		att["empty_dir"] = flattenEmptyDirVolumeSource(in.EmptyDir)
+	}
+	if len(in.VolumeClaimTemplate.Spec.AccessModes) > 0 {
+		att["volume_claim_template"] = flattenPersistentVolumeClaimSpec(in.VolumeClaimTemplate.Spec)
+	}
+	return []interface{}{att}
+}
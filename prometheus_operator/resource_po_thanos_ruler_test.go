@@ -0,0 +1,195 @@
+package prometheus_operator
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestAccPrometheusOperatorThanosRuler_basic(t *testing.T) {
+	var tr ThanosRuler
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	namespace := "monitoring"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "po_thanos_ruler.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccPrometheusOperatorThanosRulerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPrometheusOperatorThanosRulerConfig_basic(name, namespace),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccPrometheusOperatorThanosRulerExists("po_thanos_ruler.test", &tr),
+					resource.TestCheckResourceAttr("po_thanos_ruler.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttr("po_thanos_ruler.test", "metadata.0.namespace", namespace),
+					resource.TestCheckResourceAttrSet("po_thanos_ruler.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttr("po_thanos_ruler.test", "spec.0.replicas", "1"),
+					resource.TestCheckResourceAttr("po_thanos_ruler.test", "spec.0.query_endpoints.#", "1"),
+					resource.TestCheckResourceAttr("po_thanos_ruler.test", "spec.0.alertmanagers_url.0", "dnssrv+http://alertmanager-operated.monitoring.svc:9093"),
+					resource.TestCheckResourceAttr("po_thanos_ruler.test", "spec.0.rule_selector.0.match_labels.role", "thanos-rules"),
+					resource.TestCheckResourceAttr("po_thanos_ruler.test", "spec.0.retention", "48h"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPrometheusOperatorThanosRuler_importBasic(t *testing.T) {
+	resourceName := "po_thanos_ruler.test"
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	namespace := "monitoring"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccPrometheusOperatorThanosRulerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPrometheusOperatorThanosRulerConfig_basic(name, namespace),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version"},
+			},
+		},
+	})
+}
+
+func TestExpandFlattenThanosRulerSpec(t *testing.T) {
+	raw := map[string]interface{}{
+		"spec": []interface{}{map[string]interface{}{
+			"replicas":        2,
+			"query_endpoints": []interface{}{"dnssrv+_http._tcp.thanos-querier.monitoring.svc"},
+			"object_storage_config": []interface{}{map[string]interface{}{
+				"name": "thanos-objstore",
+				"key":  "objstore.yaml",
+			}},
+			"rule_namespace_selector": []interface{}{map[string]interface{}{
+				"match_labels": map[string]interface{}{"team": "sre"},
+			}},
+			"storage": []interface{}{map[string]interface{}{
+				"volume_claim_template": []interface{}{map[string]interface{}{
+					"access_modes": []interface{}{"ReadWriteOnce"},
+					"resources": []interface{}{map[string]interface{}{
+						"requests": map[string]interface{}{"storage": "10Gi"},
+					}},
+				}},
+			}},
+			"toleration": []interface{}{map[string]interface{}{
+				"key":      "dedicated",
+				"operator": "Equal",
+				"value":    "monitoring",
+				"effect":   "NoSchedule",
+			}},
+		}},
+	}
+	d := schema.TestResourceDataRaw(t, resourcePOThanosRuler().Schema, raw)
+
+	spec, err := expandThanosRulerSpec(d.Get("spec").([]interface{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *spec.Replicas != 2 || spec.ObjectStorageConfig.Key != "objstore.yaml" || len(spec.Tolerations) != 1 {
+		t.Fatalf("Unexpected spec %#v", spec)
+	}
+	if q := spec.Storage.VolumeClaimTemplate.Spec.Resources.Requests["storage"]; q.String() != "10Gi" {
+		t.Fatalf("Unexpected storage request %s", q.String())
+	}
+
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&ThanosRuler{Spec: *spec})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := &ThanosRuler{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u, tr); err != nil {
+		t.Fatal(err)
+	}
+	flattened, err := flattenThanosRulerSpec(tr.Spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Set("spec", flattened); err != nil {
+		t.Fatal(err)
+	}
+	out, err := expandThanosRulerSpec(d.Get("spec").([]interface{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(out.Storage, spec.Storage) || !reflect.DeepEqual(out.RuleNamespaceSelector, spec.RuleNamespaceSelector) {
+		t.Errorf("Expected %#v after round trip, got %#v", spec, out)
+	}
+}
+
+func testAccPrometheusOperatorThanosRulerConfig_basic(name, namespace string) string {
+	return fmt.Sprintf(`
+resource "po_thanos_ruler" "test" {
+  metadata {
+    name = "%[1]s"
+    namespace = "%[2]s"
+  }
+  spec {
+    image = "quay.io/thanos/thanos:v0.12.2"
+    query_endpoints = ["dnssrv+_http._tcp.thanos-querier.monitoring.svc"]
+    alertmanagers_url = ["dnssrv+http://alertmanager-operated.monitoring.svc:9093"]
+    retention = "48h"
+    rule_selector {
+      match_labels = {
+        role = "thanos-rules"
+      }
+    }
+  }
+}`, name, namespace)
+}
+
+func testAccPrometheusOperatorThanosRulerExists(n string, obj *ThanosRuler) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*KubeClientsets).DynamicClient
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		out, err := conn.Resource(thanosRulerGVR).Namespace(namespace).Get(name, meta_v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		return runtime.DefaultUnstructuredConverter.FromUnstructured(out.UnstructuredContent(), obj)
+	}
+}
+
+func testAccPrometheusOperatorThanosRulerDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*KubeClientsets).DynamicClient
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "po_thanos_ruler" {
+			continue
+		}
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = conn.Resource(thanosRulerGVR).Namespace(namespace).Get(name, meta_v1.GetOptions{})
+		if err == nil {
+			return fmt.Errorf("ThanosRuler still exists: %s", rs.Primary.ID)
+		}
+	}
+	return nil
+}
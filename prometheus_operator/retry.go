@@ -0,0 +1,107 @@
+package prometheus_operator
+
+import (
+	"io"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const maxRetryDelay = 30 * time.Second
+
+// retryTransport retries requests rejected by API server throttling (429),
+// failing with a server error (5xx), e.g. when an admission webhook times
+// out, or timing out on the network. Delays grow exponentially with jitter.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	backoff    time.Duration
+}
+
+func retryTransportWrapper(maxRetries int, backoff time.Duration) func(http.RoundTripper) http.RoundTripper {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		return &retryTransport{
+			next:       rt,
+			maxRetries: maxRetries,
+			backoff:    backoff,
+		}
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+		if attempt >= t.maxRetries || !isRetryable(resp, err) {
+			return resp, err
+		}
+		if req.Body != nil && req.GetBody == nil {
+			// The body was consumed and can't be replayed.
+			return resp, err
+		}
+
+		delay := t.delay(attempt, resp)
+		if resp != nil {
+			log.Printf("[DEBUG] %s %s failed with status %d, retrying in %s (%d/%d)", req.Method, req.URL, resp.StatusCode, delay, attempt+1, t.maxRetries)
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		} else {
+			log.Printf("[DEBUG] %s %s failed: %s, retrying in %s (%d/%d)", req.Method, req.URL, err, delay, attempt+1, t.maxRetries)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		req = req.Clone(req.Context())
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+	}
+}
+
+// delay returns the jittered exponential backoff for the given attempt,
+// honouring the Retry-After header sent by the API server.
+func (t *retryTransport) delay(attempt int, resp *http.Response) time.Duration {
+	d := t.backoff << uint(attempt)
+	if d <= 0 || d > maxRetryDelay {
+		d = maxRetryDelay
+	}
+	d = d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+
+	if resp != nil {
+		if s, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			if ra := time.Duration(s) * time.Second; ra > d && ra <= maxRetryDelay {
+				d = ra
+			}
+		}
+	}
+	return d
+}
+
+func isRetryable(resp *http.Response, err error) bool {
+	if err != nil {
+		netErr, ok := err.(net.Error)
+		return ok && netErr.Timeout()
+	}
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return true
+	case resp.StatusCode == http.StatusNotImplemented:
+		return false
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package prometheus_operator
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	po_types "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	monclientv1 "github.com/coreos/prometheus-operator/pkg/client/versioned/typed/monitoring/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	restclient "k8s.io/client-go/rest"
+)
+
+const webhookTimeout = `{"kind":"Status","apiVersion":"v1","status":"Failure","message":"Internal error occurred: failed calling webhook \"prometheusrulemutate.monitoring.coreos.com\": context deadline exceeded","reason":"InternalError","code":500}`
+
+// testMonitoringClient returns a monitoring client talking to the stand-in API server.
+func testMonitoringClient(t *testing.T, server *httptest.Server, maxRetries int) *monclientv1.MonitoringV1Client {
+	cfg := &restclient.Config{
+		Host:          server.URL,
+		WrapTransport: retryTransportWrapper(maxRetries, time.Millisecond),
+	}
+	m, err := monclientv1.NewForConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestRetryTransport_retriesCreate(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apis/monitoring.coreos.com/v1/namespaces/monitoring/prometheusrules" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		rule := &po_types.PrometheusRule{}
+		if err := json.Unmarshal(body, rule); err != nil || rule.Name != "test" {
+			t.Errorf("Expected request body to be replayed, got %q", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch atomic.AddInt32(&requests, 1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"TooManyRequests","code":429}`))
+		case 2:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(webhookTimeout))
+		default:
+			w.WriteHeader(http.StatusCreated)
+			w.Write(body)
+		}
+	}))
+	defer server.Close()
+
+	m := testMonitoringClient(t, server, 3)
+	out, err := m.PrometheusRules("monitoring").Create(&po_types.PrometheusRule{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "monitoring"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Name != "test" {
+		t.Errorf("Expected created rule to be returned, got %#v", out)
+	}
+	if n := atomic.LoadInt32(&requests); n != 3 {
+		t.Errorf("Expected 3 requests, got %d", n)
+	}
+}
+
+func TestRetryTransport_givesUp(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(webhookTimeout))
+	}))
+	defer server.Close()
+
+	m := testMonitoringClient(t, server, 2)
+	_, err := m.Prometheuses("monitoring").Get("k8s", metav1.GetOptions{})
+	if err == nil {
+		t.Fatal("Expected error after exhausting retries")
+	}
+	if n := atomic.LoadInt32(&requests); n != 3 {
+		t.Errorf("Expected 3 requests, got %d", n)
+	}
+}
+
+func TestRetryTransport_doesNotRetryClientErrors(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`))
+	}))
+	defer server.Close()
+
+	m := testMonitoringClient(t, server, 3)
+	if _, err := m.ServiceMonitors("monitoring").Get("missing", metav1.GetOptions{}); err == nil {
+		t.Fatal("Expected not found error")
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("Expected a single request, got %d", n)
+	}
+}
@@ -0,0 +1,318 @@
+package prometheus_operator
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	po_types "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+	restclient "k8s.io/client-go/rest"
+)
+
+// ruleShard is the JSON body of one of the PrometheusRule objects holding
+// the groups of a sharded po_prometheus_rule.
+type ruleShard map[string]interface{}
+
+func (s ruleShard) name() string {
+	metadata, _ := s["metadata"].(map[string]interface{})
+	name, _ := metadata["name"].(string)
+	return name
+}
+
+// isShardedRule tells if the groups of po_prometheus_rule are split across
+// several objects.
+func isShardedRule(d resourceGetter) bool {
+	v, ok := d.Get("max_object_bytes").(int)
+	return ok && v > 0
+}
+
+// hasRuleShards tells if po_prometheus_rule is or was sharded, so the plan
+// can't be checked against a single object named after the resource.
+func hasRuleShards(d *schema.ResourceDiff, meta interface{}) bool {
+	o, _ := d.GetChange("shards")
+	return isShardedRule(d) || len(o.([]interface{})) > 0
+}
+
+func ruleShardName(name string, i int) string {
+	return fmt.Sprintf("%s-%d", name, i)
+}
+
+// shardRuleGroups splits the groups of the PrometheusRule body into objects
+// named <name>-0, <name>-1, ..., each at most maxBytes long in JSON. Groups
+// are packed in order, so adding a group at the end only changes the last
+// shard.
+func shardRuleGroups(body []byte, maxBytes int) ([]ruleShard, error) {
+	obj := ruleShard{}
+	if err := json.Unmarshal(body, &obj); err != nil {
+		return nil, err
+	}
+	name := obj.name()
+	spec, _ := obj["spec"].(map[string]interface{})
+	if spec == nil {
+		spec = map[string]interface{}{}
+	}
+	groups, _ := spec["groups"].([]interface{})
+
+	newShard := func(i int) ruleShard {
+		shard := ruleShard{}
+		for k, v := range obj {
+			shard[k] = v
+		}
+		metadata := map[string]interface{}{}
+		for k, v := range obj["metadata"].(map[string]interface{}) {
+			metadata[k] = v
+		}
+		metadata["name"] = ruleShardName(name, i)
+		shard["metadata"] = metadata
+		shardSpec := map[string]interface{}{}
+		for k, v := range spec {
+			shardSpec[k] = v
+		}
+		shardSpec["groups"] = []interface{}{}
+		shard["spec"] = shardSpec
+		return shard
+	}
+	addGroup := func(shard ruleShard, g interface{}) {
+		shardSpec := shard["spec"].(map[string]interface{})
+		shardSpec["groups"] = append(shardSpec["groups"].([]interface{}), g)
+	}
+
+	shards := []ruleShard{newShard(0)}
+	base, err := json.Marshal(shards[0])
+	if err != nil {
+		return nil, err
+	}
+	size := len(base)
+	for _, g := range groups {
+		data, err := json.Marshal(g)
+		if err != nil {
+			return nil, err
+		}
+		if len(base)+len(data) > maxBytes {
+			groupName, _ := g.(map[string]interface{})["name"].(string)
+			return nil, fmt.Errorf("Rule group %q takes %d bytes, more than max_object_bytes %d", groupName, len(base)+len(data), maxBytes)
+		}
+		// Groups after the first one are separated by a comma.
+		if size > len(base) {
+			size++
+		}
+		if size+len(data) > maxBytes {
+			shards = append(shards, newShard(len(shards)))
+			size = len(base)
+		}
+		addGroup(shards[len(shards)-1], g)
+		size += len(data)
+	}
+	return shards, nil
+}
+
+// prometheusRuleShards returns the objects of po_prometheus_rule, which is a
+// single object named after the resource unless max_object_bytes is set.
+func prometheusRuleShards(d resourceGetter) ([]ruleShard, error) {
+	body, err := prometheusRuleObject(d)
+	if err != nil {
+		return nil, err
+	}
+	if !isShardedRule(d) {
+		obj := ruleShard{}
+		return []ruleShard{obj}, json.Unmarshal(body, &obj)
+	}
+	return shardRuleGroups(body, d.Get("max_object_bytes").(int))
+}
+
+// ruleObjectNames returns the names of the objects in the cluster, given
+// the shards recorded in the state.
+func ruleObjectNames(d *schema.ResourceData, shards []interface{}) ([]string, error) {
+	if shards := expandStringSlice(shards); len(shards) > 0 {
+		return shards, nil
+	}
+	_, name, err := idParts(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	return []string{name}, nil
+}
+
+// ruleShardsDiff plans the shard names, which change with the size of
+// the groups.
+func ruleShardsDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !isShardedRule(d) {
+		if d.Id() == "" || len(d.Get("shards").([]interface{})) > 0 {
+			return d.SetNew("shards", []interface{}{})
+		}
+		return nil
+	}
+	keys := d.GetChangedKeysPrefix("spec")
+	for _, k := range []string{"metadata.0.name", "metadata.0.labels", "metadata.0.annotations"} {
+		keys = append(keys, d.GetChangedKeysPrefix(k)...)
+	}
+	for _, k := range keys {
+		if !d.NewValueKnown(k) {
+			log.Printf("[DEBUG] Shards of PrometheusRule %q are known only after apply, %s is unknown", d.Id(), k)
+			return d.SetNewComputed("shards")
+		}
+	}
+	shards, err := prometheusRuleShards(d)
+	if err != nil {
+		return err
+	}
+	return d.SetNew("shards", ruleShardNames(shards))
+}
+
+// dryRunRuleShardsDiff submits every shard with dryRun=All. Shards are
+// created under a generated name, so existing ones don't conflict.
+func dryRunRuleShardsDiff(d *schema.ResourceDiff, meta interface{}) error {
+	clients, ok := meta.(*KubeClientsets)
+	if !ok || !clients.DryRun {
+		return nil
+	}
+	if k, ok := unknownValue(d); ok {
+		log.Printf("[DEBUG] Skipping dry-run of prometheusrules %q, %s is known only after apply", d.Id(), k)
+		return nil
+	}
+	if d.Id() != "" && !d.HasChange("metadata") && !d.HasChange("spec") && !hasSpecOverrideChange(d) && !d.HasChange("max_object_bytes") {
+		return nil
+	}
+	shards, err := prometheusRuleShards(d)
+	if err != nil {
+		return err
+	}
+	namespace := d.Get("metadata.0.namespace").(string)
+	for _, s := range shards {
+		metadata := s["metadata"].(map[string]interface{})
+		delete(metadata, "name")
+		metadata["generateName"] = d.Get("metadata.0.name").(string) + "-"
+		body, err := json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		log.Printf("[INFO] Dry-run creating prometheusrules shard in namespace %q: %s", namespace, body)
+		if err := dryRunCreate(clients.MonitoringClient.RESTClient(), "prometheusrules", namespace, body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func ruleShardNames(shards []ruleShard) []string {
+	names := make([]string, len(shards))
+	for i, s := range shards {
+		names[i] = s.name()
+	}
+	return names
+}
+
+// applyRuleShards replaces the shards of previous names and creates the
+// others, then deletes the objects of previous names which are no longer
+// part of the set. Objects which already exist but aren't previous shards
+// aren't taken over, while previous shards deleted outside of Terraform are
+// created again. It returns the names of the objects held by the resource,
+// which on error are the shards applied so far followed by the previous ones
+// still left, so they can be stored and updated by the next apply.
+func applyRuleShards(client restclient.Interface, namespace string, shards []ruleShard, previous []string) ([]string, error) {
+	current := make(map[string]bool, len(shards))
+	existing := make(map[string]bool, len(previous))
+	for _, name := range previous {
+		existing[name] = true
+	}
+	applied := make([]string, 0, len(shards))
+	deleted := map[string]bool{}
+	held := func() []string {
+		names := append([]string{}, applied...)
+		for _, name := range previous {
+			if !current[name] && !deleted[name] {
+				names = append(names, name)
+			}
+		}
+		return names
+	}
+	for _, s := range shards {
+		name := s.name()
+		current[name] = true
+		if err := applyRuleShard(client, namespace, s, existing[name]); err != nil {
+			current[name] = false
+			return held(), err
+		}
+		applied = append(applied, name)
+	}
+	for _, name := range previous {
+		if current[name] {
+			continue
+		}
+		if err := deleteObject(client, "prometheusrules", namespace, name); err != nil {
+			return held(), err
+		}
+		deleted[name] = true
+	}
+	return applied, nil
+}
+
+// applyRuleShard patches the shard if it exists, falling back to creating it
+// if it was deleted, and creates it otherwise.
+func applyRuleShard(client restclient.Interface, namespace string, s ruleShard, exists bool) error {
+	name := s.name()
+	if exists {
+		data, err := replaceObjectPatch(s)
+		if err != nil {
+			return err
+		}
+		log.Printf("[INFO] Updating PrometheusRule shard %q: %s", name, data)
+		err = client.Patch(pkgApi.JSONPatchType).
+			Namespace(namespace).
+			Resource("prometheusrules").
+			Name(name).
+			Body(data).
+			Do().
+			Error()
+		if err == nil {
+			return nil
+		}
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("Failed to update PrometheusRule %q: %s", name, err)
+		}
+		log.Printf("[DEBUG] PrometheusRule shard %q was deleted, creating it again", name)
+	}
+	body, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Creating PrometheusRule shard %q: %s", name, body)
+	if err := createObject(client, "prometheusrules", namespace, body, &po_types.PrometheusRule{}); err != nil {
+		return fmt.Errorf("Failed to create PrometheusRule %q: %s", name, err)
+	}
+	return nil
+}
+
+// readRuleShards reads the objects into a single PrometheusRule named name,
+// with the groups of all shards in order. Missing shards are skipped, so
+// their groups show up as changes. The NotFound error is returned only if
+// all shards are missing.
+func readRuleShards(d *schema.ResourceData, client restclient.Interface, namespace, name string, names []string) (*PrometheusRule, error) {
+	var out *PrometheusRule
+	var notFound error
+	for _, n := range names {
+		shard := &PrometheusRule{}
+		err := readObject(d, client, "prometheusrules", namespace, n, shard)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				log.Printf("[DEBUG] PrometheusRule shard %q was not found in Namespace %q", n, namespace)
+				notFound = err
+				continue
+			}
+			return nil, err
+		}
+		if out == nil {
+			out = shard
+			out.Name = name
+			continue
+		}
+		out.Spec.Groups = append(out.Spec.Groups, shard.Spec.Groups...)
+	}
+	if out == nil {
+		return nil, notFound
+	}
+	return out, nil
+}
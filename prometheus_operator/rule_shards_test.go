@@ -0,0 +1,246 @@
+package prometheus_operator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+func testShardedRuleData(t *testing.T, groups, maxBytes int) *schema.ResourceData {
+	g := make([]interface{}, groups)
+	for i := range g {
+		g[i] = map[string]interface{}{
+			"name": fmt.Sprintf("group-%d", i),
+			"rules": []interface{}{
+				map[string]interface{}{
+					"record": fmt.Sprintf("job:up:sum%d", i),
+					"expr":   "sum by (job) (up)",
+				},
+			},
+		}
+	}
+	return schema.TestResourceDataRaw(t, resourcePOPrometheusRule().Schema, map[string]interface{}{
+		"metadata": []interface{}{
+			map[string]interface{}{
+				"name":      "big",
+				"namespace": "monitoring",
+				"labels":    map[string]interface{}{"role": "alert-rules"},
+			},
+		},
+		"spec":             []interface{}{map[string]interface{}{"groups": g}},
+		"max_object_bytes": maxBytes,
+	})
+}
+
+func TestShardRuleGroups(t *testing.T) {
+	d := testShardedRuleData(t, 20, 1024)
+	shards, err := prometheusRuleShards(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shards) < 2 {
+		t.Fatalf("Expected groups to be split, got %d shards", len(shards))
+	}
+
+	groups := []string{}
+	for i, s := range shards {
+		if s.name() != fmt.Sprintf("big-%d", i) {
+			t.Errorf("Unexpected shard name %q", s.name())
+		}
+		if labels := s["metadata"].(map[string]interface{})["labels"]; !reflect.DeepEqual(labels, map[string]interface{}{"role": "alert-rules"}) {
+			t.Errorf("Expected shard %q to share labels, got %v", s.name(), labels)
+		}
+		data, err := json.Marshal(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(data) > 1024 {
+			t.Errorf("Expected shard %q to be at most 1024 bytes, got %d", s.name(), len(data))
+		}
+		for _, g := range s["spec"].(map[string]interface{})["groups"].([]interface{}) {
+			groups = append(groups, g.(map[string]interface{})["name"].(string))
+		}
+	}
+	if len(groups) != 20 || groups[0] != "group-0" || groups[19] != "group-19" {
+		t.Errorf("Expected all groups in order, got %v", groups)
+	}
+
+	d = testShardedRuleData(t, 1, 0)
+	shards, err = prometheusRuleShards(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shards) != 1 || shards[0].name() != "big" {
+		t.Errorf("Expected a single object without max_object_bytes, got %v", ruleShardNames(shards))
+	}
+}
+
+func TestShardRuleGroups_groupTooLarge(t *testing.T) {
+	body := `{"metadata":{"name":"big"},"spec":{"groups":[{"name":"huge","rules":[{"record":"r","expr":"` + strings.Repeat("x", 2048) + `"}]}]}}`
+	_, err := shardRuleGroups([]byte(body), 1024)
+	if err == nil || !strings.Contains(err.Error(), `"huge"`) {
+		t.Errorf("Expected error about the oversized group, got %v", err)
+	}
+}
+
+func TestApplyRuleShards(t *testing.T) {
+	var mu sync.Mutex
+	requests := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		path := strings.TrimPrefix(r.URL.Path, "/apis/monitoring.coreos.com/v1/namespaces/monitoring/prometheusrules")
+		mu.Lock()
+		requests = append(requests, r.Method+" "+path)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			w.Write(body)
+		case http.MethodPatch:
+			ops := []map[string]interface{}{}
+			if err := json.Unmarshal(body, &ops); err != nil || len(ops) != 3 || ops[2]["path"] != "/spec" {
+				t.Errorf("Unexpected patch %s", body)
+			}
+			w.Write([]byte(`{"kind":"PrometheusRule","apiVersion":"monitoring.coreos.com/v1","metadata":{"name":"big-0","namespace":"monitoring"}}`))
+		case http.MethodDelete:
+			w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Success"}`))
+		}
+	}))
+	defer server.Close()
+
+	shards, err := prometheusRuleShards(testShardedRuleData(t, 20, 1024))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := testMonitoringClient(t, server, 0)
+	previous := []string{"big-0", "big-1", "big-2", "big-3", "big-4", "big-5", "big-6", "big-7", "big-8", "big-9"}
+	names, err := applyRuleShards(m.RESTClient(), "monitoring", shards, previous)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(names, ruleShardNames(shards)) {
+		t.Errorf("Expected shards %v, got %v", ruleShardNames(shards), names)
+	}
+
+	expected := []string{}
+	for i, name := range ruleShardNames(shards) {
+		if i < len(previous) {
+			expected = append(expected, "PATCH /"+name)
+		} else {
+			expected = append(expected, "POST ")
+		}
+	}
+	for _, name := range previous[len(shards):] {
+		expected = append(expected, "DELETE /"+name)
+	}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Errorf("Expected requests %v, got %v", expected, requests)
+	}
+}
+
+func TestApplyRuleShards_alreadyExists(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodPost {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"AlreadyExists","code":409}`))
+	}))
+	defer server.Close()
+
+	shards, err := prometheusRuleShards(testShardedRuleData(t, 20, 1024))
+	if err != nil {
+		t.Fatal(err)
+	}
+	names, err := applyRuleShards(testMonitoringClient(t, server, 0).RESTClient(), "monitoring", shards, nil)
+	if err == nil || !strings.Contains(err.Error(), `Failed to create PrometheusRule "big-0"`) {
+		t.Errorf("Expected error creating the first shard, got %v", err)
+	}
+	if len(names) != 0 {
+		t.Errorf("Expected no shards held, got %v", names)
+	}
+}
+
+func TestApplyRuleShards_partial(t *testing.T) {
+	requests := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+path.Base(r.URL.Path))
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPatch && path.Base(r.URL.Path) == "big-0":
+			// big-0 was deleted outside of Terraform.
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`))
+		case r.Method == http.MethodPost && len(requests) > 3:
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Forbidden","code":403}`))
+		case r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			w.Write(body)
+		default:
+			w.Write([]byte(`{"kind":"PrometheusRule","apiVersion":"monitoring.coreos.com/v1","metadata":{"name":"big-1","namespace":"monitoring"}}`))
+		}
+	}))
+	defer server.Close()
+
+	shards, err := prometheusRuleShards(testShardedRuleData(t, 20, 1024))
+	if err != nil {
+		t.Fatal(err)
+	}
+	names, err := applyRuleShards(testMonitoringClient(t, server, 0).RESTClient(), "monitoring", shards, []string{"big-0", "big-1", "big-old"})
+	if err == nil || !strings.Contains(err.Error(), `Failed to create PrometheusRule "big-2"`) {
+		t.Errorf("Expected error creating the third shard, got %v", err)
+	}
+	expected := []string{"PATCH big-0", "POST prometheusrules", "PATCH big-1", "POST prometheusrules"}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Errorf("Expected requests %v, got %v", expected, requests)
+	}
+	// The shards applied so far and the previous object left are held.
+	if expected := []string{"big-0", "big-1", "big-old"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected shards %v, got %v", expected, names)
+	}
+}
+
+func TestReadRuleShards_missing(t *testing.T) {
+	found := map[string]bool{"big-1": true}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		name := path.Base(r.URL.Path)
+		if !found[name] {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`))
+			return
+		}
+		fmt.Fprintf(w, `{"metadata":{"name":%q,"namespace":"monitoring"},"spec":{"groups":[{"name":%q,"rules":[]}]}}`, name, name)
+	}))
+	defer server.Close()
+
+	d := testShardedRuleData(t, 20, 1024)
+	client := testMonitoringClient(t, server, 0).RESTClient()
+	names := []string{"big-0", "big-1", "big-2"}
+	obj, err := readRuleShards(d, client, "monitoring", "big", names)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obj.Name != "big" || len(obj.Spec.Groups) != 1 || obj.Spec.Groups[0].Name != "big-1" {
+		t.Errorf("Expected the groups of the remaining shard, got %#v", obj)
+	}
+
+	found = map[string]bool{}
+	if _, err := readRuleShards(d, client, "monitoring", "big", names); !errors.IsNotFound(err) {
+		t.Errorf("Expected NotFound when all shards are missing, got %v", err)
+	}
+}
@@ -0,0 +1,265 @@
+package prometheus_operator
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/prometheus/prometheus/promql"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// rulesPollInterval is the interval of checks while waiting for rules to be
+// loaded.
+var rulesPollInterval = 5 * time.Second
+
+// ruleFileName returns the name of the file of a PrometheusRule in the
+// ConfigMaps generated by the operator.
+func ruleFileName(namespace, name string) string {
+	return fmt.Sprintf("%s-%s.yaml", namespace, name)
+}
+
+// ruleGroupContent is the name and the rules of a rule group, to compare the
+// groups of the resource with those of the ConfigMaps and of Prometheus.
+type ruleGroupContent struct {
+	name  string
+	rules []string
+}
+
+// renderRule renders a rule as "record <name>: <expr>" or "alert <name>:
+// <expr>". The expression is formatted the way Prometheus prints it in its
+// API, unless it can't be parsed.
+func renderRule(kind, name, expr string) string {
+	if node, err := promql.ParseExpr(expr); err == nil {
+		expr = node.String()
+	}
+	return fmt.Sprintf("%s %s: %s", kind, name, expr)
+}
+
+func renderOperatorRule(r map[string]interface{}) string {
+	if alert, _ := r["alert"].(string); alert != "" {
+		return renderRule("alert", alert, fmt.Sprint(r["expr"]))
+	}
+	record, _ := r["record"].(string)
+	return renderRule("record", record, fmt.Sprint(r["expr"]))
+}
+
+// operatorRuleFiles returns the groups of the rule files in the
+// prometheus-<name>-rulefiles-<n> ConfigMaps of a Prometheus.
+func operatorRuleFiles(configMaps corev1.ConfigMapsGetter, namespace, name string) (map[string][]ruleGroupContent, error) {
+	list, err := configMaps.ConfigMaps(namespace).List(metav1.ListOptions{LabelSelector: "prometheus-name=" + name})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list rule ConfigMaps of Prometheus %s/%s: %s", namespace, name, err)
+	}
+	prefix := "prometheus-" + name + "-rulefiles-"
+	out := map[string][]ruleGroupContent{}
+	for _, cm := range list.Items {
+		if !strings.HasPrefix(cm.Name, prefix) {
+			continue
+		}
+		for file, content := range cm.Data {
+			rules := PrometheusRuleSpec{}
+			if err := yaml.Unmarshal([]byte(content), &rules); err != nil {
+				return nil, fmt.Errorf("Failed to decode %s of ConfigMap %s/%s: %s", file, namespace, cm.Name, err)
+			}
+			if _, ok := out[file]; !ok {
+				out[file] = []ruleGroupContent{}
+			}
+			for _, g := range rules.Groups {
+				group := ruleGroupContent{name: g.Name, rules: []string{}}
+				for _, r := range g.Rules {
+					if r.Alert != "" {
+						group.rules = append(group.rules, renderRule("alert", r.Alert, r.Expr.String()))
+					} else {
+						group.rules = append(group.rules, renderRule("record", r.Record, r.Expr.String()))
+					}
+				}
+				out[file] = append(out[file], group)
+			}
+		}
+	}
+	return out, nil
+}
+
+// loadedRuleFiles returns the groups of the rule files loaded by Prometheus,
+// by base name of the file.
+func loadedRuleFiles(proxy apiProxy) (map[string][]ruleGroupContent, error) {
+	groups, err := getRuleGroups(proxy)
+	if err != nil {
+		return nil, err
+	}
+	files := map[string][]ruleGroupContent{}
+	for _, g := range groups {
+		group := ruleGroupContent{name: g.Name, rules: []string{}}
+		for _, r := range g.Rules {
+			kind := "record"
+			if r.Type == "alerting" {
+				kind = "alert"
+			}
+			group.rules = append(group.rules, renderRule(kind, r.Name, r.Query))
+		}
+		file := path.Base(g.File)
+		files[file] = append(files[file], group)
+	}
+	return files, nil
+}
+
+// reloadError returns the error of the last reload of a Prometheus pod, or
+// "" if it succeeded. Prometheus versions without the runtimeinfo API never
+// report a failure. The error is taken from the log of the pod, as the API
+// only tells if the reload failed.
+func reloadError(clients *KubeClientsets, namespace, pod string) string {
+	conn := clients.MainClientset.CoreV1().RESTClient()
+	data, _, err := prometheusAPI(prometheusPodProxy(conn, namespace, pod), "/api/v1/status/runtimeinfo", nil)
+	if err != nil {
+		log.Printf("[DEBUG] Failed to read runtime information of %s/%s: %s", namespace, pod, err)
+		return ""
+	}
+	out := struct {
+		ReloadConfigSuccess *bool `json:"reloadConfigSuccess"`
+	}{}
+	if err := json.Unmarshal(data, &out); err != nil || out.ReloadConfigSuccess == nil || *out.ReloadConfigSuccess {
+		return ""
+	}
+
+	failed := "last reload failed"
+	tail := int64(200)
+	logs, err := clients.MainClientset.CoreV1().Pods(namespace).GetLogs(pod, &v1.PodLogOptions{Container: "prometheus", TailLines: &tail}).Do().Raw()
+	if err != nil {
+		log.Printf("[DEBUG] Failed to read log of %s/%s: %s", namespace, pod, err)
+		return failed
+	}
+	lines := strings.Split(string(logs), "\n")
+	for _, msg := range []string{`msg="loading groups failed"`, `msg="Error reloading config"`} {
+		for i := len(lines) - 1; i >= 0; i-- {
+			if strings.Contains(lines[i], msg) {
+				return failed + ": " + lines[i]
+			}
+		}
+	}
+	return failed
+}
+
+// missingGroups returns the groups of the objects, by file name, which are
+// not in files or whose rules differ there, as "group (file)" followed by the
+// first difference.
+func missingGroups(expected, files map[string][]ruleGroupContent) []string {
+	out := []string{}
+	for file, groups := range expected {
+		loaded := map[string]ruleGroupContent{}
+		for _, g := range files[file] {
+			loaded[g.name] = g
+		}
+		for _, g := range groups {
+			l, ok := loaded[g.name]
+			switch {
+			case !ok:
+				out = append(out, fmt.Sprintf("%s (%s)", g.name, file))
+			case len(l.rules) != len(g.rules):
+				out = append(out, fmt.Sprintf("%s (%s): %d rules instead of %d", g.name, file, len(l.rules), len(g.rules)))
+			default:
+				for i := range g.rules {
+					if l.rules[i] != g.rules[i] {
+						out = append(out, fmt.Sprintf("%s (%s): rule %d is %q instead of %q", g.name, file, i, l.rules[i], g.rules[i]))
+						break
+					}
+				}
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// verifyRulesLoaded waits until the groups of po_prometheus_rule are in the
+// rule ConfigMaps of every Prometheus selecting it and are loaded by it, if
+// verify_loaded is set. Every replica of the Prometheuses is checked through
+// the proxy of its pod.
+func verifyRulesLoaded(d *schema.ResourceData, meta interface{}, timeout time.Duration) error {
+	if !d.Get("verify_loaded").(bool) {
+		return nil
+	}
+	clients := meta.(*KubeClientsets)
+	conn := clients.MainClientset.CoreV1().RESTClient()
+
+	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	shards, err := prometheusRuleShards(d)
+	if err != nil {
+		return err
+	}
+	expected := map[string][]ruleGroupContent{}
+	for _, s := range shards {
+		file := ruleFileName(metadata.Namespace, s.name())
+		expected[file] = []ruleGroupContent{}
+		spec, _ := s["spec"].(map[string]interface{})
+		groups, _ := spec["groups"].([]interface{})
+		for _, g := range groups {
+			in, _ := g.(map[string]interface{})
+			name, _ := in["name"].(string)
+			group := ruleGroupContent{name: name, rules: []string{}}
+			rules, _ := in["rules"].([]interface{})
+			for _, r := range rules {
+				rule, _ := r.(map[string]interface{})
+				group.rules = append(group.rules, renderOperatorRule(rule))
+			}
+			expected[file] = append(expected[file], group)
+		}
+	}
+
+	prometheuses, err := selectingPrometheuses(clients, metadata, ruleSelectors)
+	if err != nil {
+		return err
+	}
+	if len(prometheuses) == 0 {
+		return fmt.Errorf("No Prometheus selects PrometheusRule %s/%s", metadata.Namespace, metadata.Name)
+	}
+
+	log.Printf("[INFO] Waiting for groups of PrometheusRule %s/%s to be loaded by %v", metadata.Namespace, metadata.Name, prometheusIds(prometheuses))
+	last := ""
+	err = wait.PollImmediate(rulesPollInterval, timeout, func() (bool, error) {
+		for _, p := range prometheuses {
+			id := buildId(p.ObjectMeta)
+			files, err := operatorRuleFiles(clients.MainClientset.CoreV1(), p.Namespace, p.Name)
+			if err != nil {
+				return false, err
+			}
+			if missing := missingGroups(expected, files); len(missing) > 0 {
+				last = fmt.Sprintf("the operator didn't add groups to the rule ConfigMaps of Prometheus %s: %s", id, strings.Join(missing, ", "))
+				log.Printf("[DEBUG] Groups of PrometheusRule %s/%s: %s", metadata.Namespace, metadata.Name, last)
+				return false, nil
+			}
+
+			for _, pod := range prometheusPods(p) {
+				files, err = loadedRuleFiles(prometheusPodProxy(conn, p.Namespace, pod))
+				if err != nil {
+					// Prometheus may be restarting.
+					last = fmt.Sprintf("failed to read rules of Prometheus %s, pod %s: %s", id, pod, err)
+					log.Printf("[DEBUG] %s", last)
+					return false, nil
+				}
+				if missing := missingGroups(expected, files); len(missing) > 0 {
+					last = fmt.Sprintf("Prometheus %s, pod %s didn't load groups: %s", id, pod, strings.Join(missing, ", "))
+					if reloadErr := reloadError(clients, p.Namespace, pod); reloadErr != "" {
+						last += "; " + reloadErr
+					}
+					log.Printf("[DEBUG] Groups of PrometheusRule %s/%s: %s", metadata.Namespace, metadata.Name, last)
+					return false, nil
+				}
+			}
+		}
+		return true, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return fmt.Errorf("Timed out waiting for groups of PrometheusRule %s/%s to be loaded, %s", metadata.Namespace, metadata.Name, last)
+	}
+	return err
+}
@@ -0,0 +1,112 @@
+package prometheus_operator
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	monclientv1 "github.com/coreos/prometheus-operator/pkg/client/versioned/typed/monitoring/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	restclient "k8s.io/client-go/rest"
+)
+
+const testPrometheusPodProxy = "/api/v1/namespaces/monitoring/pods/prometheus-k8s"
+
+func TestVerifyRulesLoaded(t *testing.T) {
+	defer func(d time.Duration) { rulesPollInterval = d }(rulesPollInterval)
+	rulesPollInterval = time.Millisecond
+
+	// loaded is the number of groups of the rule file loaded by the second
+	// replica, 3 when it loaded an older version of the second group. The
+	// operator updates the second group on the second check.
+	var checks, loaded int32 = 0, 2
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/apis/monitoring.coreos.com/v1/prometheuses":
+			w.Write([]byte(`{"apiVersion":"monitoring.coreos.com/v1","kind":"PrometheusList","items":[
+				{"metadata":{"name":"k8s","namespace":"monitoring"},"spec":{"replicas":2,"ruleSelector":{"matchLabels":{"role":"alert-rules"}}}},
+				{"metadata":{"name":"other","namespace":"monitoring"},"spec":{"ruleSelector":{"matchLabels":{"role":"other"}}}}
+			]}`))
+		case "/api/v1/namespaces/monitoring/configmaps":
+			if s := r.URL.Query().Get("labelSelector"); s != "prometheus-name=k8s" {
+				t.Errorf("Unexpected label selector %q", s)
+			}
+			groups := `groups:\n- name: a\n  rules:\n  - record: a\n    expr: up\n- name: b\n  rules:\n  - record: b\n    expr: `
+			if atomic.AddInt32(&checks, 1) >= 2 {
+				groups += `up\n`
+			} else {
+				groups += `down\n`
+			}
+			fmt.Fprintf(w, `{"kind":"ConfigMapList","apiVersion":"v1","items":[
+				{"metadata":{"name":"prometheus-k8s-rulefiles-0"},"data":{"monitoring-example.yaml":"%s","monitoring-other.yaml":"groups: []"}}
+			]}`, groups)
+		case testPrometheusPodProxy + "-0:9090/proxy/api/v1/rules", testPrometheusPodProxy + "-1:9090/proxy/api/v1/rules":
+			file := "/etc/prometheus/rules/prometheus-k8s-rulefiles-0/monitoring-example.yaml"
+			groups := []string{`{"name":"a","file":"` + file + `","rules":[{"name":"a","type":"recording","query":"up"}]}`}
+			switch n := atomic.LoadInt32(&loaded); {
+			case strings.Contains(r.URL.Path, "-0:") || n == 2:
+				groups = append(groups, `{"name":"b","file":"`+file+`","rules":[{"name":"b","type":"recording","query":"up"}]}`)
+			case n == 3:
+				groups = append(groups, `{"name":"b","file":"`+file+`","rules":[{"name":"b","type":"recording","query":"down"}]}`)
+			}
+			fmt.Fprintf(w, `{"status":"success","data":{"groups":[%s]}}`, strings.Join(groups, ","))
+		case testPrometheusPodProxy + "-1:9090/proxy/api/v1/status/runtimeinfo":
+			w.Write([]byte(`{"status":"success","data":{"reloadConfigSuccess":false}}`))
+		case "/api/v1/namespaces/monitoring/pods/prometheus-k8s-1/log":
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte("level=info msg=\"Loading configuration file\"\n" +
+				"level=error component=\"rule manager\" msg=\"loading groups failed\" err=\"group \\\"b\\\", rule 1: could not parse expression\"\n"))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	meta := testProxyClientsets(t, server)
+	m, err := monclientv1.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta.MonitoringClient = m
+
+	d := schema.TestResourceDataRaw(t, resourcePOPrometheusRule().Schema, map[string]interface{}{
+		"metadata": []interface{}{map[string]interface{}{
+			"name":      "example",
+			"namespace": "monitoring",
+			"labels":    map[string]interface{}{"role": "alert-rules"},
+		}},
+		"spec": []interface{}{map[string]interface{}{
+			"groups": []interface{}{
+				map[string]interface{}{"name": "a", "rules": []interface{}{map[string]interface{}{"record": "a", "expr": "up"}}},
+				map[string]interface{}{"name": "b", "rules": []interface{}{map[string]interface{}{"record": "b", "expr": "up"}}},
+			},
+		}},
+		"verify_loaded": true,
+	})
+
+	if err := verifyRulesLoaded(d, meta, time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&checks); n != 2 {
+		t.Errorf("Expected 2 checks of the ConfigMaps, got %d", n)
+	}
+
+	atomic.StoreInt32(&loaded, 1)
+	err = verifyRulesLoaded(d, meta, 20*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "Prometheus monitoring/k8s, pod prometheus-k8s-1 didn't load groups: b (monitoring-example.yaml)") ||
+		!strings.Contains(err.Error(), "could not parse expression") {
+		t.Errorf("Expected missing group and reload error, got %v", err)
+	}
+
+	atomic.StoreInt32(&loaded, 3)
+	err = verifyRulesLoaded(d, meta, 20*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), `didn't load groups: b (monitoring-example.yaml): rule 0 is "record b: down" instead of "record b: up"`) {
+		t.Errorf("Expected outdated group, got %v", err)
+	}
+}
@@ -5,233 +5,28 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 )
 
+// RuleGroupSchema returns the schema of the groups of po_prometheus_rule,
+// with the partial response strategy of Thanos Ruler.
 func RuleGroupSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		"name": {
-			Type:        schema.TypeString,
-			Description: "Name of Prometheus Rule.",
-			Required:    true,
-		},
-		"interval": {
-			Type:        schema.TypeString,
-			Description: "",
-			Optional:    true,
-		},
-		"rules": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			Description: "Rules describes an alerting or recording rules",
-			Elem: &schema.Resource{
-				Schema: RuleSchema(),
-			},
-		},
+	s := genRuleGroupSchema()
+	s["partial_response_strategy"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Description:  "How Thanos Ruler handles partial responses of the queries, abort or warn. Ignored by Prometheus.",
+		Optional:     true,
+		ValidateFunc: validation.StringInSlice([]string{"abort", "warn"}, true),
 	}
+	return s
 }
 
-func RuleSchema() map[string]*schema.Schema {
+// alertmanagerEndpointsExtraSchema returns attributes of the alertmanagers of
+// po_prometheus which the operator version doesn't support yet.
+func alertmanagerEndpointsExtraSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
-		"record": {
-			Type:        schema.TypeString,
-			Description: "(empty)",
-			Optional:    true,
-		},
-		"alert": {
-			Type:        schema.TypeString,
-			Description: "(empty)",
-			Optional:    true,
-		},
-		"expr": {
-			Type:        schema.TypeString,
-			Description: "(empty)",
-			Required:    true,
-		},
-		"for": {
-			Type:        schema.TypeString,
-			Description: "(empty)",
-			Optional:    true,
-		},
-		"labels": {
-			Type:         schema.TypeMap,
-			Description:  "(empty)",
-			Optional:     true,
-			Elem:         &schema.Schema{Type: schema.TypeString},
-			ValidateFunc: validateLabels,
-		},
-		"annotations": {
-			Type:         schema.TypeMap,
-			Description:  "(empty)",
-			Optional:     true,
-			Elem:         &schema.Schema{Type: schema.TypeString},
-		},
-	}
-}
-
-func AlertingSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		"alertmanagers": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			Description: "AlertmanagerEndpoints Prometheus should fire alerts against.",
-			Elem: &schema.Resource{
-				Schema: AlertmanagerEndpointsSchema(),
-			},
-		},
-	}
-}
-
-func AlertmanagerEndpointsSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		"namespace": {
-			Type:        schema.TypeString,
-			Description: "Namespace of Endpoints object.",
-			Required:    true,
-		},
-		"name": {
-			Type:        schema.TypeString,
-			Description: "Name of Endpoints object in Namespace.",
-			Required:    true,
-		},
-		"port": {
-			Type:        schema.TypeString,
-			Description: "Port the Alertmanager API is exposed on.",
-			Required:    true,
-		},
-		"path_prefix": {
-			Type:        schema.TypeString,
-			Description: "Prefix for the HTTP path alerts are pushed to.",
-			Optional:    true,
-		},
-		"scheme": {
-			Type:        schema.TypeString,
-			Description: "Scheme to use when firing alerts.",
-			Optional:    true,
-		},
-		"bearer_token_file": {
-			Type:        schema.TypeString,
-			Description: "BearerTokenFile to read from filesystem to use when authenticating to Alertmanager.",
-			Optional:    true,
-		},
 		"api_version": {
 			Type:        schema.TypeString,
-			Description: "Version of the Alertmanager API that Prometheus uses to send alerts. It can be 'v1' or 'v2'.",
-			Optional:    true,
-		},
-		"tls_config": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			MaxItems:    1,
-			Description: "TLS Config to use for alertmanager connection.",
-			Elem: &schema.Resource{
-				Schema: TLSConfigSchema(),
-			},
-		},
-	}
-}
-
-func NamespaceSelectorSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		"any": {
-			Type:        schema.TypeBool,
-			Optional:    true,
-			Description: "Boolean describing whether all namespaces are selected in contrast to a list restricting them.",
-		},
-		"match_names": {
-			Type:     schema.TypeSet,
-			Optional: true,
-			Elem:     &schema.Schema{Type: schema.TypeString},
-			Description: "List of namespace names",
-		},
-	}
-}
-
-func TLSConfigSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		"ca_file": {
-			Type:        schema.TypeString,
-			Description: "Path to the CA cert in the Prometheus container to use for the targets.",
-			Optional:    true,
-		},
-		"ca": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			MaxItems:    1,
-			Description: "Struct containing the CA cert to use for the targets.",
-			Elem: &schema.Resource{
-				Schema: SecretOrConfigMapSchema(),
-			},
-		},
-		"cert_file": {
-			Type:        schema.TypeString,
-			Description: "Path to the client cert file in the Prometheus container for the targets.",
-			Optional:    true,
-		},
-		"cert": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			MaxItems:    1,
-			Description: "Struct containing the client cert file for the targets.",
-			Elem: &schema.Resource{
-				Schema: SecretOrConfigMapSchema(),
-			},
-		},
-		"key_file": {
-			Type:        schema.TypeString,
-			Description: "Path to the client key file in the Prometheus container for the targets.",
-			Optional:    true,
-		},
-		"key_secret": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			MaxItems:    1,
-			Description: "Secret containing data to use for the targets",
-			Elem: &schema.Resource{
-				Schema: SecretKeySelectorSchema(),
-			},
-		},
-		"server_name": {
-			Type:        schema.TypeString,
-			Description: "Used to verify the hostname for the targets.",
+			Description: "Version of the Alertmanager API that Prometheus uses to send alerts. It can be 'v1' or 'v2'. Not supported by the operator yet, and ignored.",
 			Optional:    true,
 		},
-		"insecure_skip_verify": {
-			Type:        schema.TypeBool,
-			Description: "Disable target certificate validation.",
-			Optional:    true,
-		},
-	}
-}
-
-func SecretOrConfigMapSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		"secret": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			MaxItems:    1,
-			Description: "Secret containing data to use for the targets",
-			Elem: &schema.Resource{
-				Schema: SecretKeySelectorSchema(),
-			},
-		},
-		"config_map": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			MaxItems:    1,
-			Description: "Selects a key of a ConfigMap.",
-			Elem: &schema.Resource{
-				Schema: map[string]*schema.Schema{
-					"key": {
-						Type:        schema.TypeString,
-						Optional:    true,
-						Description: "The key to select.",
-					},
-					"name": {
-						Type:        schema.TypeString,
-						Optional:    true,
-						Description: "Name of the referent. More info: http://kubernetes.io/docs/user-guide/identifiers#names",
-					},
-				},
-			},
-		},
 	}
 }
 
@@ -250,160 +45,33 @@ func SecretKeySelectorSchema() map[string]*schema.Schema {
 	}
 }
 
-func BasicAuthSchema() map[string]*schema.Schema {
+func ConfigMapKeySelectorSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
-		"username": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			MaxItems:    1,
-			Description: "The secret in the service monitor namespace that contains the username for authentication.",
-			Elem: &schema.Resource{
-				Schema: SecretKeySelectorSchema(),
-			},
-		},
-		"password": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			MaxItems:    1,
-			Description: "The secret in the service monitor namespace that contains the password for authentication.",
-			Elem: &schema.Resource{
-				Schema: SecretKeySelectorSchema(),
-			},
-		},
-	}
-}
-
-func RelabelConfigSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		"separator": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Description: "Separator placed between concatenated source label values. default is ';'.",
-		},
-		"target_label": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Description: "Label to which the resulting value is written in a replace action. It is mandatory for replace actions. Regex capture groups are available.",
-		},
-		"regex": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Description: "Regular expression against which the extracted value is matched. Default is '(.*)'",
-		},
-		"modulus": {
-			Type:        schema.TypeInt,
-			Optional:    true,
-			Description: "Modulus to take of the hash of the source label values.",
-		},
-		"replacement": {
+		"key": {
 			Type:        schema.TypeString,
 			Optional:    true,
-			Description: "Replacement value against which a regex replace is performed if the regular expression matches. Regex capture groups are available. Default is '$1'",
+			Description: "The key to select.",
 		},
-		"action": {
+		"name": {
 			Type:        schema.TypeString,
 			Optional:    true,
-			Description: "Action to perform based on regex matching. Default is 'replace'",
-		},
-		"source_labels": {
-			Type:     schema.TypeSet,
-			Optional: true,
-			Elem:     &schema.Schema{Type: schema.TypeString},
-			Description: "The source labels select values from existing labels. More info: https://github.com/coreos/prometheus-operator/blob/master/Documentation/api.md#relabelconfig",
+			Description: "Name of the referent. More info: http://kubernetes.io/docs/user-guide/identifiers#names",
 		},
 	}
 }
 
-func EndpointSchema() map[string]*schema.Schema {
+func EndpointParamSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
-		"port": {
-			Type:        schema.TypeString,
-			Description: "Name of the port this endpoint refers to. Mutually exclusive with targetPort. More info: https://github.com/coreos/prometheus-operator/blob/master/Documentation/api.md#servicemonitorspec",
-			Optional:    true,
-		},
-		"path": {
-			Type:        schema.TypeString,
-			Description: "HTTP path to scrape for metrics. 	More info https://github.com/coreos/prometheus-operator/blob/master/Documentation/api.md#servicemonitorspec",
-			Optional:    true,
-		},
-		"scheme": {
-			Type:        schema.TypeString,
-			Description: "HTTP scheme to use for scraping.",
-			Optional:    true,
-		},
-		"interval": {
-			Type:        schema.TypeString,
-			Description: "Interval at which metrics should be scraped.",
-			Optional:    true,
-		},
-		"scrape_timeout": {
-			Type:        schema.TypeString,
-			Description: "Timeout after which the scrape is ended.",
-			Optional:    true,
-		},
-		"tls_config": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			MaxItems:    1,
-			Description: "TLS configuration to use when scraping the endpoint. More info: https://github.com/coreos/prometheus-operator/blob/master/Documentation/api.md#tlsconfig",
-			Elem: &schema.Resource{
-				Schema: TLSConfigSchema(),
-			},
-		},
-		"bearer_token_file": {
+		"name": {
 			Type:        schema.TypeString,
-			Description: "File to read bearer token for scraping targets.",
-			Optional:    true,
-		},
-		"bearer_token_secret": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			MaxItems:    1,
-			Description: "Secret to mount to read bearer token for scraping targets. The secret needs to be in the same namespace as the service monitor and accessible by the Prometheus Operator.",
-			Elem: &schema.Resource{
-				Schema: SecretKeySelectorSchema(),
-			},
-		},
-		"honor_labels": {
-			Type:        schema.TypeBool,
-			Description: "HonorLabels chooses the metric's labels on collisions with target labels.",
-			Optional:    true,
-		},
-		"honor_timestamps": {
-			Type:        schema.TypeBool,
-			Description: "HonorTimestamps controls whether Prometheus respects the timestamps present in scraped data.",
-			Optional:    true,
-			Default:     true,
-		},
-		"basic_auth": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			MaxItems:    1,
-			Description: "BasicAuth allow an endpoint to authenticate over basic authentication More info: https://prometheus.io/docs/operating/configuration/#endpoints",
-			Elem: &schema.Resource{
-				Schema: BasicAuthSchema(),
-			},
-		},
-		"metric_relabelings": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			Description: "MetricRelabelConfigs to apply to samples before ingestion.",
-			Elem: &schema.Resource{
-				Schema: RelabelConfigSchema(),
-			},
+			Description: "Name of the URL parameter.",
+			Required:    true,
 		},
-		"relabelings": {
+		"values": {
 			Type:        schema.TypeList,
-			Optional:    true,
-			Description: "RelabelConfigs to apply to samples before scraping. More info: https://prometheus.io/docs/prometheus/latest/configuration/configuration/#relabel_config",
-			Elem: &schema.Resource{
-				Schema: RelabelConfigSchema(),
-			},
-		},
-		"proxy_url": {
-			Type:        schema.TypeString,
-			Description: "ProxyURL eg http://proxyserver:2195 Directs scrapes to proxy through this endpoint.",
-			Optional:    true,
+			Description: "Values of the URL parameter.",
+			Required:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
 		},
 	}
 }
@@ -483,4 +151,23 @@ func SecurityContextSchema() map[string]*schema.Schema {
 			},
 		},
 	}
-}
\ No newline at end of file
+}
+
+// computedSchema returns a copy of the schema with every attribute computed,
+// for data sources returning blocks of resources.
+func computedSchema(in map[string]*schema.Schema) map[string]*schema.Schema {
+	out := make(map[string]*schema.Schema, len(in))
+	for k, v := range in {
+		s := &schema.Schema{
+			Type:        v.Type,
+			Description: v.Description,
+			Computed:    true,
+			Elem:        v.Elem,
+		}
+		if r, ok := v.Elem.(*schema.Resource); ok {
+			s.Elem = &schema.Resource{Schema: computedSchema(r.Schema)}
+		}
+		out[k] = s
+	}
+	return out
+}
@@ -0,0 +1,90 @@
+package prometheus_operator
+
+import (
+	"fmt"
+	"strings"
+
+	po_types "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	restclient "k8s.io/client-go/rest"
+)
+
+// prometheusPort and alertmanagerPort are the ports of the web servers of
+// Prometheus and Alertmanager pods.
+const (
+	prometheusPort   = 9090
+	alertmanagerPort = 9093
+)
+
+// apiProxy returns a request to path of the HTTP API of a service or pod,
+// through the proxy of the API server.
+type apiProxy func(verb, path string) *restclient.Request
+
+// serviceProxy returns the proxy of a service. service is the name of the
+// service, optionally followed by a colon and the name or number of the
+// port.
+func serviceProxy(client restclient.Interface, namespace, service string) apiProxy {
+	return resourceProxy(client, "services", namespace, service)
+}
+
+// podProxy returns the proxy of a pod. pod is the name of the pod followed
+// by a colon and the number of the port.
+func podProxy(client restclient.Interface, namespace, pod string) apiProxy {
+	return resourceProxy(client, "pods", namespace, pod)
+}
+
+func resourceProxy(client restclient.Interface, resource, namespace, name string) apiProxy {
+	return func(verb, path string) *restclient.Request {
+		return client.Verb(verb).
+			Namespace(namespace).
+			Resource(resource).
+			Name(name).
+			SubResource("proxy").
+			Suffix(strings.TrimPrefix(path, "/")).
+			SetHeader("Content-Type", "application/json")
+	}
+}
+
+// prometheusPods returns the pods of the replicas of a Prometheus. The
+// operator names them prometheus-<name>-<replica>.
+func prometheusPods(p *po_types.Prometheus) []string {
+	replicas := 1
+	if p.Spec.Replicas != nil {
+		replicas = int(*p.Spec.Replicas)
+	}
+	pods := make([]string, replicas)
+	for i := range pods {
+		pods[i] = prometheusPod(p.Name, i)
+	}
+	return pods
+}
+
+func prometheusPod(name string, replica int) string {
+	return fmt.Sprintf("prometheus-%s-%d", name, replica)
+}
+
+// prometheusPodProxy returns the proxy of the web server of a Prometheus
+// pod.
+func prometheusPodProxy(client restclient.Interface, namespace, pod string) apiProxy {
+	return podProxy(client, namespace, fmt.Sprintf("%s:%d", pod, prometheusPort))
+}
+
+// prometheusProxy returns the proxy of the Prometheus of a data source. The
+// service is used if set, otherwise the first replica of the Prometheus is
+// reached through its pod.
+func prometheusProxy(client restclient.Interface, d resourceGetter) (apiProxy, error) {
+	namespace, name, err := idParts(d.Get("prometheus").(string))
+	if err != nil {
+		return nil, err
+	}
+	if service := d.Get("service").(string); service != "" {
+		return serviceProxy(client, namespace, service), nil
+	}
+	return prometheusPodProxy(client, namespace, prometheusPod(name, 0)), nil
+}
+
+// alertmanagerPodProxy returns the proxy of the web server of the first
+// replica of an Alertmanager, alertmanager-<name>-0. Replicas share their
+// silences.
+func alertmanagerPodProxy(client restclient.Interface, namespace, name string) apiProxy {
+	return podProxy(client, namespace, fmt.Sprintf("alertmanager-%s-0:%d", name, alertmanagerPort))
+}
@@ -0,0 +1,252 @@
+package prometheus_operator
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/promql"
+)
+
+// sloWindowPlaceholder is replaced by the range of every recording window in
+// SLI queries.
+const sloWindowPlaceholder = "{{.window}}"
+
+// sloWindows are the short and long windows of the multi-window,
+// multi-burn-rate alerts recommended by the SRE workbook.
+var sloWindows = []string{"5m", "30m", "1h", "2h", "6h", "1d", "3d"}
+
+// sloBurnRateAlert is a pair of windows, which both have to burn the given
+// fraction of the error budget of the whole time window to fire.
+type sloBurnRateAlert struct {
+	short, long    string
+	budgetFraction float64
+}
+
+var (
+	sloPageAlerts = []sloBurnRateAlert{
+		{short: "5m", long: "1h", budgetFraction: 0.02},
+		{short: "30m", long: "6h", budgetFraction: 0.05},
+	}
+	sloTicketAlerts = []sloBurnRateAlert{
+		{short: "2h", long: "1d", budgetFraction: 0.1},
+		{short: "6h", long: "3d", budgetFraction: 0.1},
+	}
+)
+
+// sloSpec is the expanded spec of po_slo.
+type sloSpec struct {
+	Name       string
+	Objective  float64
+	TimeWindow string
+	ErrorQuery string
+	GoodQuery  string
+	TotalQuery string
+	Alerting   *sloAlerting
+}
+
+type sloAlerting struct {
+	Name           string
+	Labels         map[string]string
+	Annotations    map[string]string
+	PageSeverity   string
+	TicketSeverity string
+}
+
+// validateSLIQuery checks that the query has the window placeholder and
+// parses into an instant vector for every window.
+func validateSLIQuery(v interface{}, k string) (ws []string, es []error) {
+	q := v.(string)
+	if !strings.Contains(q, sloWindowPlaceholder) {
+		es = append(es, fmt.Errorf("%s: query must use %s as the range of its range vectors", k, sloWindowPlaceholder))
+		return
+	}
+	expr, err := promql.ParseExpr(sliQuery(q, "5m"))
+	if err != nil {
+		es = append(es, fmt.Errorf("%s: %s", k, err))
+		return
+	}
+	if t := expr.Type(); t != promql.ValueTypeVector && t != promql.ValueTypeScalar {
+		es = append(es, fmt.Errorf("%s: query must return an instant vector, got %s", k, t))
+	}
+	return
+}
+
+func validateSLOTimeWindow(v interface{}, k string) (ws []string, es []error) {
+	d, err := model.ParseDuration(v.(string))
+	if err != nil {
+		es = append(es, fmt.Errorf("%s: %s", k, err))
+		return
+	}
+	if time.Duration(d) < 7*24*time.Hour {
+		es = append(es, fmt.Errorf("%s: time window must be at least 7d, got %s", k, d))
+	}
+	return
+}
+
+func validateSLOObjective(v interface{}, k string) (ws []string, es []error) {
+	if o := v.(float64); o <= 0 || o >= 100 {
+		es = append(es, fmt.Errorf("%s: objective must be a percentage between 0 and 100 exclusive, got %v", k, o))
+	}
+	return
+}
+
+func sliQuery(q, window string) string {
+	return strings.Replace(q, sloWindowPlaceholder, window, -1)
+}
+
+// errorRatioQuery returns the query of the ratio of bad events in window.
+func (s *sloSpec) errorRatioQuery(window string) string {
+	total := sliQuery(s.TotalQuery, window)
+	if s.ErrorQuery != "" {
+		return fmt.Sprintf("(%s)\n/\n(%s)", sliQuery(s.ErrorQuery, window), total)
+	}
+	return fmt.Sprintf("1 - (\n(%s)\n/\n(%s)\n)", sliQuery(s.GoodQuery, window), total)
+}
+
+func (s *sloSpec) selector() string {
+	return fmt.Sprintf("{slo=%q}", s.Name)
+}
+
+func (s *sloSpec) errorBudget() float64 {
+	return 1 - s.Objective/100
+}
+
+// burnRate returns the burn rate at which the fraction of the error budget of
+// the time window is spent in window.
+func (s *sloSpec) burnRate(fraction float64, window string) (float64, error) {
+	tw, err := model.ParseDuration(s.TimeWindow)
+	if err != nil {
+		return 0, err
+	}
+	w, err := model.ParseDuration(window)
+	if err != nil {
+		return 0, err
+	}
+	return fraction * float64(tw) / float64(w), nil
+}
+
+// ruleGroups returns the rule groups of the SLO, in the form of the groups
+// attribute of po_prometheus_rule.
+func (s *sloSpec) ruleGroups() ([]interface{}, error) {
+	if _, err := model.ParseDuration(s.TimeWindow); err != nil {
+		return nil, err
+	}
+	labels := map[string]interface{}{"slo": s.Name}
+
+	recordings := make([]interface{}, 0, len(sloWindows)+3)
+	for _, w := range sloWindows {
+		recordings = append(recordings, map[string]interface{}{
+			"record": "slo:sli_error:ratio_rate" + w,
+			"expr":   s.errorRatioQuery(w),
+			"labels": labels,
+		})
+	}
+	// The ratio over the whole time window is averaged from the 5m ratio,
+	// instead of querying the raw series over weeks. The window is named as
+	// configured, as durations are printed in the largest unit, e.g. 28d as
+	// 4w.
+	rate5m := "slo:sli_error:ratio_rate5m" + s.selector()
+	recordings = append(recordings,
+		map[string]interface{}{
+			"record": "slo:sli_error:ratio_rate" + s.TimeWindow,
+			"expr":   fmt.Sprintf("sum_over_time(%[1]s[%[2]s])\n/\ncount_over_time(%[1]s[%[2]s])", rate5m, s.TimeWindow),
+			"labels": labels,
+		},
+		map[string]interface{}{
+			"record": "slo:objective:ratio",
+			"expr":   formatRatio(s.Objective / 100),
+			"labels": labels,
+		},
+		map[string]interface{}{
+			"record": "slo:error_budget:ratio",
+			"expr":   formatRatio(s.errorBudget()),
+			"labels": labels,
+		},
+	)
+
+	groups := []interface{}{
+		map[string]interface{}{
+			"name":  fmt.Sprintf("slo-%s-recordings", s.Name),
+			"rules": recordings,
+		},
+	}
+	if s.Alerting == nil {
+		return groups, nil
+	}
+
+	page, err := s.alertRule(s.Alerting.PageSeverity, sloPageAlerts)
+	if err != nil {
+		return nil, err
+	}
+	ticket, err := s.alertRule(s.Alerting.TicketSeverity, sloTicketAlerts)
+	if err != nil {
+		return nil, err
+	}
+	groups = append(groups, map[string]interface{}{
+		"name":  fmt.Sprintf("slo-%s-alerts", s.Name),
+		"rules": []interface{}{page, ticket},
+	})
+	return groups, nil
+}
+
+func (s *sloSpec) alertRule(severity string, alerts []sloBurnRateAlert) (map[string]interface{}, error) {
+	conditions := make([]string, len(alerts))
+	for i, a := range alerts {
+		rate, err := s.burnRate(a.budgetFraction, a.long)
+		if err != nil {
+			return nil, err
+		}
+		threshold := fmt.Sprintf("(%s * %s)", formatRatio(rate), formatRatio(s.errorBudget()))
+		conditions[i] = fmt.Sprintf("(\n  slo:sli_error:ratio_rate%[1]s%[3]s > %[4]s\n  and\n  slo:sli_error:ratio_rate%[2]s%[3]s > %[4]s\n)", a.short, a.long, s.selector(), threshold)
+	}
+
+	labels := map[string]interface{}{"slo": s.Name}
+	for k, v := range s.Alerting.Labels {
+		labels[k] = v
+	}
+	labels["severity"] = severity
+	annotations := map[string]interface{}{}
+	for k, v := range s.Alerting.Annotations {
+		annotations[k] = v
+	}
+	return map[string]interface{}{
+		"alert":       s.Alerting.Name,
+		"expr":        strings.Join(conditions, "\nor\n"),
+		"labels":      labels,
+		"annotations": annotations,
+	}, nil
+}
+
+func formatRatio(f float64) string {
+	return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.6f", f), "0"), ".")
+}
+
+func expandSLOSpec(name string, l []interface{}) *sloSpec {
+	obj := &sloSpec{Name: name}
+	if len(l) == 0 || l[0] == nil {
+		return obj
+	}
+	in := l[0].(map[string]interface{})
+
+	obj.Objective = in["objective"].(float64)
+	obj.TimeWindow = in["time_window"].(string)
+	if v, ok := in["sli"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		sli := v[0].(map[string]interface{})
+		obj.ErrorQuery = sli["error_query"].(string)
+		obj.GoodQuery = sli["good_query"].(string)
+		obj.TotalQuery = sli["total_query"].(string)
+	}
+	if v, ok := in["alerting"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		a := v[0].(map[string]interface{})
+		obj.Alerting = &sloAlerting{
+			Name:           a["name"].(string),
+			Labels:         expandStringMap(a["labels"].(map[string]interface{})),
+			Annotations:    expandStringMap(a["annotations"].(map[string]interface{})),
+			PageSeverity:   a["page_severity"].(string),
+			TicketSeverity: a["ticket_severity"].(string),
+		}
+	}
+	return obj
+}
@@ -0,0 +1,311 @@
+package prometheus_operator
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"k8s.io/apimachinery/pkg/runtime"
+	restclient "k8s.io/client-go/rest"
+	"sigs.k8s.io/yaml"
+)
+
+var specOverrideFormats = []string{"json", "yaml"}
+
+// specOverrideSchema returns the spec_override_<format> attribute, which
+// holds spec fields not modeled by the typed spec block.
+func specOverrideSchema(format string) *schema.Schema {
+	conflicts := []string{}
+	for _, f := range specOverrideFormats {
+		if f != format {
+			conflicts = append(conflicts, "spec_override_"+f)
+		}
+	}
+	return &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		ConflictsWith: conflicts,
+		Description:   fmt.Sprintf("Fields in %s, deep-merged over the spec built from typed attributes. Setting a field which is also set by typed attributes is an error, reported during plan unless the typed value is known only after apply.", strings.ToUpper(format)),
+		ValidateFunc: func(v interface{}, k string) (ws []string, es []error) {
+			if _, err := parseSpecOverride(v.(string)); err != nil {
+				es = append(es, fmt.Errorf("%s: %s", k, err))
+			}
+			return
+		},
+		DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+			o, err := parseSpecOverride(old)
+			if err != nil {
+				return false
+			}
+			n, err := parseSpecOverride(new)
+			if err != nil {
+				return false
+			}
+			return reflect.DeepEqual(o, n)
+		},
+	}
+}
+
+func parseSpecOverride(s string) (map[string]interface{}, error) {
+	return parseObject(s, "spec override")
+}
+
+// parseObject decodes a JSON or YAML object, YAML being a superset of JSON.
+func parseObject(s, what string) (map[string]interface{}, error) {
+	out := map[string]interface{}{}
+	if strings.TrimSpace(s) == "" {
+		return out, nil
+	}
+	data, err := yaml.YAMLToJSON([]byte(s))
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must be an object, got %s", what, strings.TrimSpace(string(data)))
+	}
+	return m, nil
+}
+
+// specOverride returns the attribute holding the spec override and its
+// parsed value, or an empty attribute name if none is set.
+func specOverride(d resourceGetter) (string, map[string]interface{}, error) {
+	for _, f := range specOverrideFormats {
+		k := "spec_override_" + f
+		if v, ok := d.Get(k).(string); ok && v != "" {
+			override, err := parseSpecOverride(v)
+			if err != nil {
+				return "", nil, fmt.Errorf("Failed to parse %s: %s", k, err)
+			}
+			return k, override, nil
+		}
+	}
+	return "", nil, nil
+}
+
+func hasSpecOverrideChange(d resourceGetter) bool {
+	for _, f := range specOverrideFormats {
+		if d.HasChange("spec_override_" + f) {
+			return true
+		}
+	}
+	return false
+}
+
+// specOverrideDiff merges the spec override during plan, so fields also set
+// by typed attributes are reported before apply. Typed values known only
+// after apply are empty during plan, conflicts with them are reported by
+// apply.
+func specOverrideDiff(build objectBuilder) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		for _, f := range specOverrideFormats {
+			if k := "spec_override_" + f; !d.NewValueKnown(k) {
+				log.Printf("[DEBUG] Skipping merge of the spec override of %q, %s is known only after apply", d.Id(), k)
+				return nil
+			}
+		}
+		if k, _, err := specOverride(d); err != nil || k == "" {
+			return err
+		}
+		_, err := build(d)
+		return err
+	}
+}
+
+// mergedSpec returns spec with the override merged in.
+func mergedSpec(d resourceGetter, spec interface{}) (interface{}, error) {
+	k, override, err := specOverride(d)
+	if err != nil || k == "" {
+		return spec, err
+	}
+	m, err := toUnstructured(spec)
+	if err != nil {
+		return nil, err
+	}
+	if err := mergeSpecOverride(k, "spec", m, override); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// objectWithSpecOverride returns the JSON body used to create obj.
+func objectWithSpecOverride(d resourceGetter, obj interface{}) ([]byte, error) {
+	m, err := toUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	spec, _ := m["spec"].(map[string]interface{})
+	if m["spec"], err = mergedSpec(d, spec); err != nil {
+		return nil, err
+	}
+	return json.Marshal(m)
+}
+
+// mergeSpecOverride deep-merges override into spec. Fields are merged
+// recursively into objects, other values set in both are conflicts.
+func mergeSpecOverride(key, path string, spec, override map[string]interface{}) error {
+	for _, k := range sortedKeys(override) {
+		ov := override[k]
+		sv, ok := spec[k]
+		if !ok || isEmptyValue(sv) {
+			spec[k] = ov
+			continue
+		}
+		sm, sIsMap := sv.(map[string]interface{})
+		om, oIsMap := ov.(map[string]interface{})
+		if sIsMap && oIsMap {
+			if err := mergeSpecOverride(key, path+"."+k, sm, om); err != nil {
+				return err
+			}
+			continue
+		}
+		return fmt.Errorf("%s: %s.%s is already set by typed attributes", key, path, k)
+	}
+	return nil
+}
+
+// projectSpecOverride returns the fields of spec set by override.
+func projectSpecOverride(spec, override map[string]interface{}) map[string]interface{} {
+	out := map[string]interface{}{}
+	for k, ov := range override {
+		sv, ok := spec[k]
+		if !ok {
+			continue
+		}
+		sm, sIsMap := sv.(map[string]interface{})
+		om, oIsMap := ov.(map[string]interface{})
+		if sIsMap && oIsMap {
+			out[k] = projectSpecOverride(sm, om)
+			continue
+		}
+		out[k] = sv
+	}
+	return out
+}
+
+// removeSpecOverride deletes the fields set by override from spec.
+func removeSpecOverride(spec, override map[string]interface{}) {
+	for k, ov := range override {
+		sm, sIsMap := spec[k].(map[string]interface{})
+		om, oIsMap := ov.(map[string]interface{})
+		if sIsMap && oIsMap {
+			removeSpecOverride(sm, om)
+			continue
+		}
+		delete(spec, k)
+	}
+}
+
+// createObject creates the object from its JSON body, decoding the response into out.
+func createObject(client restclient.Interface, resource, namespace string, body []byte, out runtime.Object) error {
+	return client.Post().
+		Namespace(namespace).
+		Resource(resource).
+		Body(body).
+		Do().
+		Into(out)
+}
+
+// readObject reads the object into out. Fields managed by the spec override
+// are stored back in its attribute and left out of out, so they don't show
+// up as changes of typed attributes.
+func readObject(d *schema.ResourceData, client restclient.Interface, resource, namespace, name string, out interface{}) error {
+	data, err := client.Get().
+		Namespace(namespace).
+		Resource(resource).
+		Name(name).
+		Do().
+		Raw()
+	if err != nil {
+		return err
+	}
+	return decodeObject(d, data, out)
+}
+
+// decodeObject decodes the JSON of an object read from the cluster into out,
+// storing fields managed by the spec override back in its attribute.
+func decodeObject(d *schema.ResourceData, data []byte, out interface{}) error {
+	k, override, err := specOverride(d)
+	if err != nil {
+		return err
+	}
+	if k != "" {
+		obj := map[string]interface{}{}
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return err
+		}
+		spec, _ := obj["spec"].(map[string]interface{})
+		if spec == nil {
+			spec = map[string]interface{}{}
+		}
+		v, err := formatObject(k, projectSpecOverride(spec, override))
+		if err != nil {
+			return err
+		}
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+		removeSpecOverride(spec, override)
+		if data, err = json.Marshal(obj); err != nil {
+			return err
+		}
+	}
+	return json.Unmarshal(data, out)
+}
+
+// formatObject encodes v in the format of the attribute key.
+func formatObject(key string, v map[string]interface{}) (string, error) {
+	if strings.HasSuffix(key, "_yaml") {
+		data, err := yaml.Marshal(v)
+		return string(data), err
+	}
+	data, err := json.Marshal(v)
+	return string(data), err
+}
+
+func toUnstructured(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func isEmptyValue(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case float64:
+		return v == 0
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,156 @@
+package prometheus_operator
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	po_types "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func testServiceMonitorData(t *testing.T, override map[string]interface{}) *schema.ResourceData {
+	raw := map[string]interface{}{
+		"metadata": []interface{}{
+			map[string]interface{}{
+				"name":      "example",
+				"namespace": "monitoring",
+			},
+		},
+		"spec": []interface{}{
+			map[string]interface{}{
+				"selector": []interface{}{
+					map[string]interface{}{
+						"match_labels": map[string]interface{}{"app": "example"},
+					},
+				},
+				"endpoints": []interface{}{
+					map[string]interface{}{
+						"port":     "web",
+						"interval": "30s",
+					},
+				},
+			},
+		},
+	}
+	for k, v := range override {
+		raw[k] = v
+	}
+	return schema.TestResourceDataRaw(t, resourcePOServiceMonitor().Schema, raw)
+}
+
+func TestObjectWithSpecOverride(t *testing.T) {
+	d := testServiceMonitorData(t, map[string]interface{}{
+		"spec_override_yaml": "sampleLimit: 1000\nnamespaceSelector:\n  matchNames: [default]\n",
+	})
+	body, err := serviceMonitorObject(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(body, &obj); err != nil {
+		t.Fatal(err)
+	}
+	if obj["kind"] != "ServiceMonitor" || obj["apiVersion"] != "monitoring.coreos.com/v1" {
+		t.Errorf("Expected type meta to be set, got %v %v", obj["kind"], obj["apiVersion"])
+	}
+	spec := obj["spec"].(map[string]interface{})
+	if spec["sampleLimit"] != float64(1000) {
+		t.Errorf("Expected sampleLimit from override, got %#v", spec["sampleLimit"])
+	}
+	if ns := spec["namespaceSelector"].(map[string]interface{}); !reflect.DeepEqual(ns["matchNames"], []interface{}{"default"}) {
+		t.Errorf("Expected namespaceSelector to be merged, got %#v", ns)
+	}
+	if endpoints := spec["endpoints"].([]interface{}); len(endpoints) != 1 {
+		t.Errorf("Expected typed endpoints to be kept, got %#v", endpoints)
+	}
+}
+
+func TestObjectWithSpecOverride_conflict(t *testing.T) {
+	d := testServiceMonitorData(t, map[string]interface{}{
+		"spec_override_json": `{"selector": {"matchLabels": {"app": "other"}}}`,
+	})
+	_, err := serviceMonitorObject(d)
+	if err == nil {
+		t.Fatal("Expected conflict with typed attributes")
+	}
+	if !strings.Contains(err.Error(), "spec.selector.matchLabels.app is already set by typed attributes") {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
+
+func TestSpecOverrideDiff_conflict(t *testing.T) {
+	raw := map[string]interface{}{
+		"metadata": []interface{}{map[string]interface{}{"name": "example", "namespace": "monitoring"}},
+		"spec": []interface{}{map[string]interface{}{
+			"selector":  []interface{}{map[string]interface{}{"match_labels": map[string]interface{}{"app": "example"}}},
+			"endpoints": []interface{}{map[string]interface{}{"port": "web"}},
+		}},
+		"spec_override_json": `{"selector": {"matchLabels": {"app": "other"}}}`,
+	}
+	// Schema validation is off, so the conflict is reported by the merge.
+	_, err := resourcePOServiceMonitor().Diff(nil, terraform.NewResourceConfigRaw(raw), &KubeClientsets{})
+	if err == nil || !strings.Contains(err.Error(), "spec.selector.matchLabels.app is already set by typed attributes") {
+		t.Errorf("Expected conflict during plan, got %v", err)
+	}
+
+	raw["spec_override_json"] = `{"sampleLimit": 1000}`
+	if _, err := resourcePOServiceMonitor().Diff(nil, terraform.NewResourceConfigRaw(raw), &KubeClientsets{}); err != nil {
+		t.Errorf("Expected override without conflicts to be planned, got %s", err)
+	}
+}
+
+func TestReadObject_specOverride(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apis/monitoring.coreos.com/v1/namespaces/monitoring/servicemonitors/example" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"apiVersion": "monitoring.coreos.com/v1",
+			"kind": "ServiceMonitor",
+			"metadata": {"name": "example", "namespace": "monitoring"},
+			"spec": {
+				"selector": {"matchLabels": {"app": "example"}},
+				"endpoints": [{"port": "web", "interval": "30s"}],
+				"sampleLimit": 1000,
+				"targetLimit": 50
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	d := testServiceMonitorData(t, map[string]interface{}{
+		"spec_override_json": `{"targetLimit": 50, "sampleLimit": 1000}`,
+	})
+	m := testMonitoringClient(t, server, 0)
+	out := &po_types.ServiceMonitor{}
+	if err := readObject(d, m.RESTClient(), "servicemonitors", "monitoring", "example", out); err != nil {
+		t.Fatal(err)
+	}
+
+	if v := d.Get("spec_override_json").(string); v != `{"sampleLimit":1000,"targetLimit":50}` {
+		t.Errorf("Expected only override fields to be read back, got %s", v)
+	}
+	if out.Spec.SampleLimit != 0 {
+		t.Errorf("Expected fields managed by override to be left out of typed spec, got %d", out.Spec.SampleLimit)
+	}
+	if len(out.Spec.Endpoints) != 1 || out.Spec.Endpoints[0].Port != "web" {
+		t.Errorf("Expected typed fields to be read, got %#v", out.Spec.Endpoints)
+	}
+}
+
+func TestSpecOverrideSchema_diffSuppress(t *testing.T) {
+	s := specOverrideSchema("yaml")
+	if !s.DiffSuppressFunc("spec_override_yaml", "{\"a\":1,\"b\":[\"x\"]}", "b:\n- x\na: 1\n", nil) {
+		t.Error("Expected semantically equal overrides to suppress the diff")
+	}
+	if s.DiffSuppressFunc("spec_override_yaml", "a: 1\n", "a: 2\n", nil) {
+		t.Error("Expected different overrides to produce a diff")
+	}
+}
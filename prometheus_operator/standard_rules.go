@@ -0,0 +1,356 @@
+package prometheus_operator
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/prometheus/prometheus/promql"
+)
+
+// standardRulesLatest is the version of the standard rules used if the data
+// source doesn't pin one. Released versions are never changed, changes of
+// the rules go into a new version.
+const standardRulesLatest = "1"
+
+// standardRuleBundle is the rule group of one component. The expressions are
+// templates with [[ ]] delimiters, so they don't clash with the templates of
+// Prometheus. In them, selector returns the matchers of the bundle followed
+// by the given extra matchers, and the thresholds are fields of the data.
+type standardRuleBundle struct {
+	name     string
+	selector string
+	rules    []standardRule
+}
+
+type standardRule struct {
+	alert       string
+	expr        string
+	duration    string
+	severity    string
+	summary     string
+	description string
+}
+
+var standardRuleThresholds = map[string]float64{
+	"node_filesystem_free_percent":              10,
+	"node_memory_used_percent":                  90,
+	"node_cpu_used_percent":                     90,
+	"kubelet_pleg_duration_seconds":             10,
+	"apiserver_error_percent":                   3,
+	"apiserver_latency_seconds":                 1,
+	"alertmanager_notification_failure_percent": 1,
+}
+
+var standardRuleSeverities = map[string]bool{"critical": true, "warning": true}
+
+var standardRules = map[string][]standardRuleBundle{
+	"1": {
+		{
+			name:     "node",
+			selector: `job="node-exporter"`,
+			rules: []standardRule{
+				{
+					alert:       "NodeExporterDown",
+					expr:        `up[[selector]] == 0`,
+					duration:    "5m",
+					severity:    "critical",
+					summary:     "Node exporter is down.",
+					description: "Node exporter {{ $labels.instance }} has been unreachable for 5 minutes.",
+				},
+				{
+					alert: "NodeFilesystemAlmostOutOfSpace",
+					expr: `node_filesystem_avail_bytes[[selector "fstype!=\"\""]] / node_filesystem_size_bytes[[selector "fstype!=\"\""]] * 100 < [[.node_filesystem_free_percent]]
+and
+node_filesystem_readonly[[selector "fstype!=\"\""]] == 0`,
+					duration:    "30m",
+					severity:    "warning",
+					summary:     "Filesystem is almost out of space.",
+					description: "Filesystem {{ $labels.mountpoint }} of {{ $labels.instance }} has {{ printf \"%.2f\" $value }}% space left.",
+				},
+				{
+					alert:       "NodeMemoryHigh",
+					expr:        `(1 - node_memory_MemAvailable_bytes[[selector]] / node_memory_MemTotal_bytes[[selector]]) * 100 > [[.node_memory_used_percent]]`,
+					duration:    "15m",
+					severity:    "warning",
+					summary:     "Node memory is almost exhausted.",
+					description: "{{ $labels.instance }} uses {{ printf \"%.2f\" $value }}% of its memory.",
+				},
+				{
+					alert:       "NodeCPUHigh",
+					expr:        `100 - avg by (instance) (rate(node_cpu_seconds_total[[selector "mode=\"idle\""]][5m])) * 100 > [[.node_cpu_used_percent]]`,
+					duration:    "30m",
+					severity:    "warning",
+					summary:     "Node CPU usage is high.",
+					description: "{{ $labels.instance }} uses {{ printf \"%.2f\" $value }}% of its CPU.",
+				},
+			},
+		},
+		{
+			name:     "kubelet",
+			selector: `job="kubelet"`,
+			rules: []standardRule{
+				{
+					alert:       "KubeletDown",
+					expr:        `up[[selector]] == 0`,
+					duration:    "15m",
+					severity:    "critical",
+					summary:     "Kubelet is down.",
+					description: "Kubelet {{ $labels.instance }} has been unreachable for 15 minutes.",
+				},
+				{
+					alert:       "KubeletPLEGDurationHigh",
+					expr:        `histogram_quantile(0.99, sum by (instance, le) (rate(kubelet_pleg_relist_duration_seconds_bucket[[selector]][5m]))) >= [[.kubelet_pleg_duration_seconds]]`,
+					duration:    "5m",
+					severity:    "warning",
+					summary:     "Kubelet pod lifecycle event generator is slow.",
+					description: "The 99th percentile duration of PLEG relisting of {{ $labels.instance }} is {{ $value }} seconds.",
+				},
+			},
+		},
+		{
+			name:     "apiserver",
+			selector: `job="apiserver"`,
+			rules: []standardRule{
+				{
+					alert:       "KubeAPIDown",
+					expr:        `absent(up[[selector]] == 1)`,
+					duration:    "15m",
+					severity:    "critical",
+					summary:     "Kubernetes API server is down.",
+					description: "No Kubernetes API server has been reachable for 15 minutes.",
+				},
+				{
+					alert:       "KubeAPIErrorsHigh",
+					expr:        `sum(rate(apiserver_request_total[[selector "code=~\"5..\""]][5m])) / sum(rate(apiserver_request_total[[selector]][5m])) * 100 > [[.apiserver_error_percent]]`,
+					duration:    "10m",
+					severity:    "critical",
+					summary:     "Kubernetes API server returns errors.",
+					description: "{{ printf \"%.2f\" $value }}% of the requests to the API server fail.",
+				},
+				{
+					alert:       "KubeAPILatencyHigh",
+					expr:        `histogram_quantile(0.99, sum by (verb, le) (rate(apiserver_request_duration_seconds_bucket[[selector "verb!~\"WATCH|CONNECT\""]][5m]))) > [[.apiserver_latency_seconds]]`,
+					duration:    "10m",
+					severity:    "warning",
+					summary:     "Kubernetes API server is slow.",
+					description: "The 99th percentile latency of {{ $labels.verb }} requests to the API server is {{ $value }} seconds.",
+				},
+			},
+		},
+		{
+			name:     "prometheus",
+			selector: `job="prometheus-k8s"`,
+			rules: []standardRule{
+				{
+					alert:       "PrometheusBadConfig",
+					expr:        `max_over_time(prometheus_config_last_reload_successful[[selector]][5m]) == 0`,
+					duration:    "10m",
+					severity:    "critical",
+					summary:     "Prometheus failed to reload its configuration.",
+					description: "Prometheus {{ $labels.namespace }}/{{ $labels.pod }} failed to reload its configuration.",
+				},
+				{
+					alert:       "PrometheusRuleFailures",
+					expr:        `increase(prometheus_rule_evaluation_failures_total[[selector]][5m]) > 0`,
+					duration:    "15m",
+					severity:    "critical",
+					summary:     "Prometheus fails to evaluate rules.",
+					description: "Prometheus {{ $labels.namespace }}/{{ $labels.pod }} failed to evaluate {{ printf \"%.0f\" $value }} rules in the last 5m.",
+				},
+				{
+					alert:       "PrometheusNotConnectedToAlertmanagers",
+					expr:        `max_over_time(prometheus_notifications_alertmanagers_discovered[[selector]][5m]) < 1`,
+					duration:    "10m",
+					severity:    "warning",
+					summary:     "Prometheus is not connected to any Alertmanager.",
+					description: "Prometheus {{ $labels.namespace }}/{{ $labels.pod }} doesn't send alerts to any Alertmanager.",
+				},
+				{
+					alert:       "PrometheusTSDBReloadsFailing",
+					expr:        `increase(prometheus_tsdb_reloads_failures_total[[selector]][3h]) > 0`,
+					duration:    "4h",
+					severity:    "warning",
+					summary:     "Prometheus has issues reloading blocks from disk.",
+					description: "Prometheus {{ $labels.namespace }}/{{ $labels.pod }} had {{ printf \"%.0f\" $value }} reload failures in the last 3h.",
+				},
+			},
+		},
+		{
+			name:     "alertmanager",
+			selector: `job="alertmanager-main"`,
+			rules: []standardRule{
+				{
+					alert:       "AlertmanagerFailedReload",
+					expr:        `max_over_time(alertmanager_config_last_reload_successful[[selector]][5m]) == 0`,
+					duration:    "10m",
+					severity:    "critical",
+					summary:     "Alertmanager failed to reload its configuration.",
+					description: "Alertmanager {{ $labels.namespace }}/{{ $labels.pod }} failed to reload its configuration.",
+				},
+				{
+					alert:       "AlertmanagerConfigInconsistent",
+					expr:        `count by (service) (count_values by (service) ("config_hash", alertmanager_config_hash[[selector]])) != 1`,
+					duration:    "5m",
+					severity:    "critical",
+					summary:     "Alertmanagers of a cluster have different configurations.",
+					description: "The Alertmanagers of {{ $labels.service }} aren't running the same configuration.",
+				},
+				{
+					alert:       "AlertmanagerNotificationsFailing",
+					expr:        `rate(alertmanager_notifications_failed_total[[selector]][5m]) / rate(alertmanager_notifications_total[[selector]][5m]) * 100 > [[.alertmanager_notification_failure_percent]]`,
+					duration:    "5m",
+					severity:    "warning",
+					summary:     "Alertmanager fails to send notifications.",
+					description: "Alertmanager {{ $labels.namespace }}/{{ $labels.pod }} fails to send {{ printf \"%.2f\" $value }}% of the notifications to {{ $labels.integration }}.",
+				},
+			},
+		},
+	},
+}
+
+// standardRuleBundleNames returns the names of the bundles of all versions.
+func standardRuleBundleNames() []string {
+	names := map[string]bool{}
+	for _, bundles := range standardRules {
+		for _, b := range bundles {
+			names[b.name] = true
+		}
+	}
+	out := make([]string, 0, len(names))
+	for n := range names {
+		out = append(out, n)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func standardRuleVersions() []string {
+	out := make([]string, 0, len(standardRules))
+	for v := range standardRules {
+		out = append(out, v)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// standardRulesConfig are the parameters of the standard rules.
+type standardRulesConfig struct {
+	Version     string
+	Bundles     map[string]bool
+	Selectors   map[string]string
+	Thresholds  map[string]float64
+	Severities  map[string]string
+	RunbookURL  string
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// ruleGroups renders the selected bundles, in the form of the groups
+// attribute of po_prometheus_rule.
+func (c *standardRulesConfig) ruleGroups() ([]interface{}, error) {
+	bundles, ok := standardRules[c.Version]
+	if !ok {
+		return nil, fmt.Errorf("Unknown version %q of the standard rules, expected one of %s", c.Version, strings.Join(standardRuleVersions(), ", "))
+	}
+	data := map[string]interface{}{}
+	for k, v := range standardRuleThresholds {
+		data[k] = formatRatio(v)
+	}
+	for k, v := range c.Thresholds {
+		if _, ok := standardRuleThresholds[k]; !ok {
+			return nil, fmt.Errorf("Unknown threshold %q of the standard rules", k)
+		}
+		data[k] = formatRatio(v)
+	}
+	names := map[string]bool{}
+	for _, b := range bundles {
+		names[b.name] = true
+	}
+	for k := range c.Selectors {
+		if !names[k] {
+			return nil, fmt.Errorf("Unknown bundle %q in selectors of version %s of the standard rules", k, c.Version)
+		}
+	}
+	for k := range c.Severities {
+		if !standardRuleSeverities[k] {
+			return nil, fmt.Errorf("Unknown severity %q of the standard rules, expected critical or warning", k)
+		}
+	}
+
+	groups := []interface{}{}
+	for _, b := range bundles {
+		if len(c.Bundles) > 0 && !c.Bundles[b.name] {
+			continue
+		}
+		selector := b.selector
+		if v, ok := c.Selectors[b.name]; ok {
+			selector = v
+		}
+		rules := make([]interface{}, len(b.rules))
+		for i, r := range b.rules {
+			rule, err := c.rule(r, selector, data)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to render rule %s of bundle %s: %s", r.alert, b.name, err)
+			}
+			rules[i] = rule
+		}
+		groups = append(groups, map[string]interface{}{
+			"name":  "standard-" + b.name,
+			"rules": rules,
+		})
+	}
+	return groups, nil
+}
+
+func (c *standardRulesConfig) rule(r standardRule, selector string, data map[string]interface{}) (map[string]interface{}, error) {
+	tmpl, err := template.New(r.alert).Delims("[[", "]]").Funcs(template.FuncMap{
+		"selector": func(extra ...string) string {
+			matchers := extra
+			if selector != "" {
+				matchers = append([]string{selector}, extra...)
+			}
+			if len(matchers) == 0 {
+				return ""
+			}
+			return "{" + strings.Join(matchers, ",") + "}"
+		},
+	}).Parse(r.expr)
+	if err != nil {
+		return nil, err
+	}
+	expr := &bytes.Buffer{}
+	if err := tmpl.Execute(expr, data); err != nil {
+		return nil, err
+	}
+	if _, err := promql.ParseExpr(expr.String()); err != nil {
+		return nil, err
+	}
+
+	labels := map[string]interface{}{}
+	for k, v := range c.Labels {
+		labels[k] = v
+	}
+	labels["severity"] = r.severity
+	if v, ok := c.Severities[r.severity]; ok {
+		labels["severity"] = v
+	}
+	annotations := map[string]interface{}{}
+	for k, v := range c.Annotations {
+		annotations[k] = v
+	}
+	annotations["summary"] = r.summary
+	annotations["description"] = r.description
+	if c.RunbookURL != "" {
+		annotations["runbook_url"] = strings.TrimSuffix(c.RunbookURL, "/") + "/" + strings.ToLower(r.alert)
+	}
+	return map[string]interface{}{
+		"alert":       r.alert,
+		"expr":        expr.String(),
+		"for":         r.duration,
+		"labels":      labels,
+		"annotations": annotations,
+	}, nil
+}
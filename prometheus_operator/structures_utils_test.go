@@ -2,7 +2,13 @@ package prometheus_operator
 
 import (
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
+
+	po_types "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 )
 
 func TestIsInternalKey(t *testing.T) {
@@ -31,3 +37,153 @@ func TestIsInternalKey(t *testing.T) {
 	}
 }
 
+func endpointsTestSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"endpoints": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: genEndpointSchema(),
+			},
+		},
+	}
+}
+
+func expandTestEndpoints(l []interface{}) ([]po_types.Endpoint, error) {
+	spec, err := expandServiceMonitorSpec([]interface{}{map[string]interface{}{"endpoints": l}})
+	if err != nil {
+		return nil, err
+	}
+	return spec.Endpoints, nil
+}
+
+func flattenTestEndpoints(in []po_types.Endpoint) ([]interface{}, error) {
+	spec, err := genFlattenServiceMonitorSpec(po_types.ServiceMonitorSpec{Endpoints: in})
+	if err != nil {
+		return nil, err
+	}
+	return spec[0].(map[string]interface{})["endpoints"].([]interface{}), nil
+}
+
+func TestExpandFlattenEndpoints(t *testing.T) {
+	raw := map[string]interface{}{
+		"endpoints": []interface{}{
+			map[string]interface{}{
+				"target_port": "9115",
+				"path":        "/probe",
+				"interval":    "30s",
+				"params": []interface{}{
+					map[string]interface{}{
+						"name":   "module",
+						"values": []interface{}{"http_2xx"},
+					},
+					map[string]interface{}{
+						"name":   "target",
+						"values": []interface{}{"https://example.com", "https://example.org"},
+					},
+				},
+				"bearer_token_secret": []interface{}{
+					map[string]interface{}{
+						"name": "scrape-token",
+						"key":  "token",
+					},
+				},
+				"metric_relabelings": []interface{}{
+					map[string]interface{}{
+						"action":        "hashmod",
+						"modulus":       4,
+						"target_label":  "__tmp_hash",
+						"source_labels": []interface{}{"__address__"},
+					},
+				},
+			},
+			map[string]interface{}{
+				"port": "http-metrics",
+			},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, endpointsTestSchema(), raw)
+
+	expanded, err := expandTestEndpoints(d.Get("endpoints").([]interface{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expanded[0].TargetPort == nil || expanded[0].TargetPort.IntValue() != 9115 {
+		t.Fatalf("Expected target port 9115, got %#v", expanded[0].TargetPort)
+	}
+	if len(expanded[0].Params["target"]) != 2 {
+		t.Fatalf("Expected two target params, got %#v", expanded[0].Params)
+	}
+
+	flattened, err := flattenTestEndpoints(expanded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Set("endpoints", flattened); err != nil {
+		t.Fatal(err)
+	}
+	roundTrip, err := expandTestEndpoints(d.Get("endpoints").([]interface{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expanded, roundTrip) {
+		t.Fatalf("Endpoints did not round-trip:\nexpected: %#v\ngot:      %#v", expanded, roundTrip)
+	}
+}
+
+func TestExpandEndpoints_mutuallyExclusive(t *testing.T) {
+	secret := []interface{}{
+		map[string]interface{}{
+			"name": "scrape-token",
+			"key":  "token",
+		},
+	}
+	testCases := []struct {
+		Endpoint map[string]interface{}
+		Error    string
+	}{
+		{
+			map[string]interface{}{"port": "web", "target_port": "9090"},
+			"port and target_port are mutually exclusive",
+		},
+		{
+			map[string]interface{}{"bearer_token_file": "/etc/token", "bearer_token_secret": secret},
+			"bearer_token_file and bearer_token_secret are mutually exclusive",
+		},
+		{
+			map[string]interface{}{
+				"bearer_token_secret": secret,
+				"basic_auth": []interface{}{
+					map[string]interface{}{"username": secret},
+				},
+			},
+			"basic_auth and bearer token are mutually exclusive",
+		},
+	}
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, endpointsTestSchema(), map[string]interface{}{
+				"endpoints": []interface{}{tc.Endpoint},
+			})
+			_, err := expandTestEndpoints(d.Get("endpoints").([]interface{}))
+			if err == nil {
+				t.Fatalf("Expected error %q, got none", tc.Error)
+			}
+			if !strings.Contains(err.Error(), tc.Error) {
+				t.Fatalf("Expected error %q, got %q", tc.Error, err)
+			}
+
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"metadata": []interface{}{map[string]interface{}{"name": "app", "namespace": "monitoring"}},
+				"spec": []interface{}{map[string]interface{}{
+					"selector":  []interface{}{map[string]interface{}{"match_labels": map[string]interface{}{"app": "app"}}},
+					"endpoints": []interface{}{tc.Endpoint},
+				}},
+			})
+			_, err = resourcePOServiceMonitor().Diff(nil, config, &KubeClientsets{})
+			if err == nil || !strings.Contains(err.Error(), "spec.0.endpoints.0: "+tc.Error) {
+				t.Errorf("Expected error %q during plan, got %v", tc.Error, err)
+			}
+		})
+	}
+}
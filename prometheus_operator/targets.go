@@ -0,0 +1,191 @@
+package prometheus_operator
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	po_types "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// targetsPollInterval is the interval of target checks while waiting for
+// targets to be up.
+var targetsPollInterval = 5 * time.Second
+
+// prometheusTarget is an active target of the Prometheus targets API.
+type prometheusTarget struct {
+	DiscoveredLabels map[string]string `json:"discoveredLabels"`
+	Labels           map[string]string `json:"labels"`
+	ScrapePool       string            `json:"scrapePool"`
+	ScrapeURL        string            `json:"scrapeUrl"`
+	LastError        string            `json:"lastError"`
+	Health           string            `json:"health"`
+}
+
+// job returns the name of the scrape job of the target. The operator names
+// the jobs of ServiceMonitors <namespace>/<name>/<endpoint index>.
+func (t prometheusTarget) job() string {
+	if t.ScrapePool != "" {
+		return t.ScrapePool
+	}
+	return t.DiscoveredLabels["job"]
+}
+
+func getTargets(proxy apiProxy) ([]prometheusTarget, error) {
+	data, _, err := prometheusAPI(proxy, "/api/v1/targets", map[string]string{"state": "active"})
+	if err != nil {
+		return nil, err
+	}
+	out := struct {
+		ActiveTargets []prometheusTarget `json:"activeTargets"`
+	}{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("Failed to decode targets: %s", err)
+	}
+	return out.ActiveTargets, nil
+}
+
+// prometheusSelectors returns the selectors of objects and of their
+// namespaces of a kind in the spec of a Prometheus.
+type prometheusSelectors func(spec *po_types.PrometheusSpec) (*metav1.LabelSelector, *metav1.LabelSelector)
+
+func serviceMonitorSelectors(spec *po_types.PrometheusSpec) (*metav1.LabelSelector, *metav1.LabelSelector) {
+	return spec.ServiceMonitorSelector, spec.ServiceMonitorNamespaceSelector
+}
+
+func ruleSelectors(spec *po_types.PrometheusSpec) (*metav1.LabelSelector, *metav1.LabelSelector) {
+	return spec.RuleSelector, spec.RuleNamespaceSelector
+}
+
+// selectingPrometheuses returns the Prometheuses whose selectors select the
+// object, sorted by ID. Prometheuses without an object selector
+// select nothing, and without a namespace selector only select objects in
+// their own namespace.
+func selectingPrometheuses(clients *KubeClientsets, obj metav1.ObjectMeta, selectors prometheusSelectors) ([]*po_types.Prometheus, error) {
+	list, err := clients.MonitoringClient.Prometheuses(metav1.NamespaceAll).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list Prometheuses: %s", err)
+	}
+	var namespaceLabels labels.Set
+	out := []*po_types.Prometheus{}
+	for _, p := range list.Items {
+		objSelector, nsSelector := selectors(&p.Spec)
+		if objSelector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(objSelector)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse selector of Prometheus %s/%s: %s", p.Namespace, p.Name, err)
+		}
+		if !selector.Matches(labels.Set(obj.Labels)) {
+			continue
+		}
+		if nsSelector == nil {
+			if p.Namespace != obj.Namespace {
+				continue
+			}
+		} else {
+			if namespaceLabels == nil {
+				ns, err := clients.MainClientset.CoreV1().Namespaces().Get(obj.Namespace, metav1.GetOptions{})
+				if err != nil {
+					return nil, fmt.Errorf("Failed to read namespace %q: %s", obj.Namespace, err)
+				}
+				namespaceLabels = labels.Set(ns.Labels)
+			}
+			selector, err := metav1.LabelSelectorAsSelector(nsSelector)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to parse namespace selector of Prometheus %s/%s: %s", p.Namespace, p.Name, err)
+			}
+			if !selector.Matches(namespaceLabels) {
+				continue
+			}
+		}
+		out = append(out, p)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return buildId(out[i].ObjectMeta) < buildId(out[j].ObjectMeta)
+	})
+	return out, nil
+}
+
+func prometheusIds(prometheuses []*po_types.Prometheus) []string {
+	ids := make([]string, len(prometheuses))
+	for i, p := range prometheuses {
+		ids[i] = buildId(p.ObjectMeta)
+	}
+	return ids
+}
+
+// waitForTargets waits until every replica of the Prometheuses selecting the
+// ServiceMonitor has at least wait_for_targets.0.min_up targets of its jobs
+// up. Replicas are reached through the proxy of their pod.
+func waitForTargets(d *schema.ResourceData, meta interface{}, timeout time.Duration) error {
+	w, ok := d.Get("wait_for_targets").([]interface{})
+	if !ok || len(w) == 0 || w[0] == nil {
+		return nil
+	}
+	minUp := w[0].(map[string]interface{})["min_up"].(int)
+	clients := meta.(*KubeClientsets)
+	conn := clients.MainClientset.CoreV1().RESTClient()
+
+	sm, err := buildServiceMonitor(d)
+	if err != nil {
+		return err
+	}
+	prometheuses, err := selectingPrometheuses(clients, sm.ObjectMeta, serviceMonitorSelectors)
+	if err != nil {
+		return err
+	}
+	if len(prometheuses) == 0 {
+		return fmt.Errorf("No Prometheus selects ServiceMonitor %s/%s", sm.Namespace, sm.Name)
+	}
+
+	prefix := sm.Namespace + "/" + sm.Name + "/"
+	log.Printf("[INFO] Waiting for %d targets of ServiceMonitor %s/%s to be up in %v", minUp, sm.Namespace, sm.Name, prometheusIds(prometheuses))
+	last := "no targets"
+	err = wait.PollImmediate(targetsPollInterval, timeout, func() (bool, error) {
+		for _, p := range prometheuses {
+			for _, pod := range prometheusPods(p) {
+				targets, err := getTargets(prometheusPodProxy(conn, p.Namespace, pod))
+				if err != nil {
+					// Prometheus may be restarting to load the new configuration.
+					last = fmt.Sprintf("Failed to read targets of Prometheus %s, pod %s: %s", buildId(p.ObjectMeta), pod, err)
+					log.Printf("[DEBUG] %s", last)
+					return false, nil
+				}
+				up := 0
+				failing := []string{}
+				for _, t := range targets {
+					if !strings.HasPrefix(t.job(), prefix) {
+						continue
+					}
+					if t.Health == "up" {
+						up++
+					} else if t.LastError != "" {
+						failing = append(failing, fmt.Sprintf("%s: %s", t.ScrapeURL, t.LastError))
+					}
+				}
+				if up < minUp {
+					last = fmt.Sprintf("%d up in Prometheus %s, pod %s", up, buildId(p.ObjectMeta), pod)
+					if len(failing) > 0 {
+						last += ", failing targets: " + strings.Join(failing, "; ")
+					}
+					log.Printf("[DEBUG] Targets of ServiceMonitor %s/%s: %s", sm.Namespace, sm.Name, last)
+					return false, nil
+				}
+			}
+		}
+		return true, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return fmt.Errorf("Timed out waiting for %d targets of ServiceMonitor %s/%s to be up, %s", minUp, sm.Namespace, sm.Name, last)
+	}
+	return err
+}
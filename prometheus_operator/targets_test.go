@@ -0,0 +1,125 @@
+package prometheus_operator
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	monclientv1 "github.com/coreos/prometheus-operator/pkg/client/versioned/typed/monitoring/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	restclient "k8s.io/client-go/rest"
+)
+
+const testPrometheuses = `{"apiVersion":"monitoring.coreos.com/v1","kind":"PrometheusList","items":[
+	{"metadata":{"name":"k8s","namespace":"monitoring"},"spec":{"serviceMonitorSelector":{"matchLabels":{"team":"api"}}}},
+	{"metadata":{"name":"other","namespace":"other"},"spec":{"serviceMonitorSelector":{},"serviceMonitorNamespaceSelector":{"matchLabels":{"team":"db"}}}},
+	{"metadata":{"name":"none","namespace":"monitoring"},"spec":{}}
+]}`
+
+func TestWaitForTargets(t *testing.T) {
+	defer func(d time.Duration) { targetsPollInterval = d }(targetsPollInterval)
+	targetsPollInterval = time.Millisecond
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/apis/monitoring.coreos.com/v1/prometheuses":
+			w.Write([]byte(testPrometheuses))
+		case "/api/v1/namespaces/monitoring":
+			w.Write([]byte(`{"kind":"Namespace","apiVersion":"v1","metadata":{"name":"monitoring","labels":{"team":"api"}}}`))
+		case testPrometheusPodProxy + "-0:9090/proxy/api/v1/targets":
+			health := "down"
+			if atomic.AddInt32(&requests, 1) >= 3 {
+				health = "up"
+			}
+			fmt.Fprintf(w, `{"status":"success","data":{"activeTargets":[
+				{"scrapePool":"monitoring/api/0","scrapeUrl":"http://10.0.0.1:8080/metrics","health":"up"},
+				{"scrapePool":"monitoring/api/0","scrapeUrl":"http://10.0.0.2:8080/metrics","lastError":"connection refused","health":"%s"},
+				{"scrapePool":"monitoring/api-other/0","scrapeUrl":"http://10.0.0.3:8080/metrics","health":"up"}
+			]}}`, health)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	meta := testProxyClientsets(t, server)
+	m, err := monclientv1.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta.MonitoringClient = m
+
+	d := schema.TestResourceDataRaw(t, resourcePOServiceMonitor().Schema, map[string]interface{}{
+		"metadata": []interface{}{map[string]interface{}{
+			"name":      "api",
+			"namespace": "monitoring",
+			"labels":    map[string]interface{}{"team": "api"},
+		}},
+		"spec":             []interface{}{map[string]interface{}{}},
+		"wait_for_targets": []interface{}{map[string]interface{}{"min_up": 2}},
+	})
+
+	if err := waitForTargets(d, meta, time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 3 {
+		t.Errorf("Expected 3 requests of targets, got %d", n)
+	}
+
+	d.Set("wait_for_targets", []interface{}{map[string]interface{}{"min_up": 3}})
+	atomic.StoreInt32(&requests, -1000)
+	err = waitForTargets(d, meta, 20*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "1 up in Prometheus monitoring/k8s, pod prometheus-k8s-0, failing targets: http://10.0.0.2:8080/metrics: connection refused") {
+		t.Errorf("Expected timeout reporting the last error of the failing target, got %v", err)
+	}
+}
+
+func TestSelectingPrometheuses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/apis/monitoring.coreos.com/v1/prometheuses":
+			w.Write([]byte(testPrometheuses))
+		case "/api/v1/namespaces/db":
+			w.Write([]byte(`{"kind":"Namespace","apiVersion":"v1","metadata":{"name":"db","labels":{"team":"db"}}}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	meta := testProxyClientsets(t, server)
+	m, err := monclientv1.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta.MonitoringClient = m
+
+	d := schema.TestResourceDataRaw(t, resourcePOServiceMonitor().Schema, map[string]interface{}{
+		"metadata": []interface{}{map[string]interface{}{
+			"name":      "postgres",
+			"namespace": "db",
+			"labels":    map[string]interface{}{"team": "api"},
+		}},
+		"spec": []interface{}{map[string]interface{}{}},
+	})
+	sm, err := buildServiceMonitor(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prometheuses, err := selectingPrometheuses(meta, sm.ObjectMeta, serviceMonitorSelectors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := prometheusIds(prometheuses); len(ids) != 1 || ids[0] != "other/other" {
+		t.Errorf("Expected only other/other to select the ServiceMonitor, got %v", ids)
+	}
+}
@@ -0,0 +1,1570 @@
+// Code generated by internal/codegen. DO NOT EDIT.
+
+package prometheus_operator
+
+import (
+	"reflect"
+
+	po_types "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// genAlertingSpecSchema returns the schema of AlertingSpec. AlertingSpec defines parameters for alerting configuration of Prometheus servers.
+func genAlertingSpecSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"alertmanagers": {
+			Type: schema.TypeList,
+			Elem: &schema.Resource{
+				Schema: genAlertmanagerEndpointsSchema(),
+			},
+			Optional:    true,
+			Description: "AlertmanagerEndpoints Prometheus should fire alerts against.",
+		},
+	}
+}
+
+func genExpandAlertingSpec(l []interface{}) (*po_types.AlertingSpec, error) {
+	obj := &po_types.AlertingSpec{}
+	if len(l) == 0 || l[0] == nil {
+		return obj, nil
+	}
+	in := l[0].(map[string]interface{})
+
+	if v, ok := in["alertmanagers"].([]interface{}); ok && len(v) > 0 {
+		obj.Alertmanagers = make([]po_types.AlertmanagerEndpoints, len(v))
+		for i, e := range v {
+			s, err := genExpandAlertmanagerEndpoints([]interface{}{e})
+			if err != nil {
+				return obj, err
+			}
+			obj.Alertmanagers[i] = *s
+		}
+	}
+	return obj, nil
+}
+
+func genFlattenAlertingSpec(in po_types.AlertingSpec) ([]interface{}, error) {
+	att := make(map[string]interface{})
+
+	if len(in.Alertmanagers) > 0 {
+		l := make([]interface{}, 0, len(in.Alertmanagers))
+		for _, e := range in.Alertmanagers {
+			s, err := genFlattenAlertmanagerEndpoints(e)
+			if err != nil {
+				return nil, err
+			}
+			l = append(l, s[0])
+		}
+		att["alertmanagers"] = l
+	}
+	return []interface{}{att}, nil
+}
+
+// genAlertmanagerEndpointsSchema returns the schema of AlertmanagerEndpoints. AlertmanagerEndpoints defines a selection of a single Endpoints object containing alertmanager IPs to fire alerts against.
+func genAlertmanagerEndpointsSchema() map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"namespace": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Namespace of Endpoints object.",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Name of Endpoints object in Namespace.",
+		},
+		"port": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Port the Alertmanager API is exposed on.",
+		},
+		"scheme": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Scheme to use when firing alerts.",
+		},
+		"path_prefix": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Prefix for the HTTP path alerts are pushed to.",
+		},
+		"tls_config": {
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: genTLSConfigSchema(),
+			},
+			Optional:    true,
+			Description: "TLS Config to use for alertmanager connection.",
+		},
+		"bearer_token_file": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "BearerTokenFile to read from filesystem to use when authenticating to Alertmanager.",
+		},
+	}
+	for k, v := range alertmanagerEndpointsExtraSchema() {
+		s[k] = v
+	}
+	return s
+}
+
+func genExpandAlertmanagerEndpoints(l []interface{}) (*po_types.AlertmanagerEndpoints, error) {
+	obj := &po_types.AlertmanagerEndpoints{}
+	if len(l) == 0 || l[0] == nil {
+		return obj, nil
+	}
+	in := l[0].(map[string]interface{})
+
+	if v, ok := in["namespace"].(string); ok && v != "" {
+		obj.Namespace = v
+	}
+	if v, ok := in["name"].(string); ok && v != "" {
+		obj.Name = v
+	}
+	if v, ok := in["port"].(string); ok && v != "" {
+		obj.Port = intstr.Parse(v)
+	}
+	if v, ok := in["scheme"].(string); ok && v != "" {
+		obj.Scheme = v
+	}
+	if v, ok := in["path_prefix"].(string); ok && v != "" {
+		obj.PathPrefix = v
+	}
+	if v, ok := in["tls_config"].([]interface{}); ok && len(v) > 0 {
+		s, err := genExpandTLSConfig(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.TLSConfig = s
+	}
+	if v, ok := in["bearer_token_file"].(string); ok && v != "" {
+		obj.BearerTokenFile = v
+	}
+	return obj, nil
+}
+
+func genFlattenAlertmanagerEndpoints(in po_types.AlertmanagerEndpoints) ([]interface{}, error) {
+	att := make(map[string]interface{})
+
+	att["namespace"] = in.Namespace
+	att["name"] = in.Name
+	att["port"] = in.Port.String()
+	att["scheme"] = in.Scheme
+	att["path_prefix"] = in.PathPrefix
+	if in.TLSConfig != nil {
+		s, err := genFlattenTLSConfig(*in.TLSConfig)
+		if err != nil {
+			return nil, err
+		}
+		att["tls_config"] = s
+	}
+	att["bearer_token_file"] = in.BearerTokenFile
+	return []interface{}{att}, nil
+}
+
+// genAlertmanagerSpecSchema returns the schema of AlertmanagerSpec. AlertmanagerSpec is a specification of the desired behavior of the Alertmanager cluster. More info: https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+// Not included: PodMetadata, ImagePullSecrets, ConfigSecret, LogLevel, LogFormat, Retention, Storage, Volumes, VolumeMounts, RoutePrefix, NodeSelector, Resources, Affinity, Tolerations, SecurityContext, Containers, InitContainers, AdditionalPeers.
+func genAlertmanagerSpecSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"image": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "Image if specified has precedence over baseImage, tag and sha combinations. Specifying the version is still necessary to ensure the Prometheus Operator knows what version of Alertmanager is being configured.",
+		},
+		"version": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "Version the cluster should be on.",
+		},
+		"tag": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "Tag of Alertmanager container image to be deployed. Defaults to the value of `version`. Version is ignored if Tag is set.",
+		},
+		"sha": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "SHA of Alertmanager container image to be deployed. Defaults to the value of `version`. Similar to a tag, but the SHA explicitly deploys an immutable container image. Version and Tag are ignored if SHA is set.",
+		},
+		"base_image": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Default:     "quay.io/prometheus/alertmanager",
+			Description: "Base image that is used to deploy pods, without tag.",
+		},
+		"secrets": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Description: "Secrets is a list of Secrets in the same namespace as the Alertmanager object, which shall be mounted into the Alertmanager Pods. The Secrets are mounted into /etc/alertmanager/secrets/<secret-name>.",
+		},
+		"config_maps": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Description: "ConfigMaps is a list of ConfigMaps in the same namespace as the Alertmanager object, which shall be mounted into the Alertmanager Pods. The ConfigMaps are mounted into /etc/alertmanager/configmaps/<configmap-name>.",
+		},
+		"replicas": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Default:     3,
+			Description: "Size is the expected size of the alertmanager cluster. The controller will eventually make the size of the running cluster equal to the expected size.",
+		},
+		"external_url": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The external URL the Alertmanager instances will be available under. This is necessary to generate correct URLs. This is necessary if Alertmanager is not served from root of a DNS name.",
+		},
+		"paused": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "If set to true all actions on the underlaying managed objects are not goint to be performed, except for delete actions.",
+		},
+		"service_account_name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "ServiceAccountName is the name of the ServiceAccount to use to run the Prometheus Pods.",
+		},
+		"listen_local": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "ListenLocal makes the Alertmanager server listen on loopback, so that it does not bind against the Pod IP. Note this is only for the Alertmanager UI, not the gossip communication.",
+		},
+		"priority_class_name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Priority class assigned to the Pods",
+		},
+		"port_name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Port name used for the pods and governing service. This defaults to web",
+		},
+	}
+}
+
+func genExpandAlertmanagerSpec(l []interface{}) (*po_types.AlertmanagerSpec, error) {
+	obj := &po_types.AlertmanagerSpec{}
+	if len(l) == 0 || l[0] == nil {
+		return obj, nil
+	}
+	in := l[0].(map[string]interface{})
+
+	if v, ok := in["image"].(string); ok && v != "" {
+		p := v
+		obj.Image = &p
+	}
+	if v, ok := in["version"].(string); ok && v != "" {
+		obj.Version = v
+	}
+	if v, ok := in["tag"].(string); ok && v != "" {
+		obj.Tag = v
+	}
+	if v, ok := in["sha"].(string); ok && v != "" {
+		obj.SHA = v
+	}
+	if v, ok := in["base_image"].(string); ok && v != "" {
+		obj.BaseImage = v
+	}
+	if v, ok := in["secrets"].([]interface{}); ok && len(v) > 0 {
+		obj.Secrets = expandStringSlice(v)
+	}
+	if v, ok := in["config_maps"].([]interface{}); ok && len(v) > 0 {
+		obj.ConfigMaps = expandStringSlice(v)
+	}
+	if v, ok := in["replicas"].(int); ok {
+		p := int32(v)
+		obj.Replicas = &p
+	}
+	if v, ok := in["external_url"].(string); ok && v != "" {
+		obj.ExternalURL = v
+	}
+	if v, ok := in["paused"].(bool); ok && v {
+		obj.Paused = v
+	}
+	if v, ok := in["service_account_name"].(string); ok && v != "" {
+		obj.ServiceAccountName = v
+	}
+	if v, ok := in["listen_local"].(bool); ok && v {
+		obj.ListenLocal = v
+	}
+	if v, ok := in["priority_class_name"].(string); ok && v != "" {
+		obj.PriorityClassName = v
+	}
+	if v, ok := in["port_name"].(string); ok && v != "" {
+		obj.PortName = v
+	}
+	return obj, nil
+}
+
+func genFlattenAlertmanagerSpec(in po_types.AlertmanagerSpec) ([]interface{}, error) {
+	att := make(map[string]interface{})
+
+	if in.Image != nil {
+		att["image"] = *in.Image
+	}
+	att["version"] = in.Version
+	att["tag"] = in.Tag
+	att["sha"] = in.SHA
+	att["base_image"] = in.BaseImage
+	att["secrets"] = genFlattenStringSlice(in.Secrets)
+	att["config_maps"] = genFlattenStringSlice(in.ConfigMaps)
+	if in.Replicas != nil {
+		att["replicas"] = int(*in.Replicas)
+	}
+	att["external_url"] = in.ExternalURL
+	att["paused"] = in.Paused
+	att["service_account_name"] = in.ServiceAccountName
+	att["listen_local"] = in.ListenLocal
+	att["priority_class_name"] = in.PriorityClassName
+	att["port_name"] = in.PortName
+	return []interface{}{att}, nil
+}
+
+// genBasicAuthSchema returns the schema of BasicAuth. BasicAuth allow an endpoint to authenticate over basic authentication More info: https://prometheus.io/docs/operating/configuration/#endpoints
+func genBasicAuthSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"username": {
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: SecretKeySelectorSchema(),
+			},
+			Optional:    true,
+			Sensitive:   true,
+			Description: "The secret in the service monitor namespace that contains the username for authentication.",
+		},
+		"password": {
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: SecretKeySelectorSchema(),
+			},
+			Optional:    true,
+			Sensitive:   true,
+			Description: "The secret in the service monitor namespace that contains the password for authentication.",
+		},
+	}
+}
+
+func genExpandBasicAuth(l []interface{}) (*po_types.BasicAuth, error) {
+	obj := &po_types.BasicAuth{}
+	if len(l) == 0 || l[0] == nil {
+		return obj, nil
+	}
+	in := l[0].(map[string]interface{})
+
+	if v, ok := in["username"].([]interface{}); ok && len(v) > 0 {
+		x, err := expandSecretKeyRef(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.Username = *x
+	}
+	if v, ok := in["password"].([]interface{}); ok && len(v) > 0 {
+		x, err := expandSecretKeyRef(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.Password = *x
+	}
+	return obj, nil
+}
+
+func genFlattenBasicAuth(in po_types.BasicAuth) ([]interface{}, error) {
+	att := make(map[string]interface{})
+
+	if !reflect.ValueOf(in.Username).IsZero() {
+		att["username"] = flattenSecretKeyRef(&in.Username)
+	}
+	if !reflect.ValueOf(in.Password).IsZero() {
+		att["password"] = flattenSecretKeyRef(&in.Password)
+	}
+	return []interface{}{att}, nil
+}
+
+// genEndpointSchema returns the schema of Endpoint. Endpoint defines a scrapeable endpoint serving Prometheus metrics.
+func genEndpointSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"port": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Name of the service port this endpoint refers to. Mutually exclusive with targetPort.",
+		},
+		"target_port": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Name or number of the target port of the endpoint. Mutually exclusive with port.",
+		},
+		"path": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "HTTP path to scrape for metrics.",
+		},
+		"scheme": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "HTTP scheme to use for scraping.",
+		},
+		"params": {
+			Type: schema.TypeSet,
+			Elem: &schema.Resource{
+				Schema: EndpointParamSchema(),
+			},
+			Optional:    true,
+			Description: "Optional HTTP URL parameters",
+		},
+		"interval": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Interval at which metrics should be scraped",
+		},
+		"scrape_timeout": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Timeout after which the scrape is ended",
+		},
+		"tls_config": {
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: genTLSConfigSchema(),
+			},
+			Optional:    true,
+			Description: "TLS configuration to use when scraping the endpoint",
+		},
+		"bearer_token_file": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "File to read bearer token for scraping targets.",
+		},
+		"bearer_token_secret": {
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: SecretKeySelectorSchema(),
+			},
+			Optional:    true,
+			Description: "Secret to mount to read bearer token for scraping targets. The secret needs to be in the same namespace as the service monitor and accessible by the Prometheus Operator.",
+		},
+		"honor_labels": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "HonorLabels chooses the metric's labels on collisions with target labels.",
+		},
+		"honor_timestamps": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "HonorTimestamps controls whether Prometheus respects the timestamps present in scraped data.",
+		},
+		"basic_auth": {
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: genBasicAuthSchema(),
+			},
+			Optional:    true,
+			Description: "BasicAuth allow an endpoint to authenticate over basic authentication More info: https://prometheus.io/docs/operating/configuration/#endpoints",
+		},
+		"metric_relabelings": {
+			Type: schema.TypeList,
+			Elem: &schema.Resource{
+				Schema: genRelabelConfigSchema(),
+			},
+			Optional:    true,
+			Description: "MetricRelabelConfigs to apply to samples before ingestion.",
+		},
+		"relabelings": {
+			Type: schema.TypeList,
+			Elem: &schema.Resource{
+				Schema: genRelabelConfigSchema(),
+			},
+			Optional:    true,
+			Description: "RelabelConfigs to apply to samples before scraping. More info: https://prometheus.io/docs/prometheus/latest/configuration/configuration/#relabel_config",
+		},
+		"proxy_url": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "ProxyURL eg http://proxyserver:2195 Directs scrapes to proxy through this endpoint.",
+		},
+	}
+}
+
+func genExpandEndpoint(l []interface{}) (*po_types.Endpoint, error) {
+	obj := &po_types.Endpoint{}
+	if len(l) == 0 || l[0] == nil {
+		return obj, nil
+	}
+	in := l[0].(map[string]interface{})
+
+	if v, ok := in["port"].(string); ok && v != "" {
+		obj.Port = v
+	}
+	if v, ok := in["target_port"].(string); ok && v != "" {
+		p := intstr.Parse(v)
+		obj.TargetPort = &p
+	}
+	if v, ok := in["path"].(string); ok && v != "" {
+		obj.Path = v
+	}
+	if v, ok := in["scheme"].(string); ok && v != "" {
+		obj.Scheme = v
+	}
+	if s, ok := in["params"].(*schema.Set); ok && s.Len() > 0 {
+		v := s.List()
+		x := expandEndpointParams(v)
+		obj.Params = x
+	}
+	if v, ok := in["interval"].(string); ok && v != "" {
+		obj.Interval = v
+	}
+	if v, ok := in["scrape_timeout"].(string); ok && v != "" {
+		obj.ScrapeTimeout = v
+	}
+	if v, ok := in["tls_config"].([]interface{}); ok && len(v) > 0 {
+		s, err := genExpandTLSConfig(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.TLSConfig = s
+	}
+	if v, ok := in["bearer_token_file"].(string); ok && v != "" {
+		obj.BearerTokenFile = v
+	}
+	if v, ok := in["bearer_token_secret"].([]interface{}); ok && len(v) > 0 {
+		x, err := expandSecretKeyRef(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.BearerTokenSecret = *x
+	}
+	if v, ok := in["honor_labels"].(bool); ok && v {
+		obj.HonorLabels = v
+	}
+	if v, ok := in["honor_timestamps"].(bool); ok {
+		p := v
+		obj.HonorTimestamps = &p
+	}
+	if v, ok := in["basic_auth"].([]interface{}); ok && len(v) > 0 {
+		s, err := genExpandBasicAuth(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.BasicAuth = s
+	}
+	if v, ok := in["metric_relabelings"].([]interface{}); ok && len(v) > 0 {
+		obj.MetricRelabelConfigs = make([]*po_types.RelabelConfig, len(v))
+		for i, e := range v {
+			s, err := genExpandRelabelConfig([]interface{}{e})
+			if err != nil {
+				return obj, err
+			}
+			obj.MetricRelabelConfigs[i] = s
+		}
+	}
+	if v, ok := in["relabelings"].([]interface{}); ok && len(v) > 0 {
+		obj.RelabelConfigs = make([]*po_types.RelabelConfig, len(v))
+		for i, e := range v {
+			s, err := genExpandRelabelConfig([]interface{}{e})
+			if err != nil {
+				return obj, err
+			}
+			obj.RelabelConfigs[i] = s
+		}
+	}
+	if v, ok := in["proxy_url"].(string); ok && v != "" {
+		p := v
+		obj.ProxyURL = &p
+	}
+	return obj, nil
+}
+
+func genFlattenEndpoint(in po_types.Endpoint) ([]interface{}, error) {
+	att := make(map[string]interface{})
+
+	att["port"] = in.Port
+	if in.TargetPort != nil {
+		att["target_port"] = in.TargetPort.String()
+	}
+	att["path"] = in.Path
+	att["scheme"] = in.Scheme
+	att["params"] = flattenEndpointParams(in.Params)
+	att["interval"] = in.Interval
+	att["scrape_timeout"] = in.ScrapeTimeout
+	if in.TLSConfig != nil {
+		s, err := genFlattenTLSConfig(*in.TLSConfig)
+		if err != nil {
+			return nil, err
+		}
+		att["tls_config"] = s
+	}
+	att["bearer_token_file"] = in.BearerTokenFile
+	if !reflect.ValueOf(in.BearerTokenSecret).IsZero() {
+		att["bearer_token_secret"] = flattenSecretKeyRef(&in.BearerTokenSecret)
+	}
+	att["honor_labels"] = in.HonorLabels
+	if in.HonorTimestamps != nil {
+		att["honor_timestamps"] = *in.HonorTimestamps
+	}
+	if in.BasicAuth != nil {
+		s, err := genFlattenBasicAuth(*in.BasicAuth)
+		if err != nil {
+			return nil, err
+		}
+		att["basic_auth"] = s
+	}
+	if len(in.MetricRelabelConfigs) > 0 {
+		l := make([]interface{}, 0, len(in.MetricRelabelConfigs))
+		for _, e := range in.MetricRelabelConfigs {
+			if e == nil {
+				continue
+			}
+			s, err := genFlattenRelabelConfig(*e)
+			if err != nil {
+				return nil, err
+			}
+			l = append(l, s[0])
+		}
+		att["metric_relabelings"] = l
+	}
+	if len(in.RelabelConfigs) > 0 {
+		l := make([]interface{}, 0, len(in.RelabelConfigs))
+		for _, e := range in.RelabelConfigs {
+			if e == nil {
+				continue
+			}
+			s, err := genFlattenRelabelConfig(*e)
+			if err != nil {
+				return nil, err
+			}
+			l = append(l, s[0])
+		}
+		att["relabelings"] = l
+	}
+	if in.ProxyURL != nil {
+		att["proxy_url"] = *in.ProxyURL
+	}
+	return []interface{}{att}, nil
+}
+
+// genNamespaceSelectorSchema returns the schema of NamespaceSelector. NamespaceSelector is a selector for selecting either all namespaces or a list of namespaces.
+func genNamespaceSelectorSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"any": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Boolean describing whether all namespaces are selected in contrast to a list restricting them.",
+		},
+		"match_names": {
+			Type:        schema.TypeSet,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Description: "List of namespace names.",
+		},
+	}
+}
+
+func genExpandNamespaceSelector(l []interface{}) (*po_types.NamespaceSelector, error) {
+	obj := &po_types.NamespaceSelector{}
+	if len(l) == 0 || l[0] == nil {
+		return obj, nil
+	}
+	in := l[0].(map[string]interface{})
+
+	if v, ok := in["any"].(bool); ok && v {
+		obj.Any = v
+	}
+	if s, ok := in["match_names"].(*schema.Set); ok && s.Len() > 0 {
+		v := s.List()
+		obj.MatchNames = expandStringSlice(v)
+	}
+	return obj, nil
+}
+
+func genFlattenNamespaceSelector(in po_types.NamespaceSelector) ([]interface{}, error) {
+	att := make(map[string]interface{})
+
+	att["any"] = in.Any
+	att["match_names"] = genFlattenStringSlice(in.MatchNames)
+	return []interface{}{att}, nil
+}
+
+// genPrometheusSpecSchema returns the schema of PrometheusSpec. PrometheusSpec is a specification of the desired behavior of the Prometheus cluster. More info: https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+// Not included: PodMetadata, ImagePullSecrets, ReplicaExternalLabelName, PrometheusExternalLabelName, WALCompression, LogLevel, LogFormat, ScrapeInterval, EvaluationInterval, Rules, ExternalLabels, EnableAdminAPI, RoutePrefix, Query, Storage, Volumes, Resources, NodeSelector, Affinity, Tolerations, RemoteWrite, RemoteRead, SecurityContext, Containers, InitContainers, AdditionalScrapeConfigs, AdditionalAlertRelabelConfigs, AdditionalAlertManagerConfigs, APIServerConfig, Thanos, ArbitraryFSAccessThroughSMs, OverrideHonorLabels, OverrideHonorTimestamps, IgnoreNamespaceSelectors, EnforcedNamespaceLabel.
+func genPrometheusSpecSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"service_monitor_selector": {
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: labelSelectorFields(true),
+			},
+			Optional:    true,
+			Description: "ServiceMonitors to be selected for target discovery.",
+		},
+		"service_monitor_namespace_selector": {
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: labelSelectorFields(true),
+			},
+			Optional:    true,
+			Description: "Namespaces to be selected for ServiceMonitor discovery. If nil, only check own namespace.",
+		},
+		"pod_monitor_selector": {
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: labelSelectorFields(true),
+			},
+			Optional:    true,
+			Description: "*Experimental* PodMonitors to be selected for target discovery.",
+		},
+		"pod_monitor_namespace_selector": {
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: labelSelectorFields(true),
+			},
+			Optional:    true,
+			Description: "Namespaces to be selected for PodMonitor discovery. If nil, only check own namespace.",
+		},
+		"version": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "Version of Prometheus to be deployed.",
+		},
+		"tag": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "Tag of Prometheus container image to be deployed. Defaults to the value of `version`. Version is ignored if Tag is set.",
+		},
+		"sha": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "SHA of Prometheus container image to be deployed. Defaults to the value of `version`. Similar to a tag, but the SHA explicitly deploys an immutable container image. Version and Tag are ignored if SHA is set.",
+		},
+		"paused": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "When a Prometheus deployment is paused, no actions except for deletion will be performed on the underlying objects.",
+		},
+		"image": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "Image if specified has precedence over baseImage, tag and sha combinations. Specifying the version is still necessary to ensure the Prometheus Operator knows what version of Prometheus is being configured.",
+		},
+		"base_image": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Default:     "quay.io/prometheus/prometheus",
+			Description: "Base image to use for a Prometheus deployment.",
+		},
+		"replicas": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Default:     2,
+			Description: "Number of instances to deploy for a Prometheus deployment.",
+		},
+		"retention": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "24h",
+			Description: "Time duration Prometheus shall retain data for. Default is '24h', and must match the regular expression `[0-9]+(ms|s|m|h|d|w|y)` (milliseconds seconds minutes hours days weeks years).",
+		},
+		"retention_size": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Maximum amount of disk space used by blocks.",
+		},
+		"external_url": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The external URL the Prometheus instances will be available under. This is necessary to generate correct URLs. This is necessary if Prometheus is not served from root of a DNS name.",
+		},
+		"rule_selector": {
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: labelSelectorFields(true),
+			},
+			Optional:    true,
+			Description: "A selector to select which PrometheusRules to mount for loading alerting rules from. Until (excluding) Prometheus Operator v0.24.0 Prometheus Operator will migrate any legacy rule ConfigMaps to PrometheusRule custom resources selected by RuleSelector. Make sure it does not match any config maps that you do not want to be migrated.",
+		},
+		"rule_namespace_selector": {
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: labelSelectorFields(true),
+			},
+			Optional:    true,
+			Description: "Namespaces to be selected for PrometheusRules discovery. If unspecified, only the same namespace as the Prometheus object is in is used.",
+		},
+		"alerting": {
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: genAlertingSpecSchema(),
+			},
+			Optional:    true,
+			Description: "Define details regarding alerting.",
+		},
+		"service_account_name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "ServiceAccountName is the name of the ServiceAccount to use to run the Prometheus Pods.",
+		},
+		"secrets": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Description: "Secrets is a list of Secrets in the same namespace as the Prometheus object, which shall be mounted into the Prometheus Pods. The Secrets are mounted into /etc/prometheus/secrets/<secret-name>.",
+		},
+		"config_maps": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Description: "ConfigMaps is a list of ConfigMaps in the same namespace as the Prometheus object, which shall be mounted into the Prometheus Pods. The ConfigMaps are mounted into /etc/prometheus/configmaps/<configmap-name>.",
+		},
+		"listen_local": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "ListenLocal makes the Prometheus server listen on loopback, so that it does not bind against the Pod IP.",
+		},
+		"priority_class_name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Priority class assigned to the Pods",
+		},
+		"port_name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Port name used for the pods and governing service. This defaults to web",
+		},
+	}
+}
+
+func genExpandPrometheusSpec(l []interface{}) (*po_types.PrometheusSpec, error) {
+	obj := &po_types.PrometheusSpec{}
+	if len(l) == 0 || l[0] == nil {
+		return obj, nil
+	}
+	in := l[0].(map[string]interface{})
+
+	if v, ok := in["service_monitor_selector"].([]interface{}); ok && len(v) > 0 {
+		x := expandLabelSelector(v)
+		obj.ServiceMonitorSelector = x
+	}
+	if v, ok := in["service_monitor_namespace_selector"].([]interface{}); ok && len(v) > 0 {
+		x := expandLabelSelector(v)
+		obj.ServiceMonitorNamespaceSelector = x
+	}
+	if v, ok := in["pod_monitor_selector"].([]interface{}); ok && len(v) > 0 {
+		x := expandLabelSelector(v)
+		obj.PodMonitorSelector = x
+	}
+	if v, ok := in["pod_monitor_namespace_selector"].([]interface{}); ok && len(v) > 0 {
+		x := expandLabelSelector(v)
+		obj.PodMonitorNamespaceSelector = x
+	}
+	if v, ok := in["version"].(string); ok && v != "" {
+		obj.Version = v
+	}
+	if v, ok := in["tag"].(string); ok && v != "" {
+		obj.Tag = v
+	}
+	if v, ok := in["sha"].(string); ok && v != "" {
+		obj.SHA = v
+	}
+	if v, ok := in["paused"].(bool); ok && v {
+		obj.Paused = v
+	}
+	if v, ok := in["image"].(string); ok && v != "" {
+		p := v
+		obj.Image = &p
+	}
+	if v, ok := in["base_image"].(string); ok && v != "" {
+		obj.BaseImage = v
+	}
+	if v, ok := in["replicas"].(int); ok {
+		p := int32(v)
+		obj.Replicas = &p
+	}
+	if v, ok := in["retention"].(string); ok && v != "" {
+		obj.Retention = v
+	}
+	if v, ok := in["retention_size"].(string); ok && v != "" {
+		obj.RetentionSize = v
+	}
+	if v, ok := in["external_url"].(string); ok && v != "" {
+		obj.ExternalURL = v
+	}
+	if v, ok := in["rule_selector"].([]interface{}); ok && len(v) > 0 {
+		x := expandLabelSelector(v)
+		obj.RuleSelector = x
+	}
+	if v, ok := in["rule_namespace_selector"].([]interface{}); ok && len(v) > 0 {
+		x := expandLabelSelector(v)
+		obj.RuleNamespaceSelector = x
+	}
+	if v, ok := in["alerting"].([]interface{}); ok && len(v) > 0 {
+		s, err := genExpandAlertingSpec(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.Alerting = s
+	}
+	if v, ok := in["service_account_name"].(string); ok && v != "" {
+		obj.ServiceAccountName = v
+	}
+	if v, ok := in["secrets"].([]interface{}); ok && len(v) > 0 {
+		obj.Secrets = expandStringSlice(v)
+	}
+	if v, ok := in["config_maps"].([]interface{}); ok && len(v) > 0 {
+		obj.ConfigMaps = expandStringSlice(v)
+	}
+	if v, ok := in["listen_local"].(bool); ok && v {
+		obj.ListenLocal = v
+	}
+	if v, ok := in["priority_class_name"].(string); ok && v != "" {
+		obj.PriorityClassName = v
+	}
+	if v, ok := in["port_name"].(string); ok && v != "" {
+		obj.PortName = v
+	}
+	return obj, nil
+}
+
+func genFlattenPrometheusSpec(in po_types.PrometheusSpec) ([]interface{}, error) {
+	att := make(map[string]interface{})
+
+	if in.ServiceMonitorSelector != nil {
+		att["service_monitor_selector"] = flattenLabelSelector(in.ServiceMonitorSelector)
+	}
+	if in.ServiceMonitorNamespaceSelector != nil {
+		att["service_monitor_namespace_selector"] = flattenLabelSelector(in.ServiceMonitorNamespaceSelector)
+	}
+	if in.PodMonitorSelector != nil {
+		att["pod_monitor_selector"] = flattenLabelSelector(in.PodMonitorSelector)
+	}
+	if in.PodMonitorNamespaceSelector != nil {
+		att["pod_monitor_namespace_selector"] = flattenLabelSelector(in.PodMonitorNamespaceSelector)
+	}
+	att["version"] = in.Version
+	att["tag"] = in.Tag
+	att["sha"] = in.SHA
+	att["paused"] = in.Paused
+	if in.Image != nil {
+		att["image"] = *in.Image
+	}
+	att["base_image"] = in.BaseImage
+	if in.Replicas != nil {
+		att["replicas"] = int(*in.Replicas)
+	}
+	att["retention"] = in.Retention
+	att["retention_size"] = in.RetentionSize
+	att["external_url"] = in.ExternalURL
+	if in.RuleSelector != nil {
+		att["rule_selector"] = flattenLabelSelector(in.RuleSelector)
+	}
+	if in.RuleNamespaceSelector != nil {
+		att["rule_namespace_selector"] = flattenLabelSelector(in.RuleNamespaceSelector)
+	}
+	if in.Alerting != nil {
+		s, err := genFlattenAlertingSpec(*in.Alerting)
+		if err != nil {
+			return nil, err
+		}
+		att["alerting"] = s
+	}
+	att["service_account_name"] = in.ServiceAccountName
+	att["secrets"] = genFlattenStringSlice(in.Secrets)
+	att["config_maps"] = genFlattenStringSlice(in.ConfigMaps)
+	att["listen_local"] = in.ListenLocal
+	att["priority_class_name"] = in.PriorityClassName
+	att["port_name"] = in.PortName
+	return []interface{}{att}, nil
+}
+
+// genRelabelConfigSchema returns the schema of RelabelConfig. RelabelConfig allows dynamic rewriting of the label set, being applied to samples before ingestion. It defines `<metric_relabel_configs>`-section of Prometheus configuration. More info: https://prometheus.io/docs/prometheus/latest/configuration/configuration/#metric_relabel_configs
+func genRelabelConfigSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"source_labels": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Description: "The source labels select values from existing labels. Their content is concatenated using the configured separator and matched against the configured regular expression for the replace, keep, and drop actions.",
+		},
+		"separator": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Separator placed between concatenated source label values. default is ';'.",
+		},
+		"target_label": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Label to which the resulting value is written in a replace action. It is mandatory for replace actions. Regex capture groups are available.",
+		},
+		"regex": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Regular expression against which the extracted value is matched. defailt is '(.*)'",
+		},
+		"modulus": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "Modulus to take of the hash of the source label values.",
+		},
+		"replacement": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Replacement value against which a regex replace is performed if the regular expression matches. Regex capture groups are available. Default is '$1'",
+		},
+		"action": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Action to perform based on regex matching. Default is 'replace'",
+		},
+	}
+}
+
+func genExpandRelabelConfig(l []interface{}) (*po_types.RelabelConfig, error) {
+	obj := &po_types.RelabelConfig{}
+	if len(l) == 0 || l[0] == nil {
+		return obj, nil
+	}
+	in := l[0].(map[string]interface{})
+
+	if v, ok := in["source_labels"].([]interface{}); ok && len(v) > 0 {
+		obj.SourceLabels = expandStringSlice(v)
+	}
+	if v, ok := in["separator"].(string); ok && v != "" {
+		obj.Separator = v
+	}
+	if v, ok := in["target_label"].(string); ok && v != "" {
+		obj.TargetLabel = v
+	}
+	if v, ok := in["regex"].(string); ok && v != "" {
+		obj.Regex = v
+	}
+	if v, ok := in["modulus"].(int); ok && v != 0 {
+		obj.Modulus = uint64(v)
+	}
+	if v, ok := in["replacement"].(string); ok && v != "" {
+		obj.Replacement = v
+	}
+	if v, ok := in["action"].(string); ok && v != "" {
+		obj.Action = v
+	}
+	return obj, nil
+}
+
+func genFlattenRelabelConfig(in po_types.RelabelConfig) ([]interface{}, error) {
+	att := make(map[string]interface{})
+
+	att["source_labels"] = genFlattenStringSlice(in.SourceLabels)
+	att["separator"] = in.Separator
+	att["target_label"] = in.TargetLabel
+	att["regex"] = in.Regex
+	att["modulus"] = int(in.Modulus)
+	att["replacement"] = in.Replacement
+	att["action"] = in.Action
+	return []interface{}{att}, nil
+}
+
+// genRuleSchema returns the schema of Rule. Rule describes an alerting or recording rule.
+func genRuleSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"record": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Name of the series recorded by recording rules.",
+		},
+		"alert": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Name of the alert, for alerting rules.",
+		},
+		"expr": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "PromQL expression of the rule.",
+		},
+		"for": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Duration the expression has to return a series for before its alert fires.",
+		},
+		"labels": {
+			Type:         schema.TypeMap,
+			Elem:         &schema.Schema{Type: schema.TypeString},
+			Optional:     true,
+			ValidateFunc: validateLabels,
+			Description:  "Labels added to the alerts or the recorded series.",
+		},
+		"annotations": {
+			Type:        schema.TypeMap,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Description: "Annotations added to the alerts of alerting rules.",
+		},
+	}
+}
+
+func genExpandRule(l []interface{}) (*po_types.Rule, error) {
+	obj := &po_types.Rule{}
+	if len(l) == 0 || l[0] == nil {
+		return obj, nil
+	}
+	in := l[0].(map[string]interface{})
+
+	if v, ok := in["record"].(string); ok && v != "" {
+		obj.Record = v
+	}
+	if v, ok := in["alert"].(string); ok && v != "" {
+		obj.Alert = v
+	}
+	if v, ok := in["expr"].(string); ok && v != "" {
+		obj.Expr = intstr.Parse(v)
+	}
+	if v, ok := in["for"].(string); ok && v != "" {
+		obj.For = v
+	}
+	if v, ok := in["labels"].(map[string]interface{}); ok && len(v) > 0 {
+		obj.Labels = expandStringMap(v)
+	}
+	if v, ok := in["annotations"].(map[string]interface{}); ok && len(v) > 0 {
+		obj.Annotations = expandStringMap(v)
+	}
+	return obj, nil
+}
+
+func genFlattenRule(in po_types.Rule) ([]interface{}, error) {
+	att := make(map[string]interface{})
+
+	att["record"] = in.Record
+	att["alert"] = in.Alert
+	att["expr"] = in.Expr.String()
+	att["for"] = in.For
+	att["labels"] = genFlattenStringMap(in.Labels)
+	att["annotations"] = genFlattenStringMap(in.Annotations)
+	return []interface{}{att}, nil
+}
+
+// genRuleGroupSchema returns the schema of RuleGroup. RuleGroup is a list of sequentially evaluated recording and alerting rules.
+func genRuleGroupSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Name of the rule group.",
+		},
+		"interval": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Interval at which the rules of the group are evaluated.",
+		},
+		"rules": {
+			Type: schema.TypeList,
+			Elem: &schema.Resource{
+				Schema: genRuleSchema(),
+			},
+			Optional:    true,
+			Description: "Alerting or recording rules of the group.",
+		},
+	}
+}
+
+func genExpandRuleGroup(l []interface{}) (*po_types.RuleGroup, error) {
+	obj := &po_types.RuleGroup{}
+	if len(l) == 0 || l[0] == nil {
+		return obj, nil
+	}
+	in := l[0].(map[string]interface{})
+
+	if v, ok := in["name"].(string); ok && v != "" {
+		obj.Name = v
+	}
+	if v, ok := in["interval"].(string); ok && v != "" {
+		obj.Interval = v
+	}
+	if v, ok := in["rules"].([]interface{}); ok && len(v) > 0 {
+		obj.Rules = make([]po_types.Rule, len(v))
+		for i, e := range v {
+			s, err := genExpandRule([]interface{}{e})
+			if err != nil {
+				return obj, err
+			}
+			obj.Rules[i] = *s
+		}
+	}
+	return obj, nil
+}
+
+func genFlattenRuleGroup(in po_types.RuleGroup) ([]interface{}, error) {
+	att := make(map[string]interface{})
+
+	att["name"] = in.Name
+	att["interval"] = in.Interval
+	if len(in.Rules) > 0 {
+		l := make([]interface{}, 0, len(in.Rules))
+		for _, e := range in.Rules {
+			s, err := genFlattenRule(e)
+			if err != nil {
+				return nil, err
+			}
+			l = append(l, s[0])
+		}
+		att["rules"] = l
+	}
+	return []interface{}{att}, nil
+}
+
+// genSecretOrConfigMapSchema returns the schema of SecretOrConfigMap. SecretOrConfigMap allows to specify data as a Secret or ConfigMap. Fields are mutually exclusive.
+func genSecretOrConfigMapSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"secret": {
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: SecretKeySelectorSchema(),
+			},
+			Optional:    true,
+			Description: "Secret containing data to use for the targets.",
+		},
+		"config_map": {
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: ConfigMapKeySelectorSchema(),
+			},
+			Optional:    true,
+			Description: "ConfigMap containing data to use for the targets.",
+		},
+	}
+}
+
+func genExpandSecretOrConfigMap(l []interface{}) (*po_types.SecretOrConfigMap, error) {
+	obj := &po_types.SecretOrConfigMap{}
+	if len(l) == 0 || l[0] == nil {
+		return obj, nil
+	}
+	in := l[0].(map[string]interface{})
+
+	if v, ok := in["secret"].([]interface{}); ok && len(v) > 0 {
+		x, err := expandSecretKeyRef(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.Secret = x
+	}
+	if v, ok := in["config_map"].([]interface{}); ok && len(v) > 0 {
+		x, err := expandConfigMapKeyRef(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.ConfigMap = x
+	}
+	return obj, nil
+}
+
+func genFlattenSecretOrConfigMap(in po_types.SecretOrConfigMap) ([]interface{}, error) {
+	att := make(map[string]interface{})
+
+	if in.Secret != nil {
+		att["secret"] = flattenSecretKeyRef(in.Secret)
+	}
+	if in.ConfigMap != nil {
+		att["config_map"] = flattenConfigMapKeyRef(in.ConfigMap)
+	}
+	return []interface{}{att}, nil
+}
+
+// genServiceMonitorSpecSchema returns the schema of ServiceMonitorSpec. ServiceMonitorSpec contains specification parameters for a ServiceMonitor.
+func genServiceMonitorSpecSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"job_label": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The label to use to retrieve the job name from.",
+		},
+		"target_labels": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Description: "TargetLabels transfers labels on the Kubernetes Service onto the target.",
+		},
+		"pod_target_labels": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Description: "PodTargetLabels transfers labels on the Kubernetes Pod onto the target.",
+		},
+		"endpoints": {
+			Type: schema.TypeList,
+			Elem: &schema.Resource{
+				Schema: genEndpointSchema(),
+			},
+			Optional:    true,
+			Description: "A list of endpoints allowed as part of this ServiceMonitor.",
+		},
+		"selector": {
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: labelSelectorFields(true),
+			},
+			Optional:    true,
+			Description: "Selector to select Endpoints objects.",
+		},
+		"namespace_selector": {
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: genNamespaceSelectorSchema(),
+			},
+			Optional:    true,
+			Description: "Selector to select which namespaces the Endpoints objects are discovered from.",
+		},
+		"sample_limit": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "SampleLimit defines per-scrape limit on number of scraped samples that will be accepted.",
+		},
+	}
+}
+
+func genExpandServiceMonitorSpec(l []interface{}) (*po_types.ServiceMonitorSpec, error) {
+	obj := &po_types.ServiceMonitorSpec{}
+	if len(l) == 0 || l[0] == nil {
+		return obj, nil
+	}
+	in := l[0].(map[string]interface{})
+
+	if v, ok := in["job_label"].(string); ok && v != "" {
+		obj.JobLabel = v
+	}
+	if v, ok := in["target_labels"].([]interface{}); ok && len(v) > 0 {
+		obj.TargetLabels = expandStringSlice(v)
+	}
+	if v, ok := in["pod_target_labels"].([]interface{}); ok && len(v) > 0 {
+		obj.PodTargetLabels = expandStringSlice(v)
+	}
+	if v, ok := in["endpoints"].([]interface{}); ok && len(v) > 0 {
+		obj.Endpoints = make([]po_types.Endpoint, len(v))
+		for i, e := range v {
+			s, err := genExpandEndpoint([]interface{}{e})
+			if err != nil {
+				return obj, err
+			}
+			obj.Endpoints[i] = *s
+		}
+	}
+	if v, ok := in["selector"].([]interface{}); ok && len(v) > 0 {
+		x := expandLabelSelector(v)
+		obj.Selector = *x
+	}
+	if v, ok := in["namespace_selector"].([]interface{}); ok && len(v) > 0 {
+		s, err := genExpandNamespaceSelector(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.NamespaceSelector = *s
+	}
+	if v, ok := in["sample_limit"].(int); ok && v != 0 {
+		obj.SampleLimit = uint64(v)
+	}
+	return obj, nil
+}
+
+func genFlattenServiceMonitorSpec(in po_types.ServiceMonitorSpec) ([]interface{}, error) {
+	att := make(map[string]interface{})
+
+	att["job_label"] = in.JobLabel
+	att["target_labels"] = genFlattenStringSlice(in.TargetLabels)
+	att["pod_target_labels"] = genFlattenStringSlice(in.PodTargetLabels)
+	if len(in.Endpoints) > 0 {
+		l := make([]interface{}, 0, len(in.Endpoints))
+		for _, e := range in.Endpoints {
+			s, err := genFlattenEndpoint(e)
+			if err != nil {
+				return nil, err
+			}
+			l = append(l, s[0])
+		}
+		att["endpoints"] = l
+	}
+	if !reflect.ValueOf(in.Selector).IsZero() {
+		att["selector"] = flattenLabelSelector(&in.Selector)
+	}
+	if !reflect.ValueOf(in.NamespaceSelector).IsZero() {
+		s, err := genFlattenNamespaceSelector(in.NamespaceSelector)
+		if err != nil {
+			return nil, err
+		}
+		att["namespace_selector"] = s
+	}
+	att["sample_limit"] = int(in.SampleLimit)
+	return []interface{}{att}, nil
+}
+
+// genTLSConfigSchema returns the schema of TLSConfig. TLSConfig specifies TLS configuration parameters.
+func genTLSConfigSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"ca_file": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Path to the CA cert in the Prometheus container to use for the targets.",
+		},
+		"ca": {
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: genSecretOrConfigMapSchema(),
+			},
+			Optional:    true,
+			Description: "Stuct containing the CA cert to use for the targets.",
+		},
+		"cert_file": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Path to the client cert file in the Prometheus container for the targets.",
+		},
+		"cert": {
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: genSecretOrConfigMapSchema(),
+			},
+			Optional:    true,
+			Description: "Struct containing the client cert file for the targets.",
+		},
+		"key_file": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Path to the client key file in the Prometheus container for the targets.",
+		},
+		"key_secret": {
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: SecretKeySelectorSchema(),
+			},
+			Optional:    true,
+			Description: "Secret containing the client key file for the targets.",
+		},
+		"server_name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Used to verify the hostname for the targets.",
+		},
+		"insecure_skip_verify": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Disable target certificate validation.",
+		},
+	}
+}
+
+func genExpandTLSConfig(l []interface{}) (*po_types.TLSConfig, error) {
+	obj := &po_types.TLSConfig{}
+	if len(l) == 0 || l[0] == nil {
+		return obj, nil
+	}
+	in := l[0].(map[string]interface{})
+
+	if v, ok := in["ca_file"].(string); ok && v != "" {
+		obj.CAFile = v
+	}
+	if v, ok := in["ca"].([]interface{}); ok && len(v) > 0 {
+		s, err := genExpandSecretOrConfigMap(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.CA = *s
+	}
+	if v, ok := in["cert_file"].(string); ok && v != "" {
+		obj.CertFile = v
+	}
+	if v, ok := in["cert"].([]interface{}); ok && len(v) > 0 {
+		s, err := genExpandSecretOrConfigMap(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.Cert = *s
+	}
+	if v, ok := in["key_file"].(string); ok && v != "" {
+		obj.KeyFile = v
+	}
+	if v, ok := in["key_secret"].([]interface{}); ok && len(v) > 0 {
+		x, err := expandSecretKeyRef(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.KeySecret = x
+	}
+	if v, ok := in["server_name"].(string); ok && v != "" {
+		obj.ServerName = v
+	}
+	if v, ok := in["insecure_skip_verify"].(bool); ok && v {
+		obj.InsecureSkipVerify = v
+	}
+	return obj, nil
+}
+
+func genFlattenTLSConfig(in po_types.TLSConfig) ([]interface{}, error) {
+	att := make(map[string]interface{})
+
+	att["ca_file"] = in.CAFile
+	if !reflect.ValueOf(in.CA).IsZero() {
+		s, err := genFlattenSecretOrConfigMap(in.CA)
+		if err != nil {
+			return nil, err
+		}
+		att["ca"] = s
+	}
+	att["cert_file"] = in.CertFile
+	if !reflect.ValueOf(in.Cert).IsZero() {
+		s, err := genFlattenSecretOrConfigMap(in.Cert)
+		if err != nil {
+			return nil, err
+		}
+		att["cert"] = s
+	}
+	att["key_file"] = in.KeyFile
+	if in.KeySecret != nil {
+		att["key_secret"] = flattenSecretKeyRef(in.KeySecret)
+	}
+	att["server_name"] = in.ServerName
+	att["insecure_skip_verify"] = in.InsecureSkipVerify
+	return []interface{}{att}, nil
+}
+
+func genFlattenStringSlice(in []string) []interface{} {
+	out := make([]interface{}, len(in))
+	for i, v := range in {
+		out[i] = v
+	}
+	return out
+}
+
+func genFlattenStringMap(in map[string]string) map[string]interface{} {
+	out := make(map[string]interface{}, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
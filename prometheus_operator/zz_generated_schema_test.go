@@ -0,0 +1,241 @@
+// Code generated by internal/codegen. DO NOT EDIT.
+
+package prometheus_operator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestGenAlertmanagerSpec_roundTrip(t *testing.T) {
+	s := genRoundTripSchema(genAlertmanagerSpecSchema())
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{
+		"spec": []interface{}{genSampleConfig(genAlertmanagerSpecSchema())},
+	})
+	expanded, err := genExpandAlertmanagerSpec(d.Get("spec").([]interface{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	flattened, err := genFlattenAlertmanagerSpec(*expanded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d = schema.TestResourceDataRaw(t, s, map[string]interface{}{})
+	if err := d.Set("spec", flattened); err != nil {
+		t.Fatal(err)
+	}
+	roundTripped, err := genExpandAlertmanagerSpec(d.Get("spec").([]interface{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Flattened values are compared, as helpers of external types don't
+	// preserve the difference between nil and empty values.
+	again, err := genFlattenAlertmanagerSpec(*roundTripped)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(genNormalize(flattened), genNormalize(again)) {
+		t.Errorf("AlertmanagerSpec differs after round trip:\n%#v\n%#v", flattened, again)
+	}
+}
+
+func TestGenPrometheusSpec_roundTrip(t *testing.T) {
+	s := genRoundTripSchema(genPrometheusSpecSchema())
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{
+		"spec": []interface{}{genSampleConfig(genPrometheusSpecSchema())},
+	})
+	expanded, err := genExpandPrometheusSpec(d.Get("spec").([]interface{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	flattened, err := genFlattenPrometheusSpec(*expanded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d = schema.TestResourceDataRaw(t, s, map[string]interface{}{})
+	if err := d.Set("spec", flattened); err != nil {
+		t.Fatal(err)
+	}
+	roundTripped, err := genExpandPrometheusSpec(d.Get("spec").([]interface{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Flattened values are compared, as helpers of external types don't
+	// preserve the difference between nil and empty values.
+	again, err := genFlattenPrometheusSpec(*roundTripped)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(genNormalize(flattened), genNormalize(again)) {
+		t.Errorf("PrometheusSpec differs after round trip:\n%#v\n%#v", flattened, again)
+	}
+}
+
+func TestGenRelabelConfig_roundTrip(t *testing.T) {
+	s := genRoundTripSchema(genRelabelConfigSchema())
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{
+		"spec": []interface{}{genSampleConfig(genRelabelConfigSchema())},
+	})
+	expanded, err := genExpandRelabelConfig(d.Get("spec").([]interface{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	flattened, err := genFlattenRelabelConfig(*expanded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d = schema.TestResourceDataRaw(t, s, map[string]interface{}{})
+	if err := d.Set("spec", flattened); err != nil {
+		t.Fatal(err)
+	}
+	roundTripped, err := genExpandRelabelConfig(d.Get("spec").([]interface{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Flattened values are compared, as helpers of external types don't
+	// preserve the difference between nil and empty values.
+	again, err := genFlattenRelabelConfig(*roundTripped)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(genNormalize(flattened), genNormalize(again)) {
+		t.Errorf("RelabelConfig differs after round trip:\n%#v\n%#v", flattened, again)
+	}
+}
+
+func TestGenRuleGroup_roundTrip(t *testing.T) {
+	s := genRoundTripSchema(genRuleGroupSchema())
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{
+		"spec": []interface{}{genSampleConfig(genRuleGroupSchema())},
+	})
+	expanded, err := genExpandRuleGroup(d.Get("spec").([]interface{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	flattened, err := genFlattenRuleGroup(*expanded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d = schema.TestResourceDataRaw(t, s, map[string]interface{}{})
+	if err := d.Set("spec", flattened); err != nil {
+		t.Fatal(err)
+	}
+	roundTripped, err := genExpandRuleGroup(d.Get("spec").([]interface{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Flattened values are compared, as helpers of external types don't
+	// preserve the difference between nil and empty values.
+	again, err := genFlattenRuleGroup(*roundTripped)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(genNormalize(flattened), genNormalize(again)) {
+		t.Errorf("RuleGroup differs after round trip:\n%#v\n%#v", flattened, again)
+	}
+}
+
+func TestGenServiceMonitorSpec_roundTrip(t *testing.T) {
+	s := genRoundTripSchema(genServiceMonitorSpecSchema())
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{
+		"spec": []interface{}{genSampleConfig(genServiceMonitorSpecSchema())},
+	})
+	expanded, err := genExpandServiceMonitorSpec(d.Get("spec").([]interface{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	flattened, err := genFlattenServiceMonitorSpec(*expanded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d = schema.TestResourceDataRaw(t, s, map[string]interface{}{})
+	if err := d.Set("spec", flattened); err != nil {
+		t.Fatal(err)
+	}
+	roundTripped, err := genExpandServiceMonitorSpec(d.Get("spec").([]interface{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Flattened values are compared, as helpers of external types don't
+	// preserve the difference between nil and empty values.
+	again, err := genFlattenServiceMonitorSpec(*roundTripped)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(genNormalize(flattened), genNormalize(again)) {
+		t.Errorf("ServiceMonitorSpec differs after round trip:\n%#v\n%#v", flattened, again)
+	}
+}
+
+func genRoundTripSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"spec": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem:     &schema.Resource{Schema: s},
+		},
+	}
+}
+
+// genNormalize replaces sets, which can't be compared with reflect.DeepEqual,
+// by lists.
+func genNormalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case *schema.Set:
+		return genNormalize(v.List())
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, e := range v {
+			out[i] = genNormalize(e)
+		}
+		return out
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			out[k] = genNormalize(e)
+		}
+		return out
+	}
+	return v
+}
+
+// genSampleConfig returns a configuration setting every attribute, except
+// validated attributes, which need meaningful values.
+func genSampleConfig(s map[string]*schema.Schema) map[string]interface{} {
+	out := map[string]interface{}{}
+	for k, v := range s {
+		if (v.Computed && !v.Optional) || v.ValidateFunc != nil {
+			continue
+		}
+		switch v.Type {
+		case schema.TypeString:
+			out[k] = "sample"
+		case schema.TypeBool:
+			out[k] = true
+		case schema.TypeInt:
+			out[k] = 1
+		case schema.TypeFloat:
+			out[k] = 1.5
+		case schema.TypeMap:
+			out[k] = map[string]interface{}{"key": "value"}
+		case schema.TypeList, schema.TypeSet:
+			switch e := v.Elem.(type) {
+			case *schema.Resource:
+				out[k] = []interface{}{genSampleConfig(e.Schema)}
+			case *schema.Schema:
+				if e.Type == schema.TypeString {
+					out[k] = []interface{}{"sample"}
+				}
+			}
+		}
+	}
+	return out
+}